@@ -121,6 +121,11 @@ func Run() {
 	// 创建Gin引擎
 	logger.Infof("Creating HTTP routes...")
 	router := gin.New()
+	// 默认信任所有来源的 X-Forwarded-For，客户端可以伪造 IP 绕过登录风控、污染审计记录；只信任配置的入口网关
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal(fmt.Sprintf("Invalid server.trusted_proxies: %v", err))
+		return
+	}
 	router.Use(
 		gin.Recovery(),
 		middleware.RequestContextMiddleware(),
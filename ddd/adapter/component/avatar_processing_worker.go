@@ -0,0 +1,75 @@
+package component
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainservice "user-service/ddd/domain/service"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+	"user-service/pkg/manager"
+)
+
+// AvatarProcessingWorkerPlugin wires the avatar processing worker into the component system.
+type AvatarProcessingWorkerPlugin struct{}
+
+func (p *AvatarProcessingWorkerPlugin) Name() string { return "avatarProcessingWorker" }
+
+func (p *AvatarProcessingWorkerPlugin) MustCreateComponent(deps *manager.Dependencies) manager.Component {
+	interval := 3 * time.Second
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Profile.AvatarJobPollInterval > 0 {
+		interval = cfg.Profile.AvatarJobPollInterval
+	}
+	return &avatarProcessingWorker{
+		processor: domainservice.NewAvatarProcessor(),
+		interval:  interval,
+	}
+}
+
+// avatarProcessingWorker 轮询头像处理任务，生成多尺寸头像。
+type avatarProcessingWorker struct {
+	processor *domainservice.AvatarProcessor
+	interval  time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+func (w *avatarProcessingWorker) Start() error {
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.wg.Add(1)
+	go w.loop()
+	logger.Infof("AvatarProcessingWorker started interval=%s", w.interval)
+	return nil
+}
+
+func (w *avatarProcessingWorker) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	return nil
+}
+
+func (w *avatarProcessingWorker) GetName() string { return "avatarProcessingWorker" }
+
+func (w *avatarProcessingWorker) loop() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.processor.RunPending(w.ctx); err != nil {
+				logger.Warnf("AvatarProcessingWorker run pending error=%v", err)
+			}
+		}
+	}
+}
+
+func init() {
+	manager.RegisterComponentPlugin(&AvatarProcessingWorkerPlugin{})
+}
@@ -9,10 +9,11 @@ import (
 	"sync"
 	"time"
 
-	drepo "user-service/ddd/domain/repo"
-	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/domain/entity"
+	domainservice "user-service/ddd/domain/service"
 	kafkainfra "user-service/ddd/infrastructure/kafka"
 	"user-service/pkg/config"
+	"user-service/pkg/errno"
 	pkgkafka "user-service/pkg/kafka"
 	"user-service/pkg/logger"
 	"user-service/pkg/manager"
@@ -21,18 +22,22 @@ import (
 )
 
 // FollowEventConsumerPlugin wires the follow-event Kafka consumer into the component system.
+// Follow writes now go to MySQL directly (with an outbox event), so this consumer only
+// drains follow commands still sitting in user.follow.events. They go through the same
+// SocialService path as the HTTP API (block check, private-account requests, outbox
+// event); commands that path refuses are dropped.
 type FollowEventConsumerPlugin struct{}
 
 func (p *FollowEventConsumerPlugin) Name() string { return "followEventConsumer" }
 
 func (p *FollowEventConsumerPlugin) MustCreateComponent(deps *manager.Dependencies) manager.Component {
 	return &followEventConsumer{
-		repo: persistence.NewFollowRepository(),
+		social: domainservice.NewSocialService(),
 	}
 }
 
 type followEventConsumer struct {
-	repo   drepo.FollowRepository
+	social *domainservice.SocialService
 	ctx    context.Context
 	cancel context.CancelFunc
 	reader *kafka.Reader
@@ -124,15 +129,22 @@ func (c *followEventConsumer) handleMessage(msg kafka.Message) error {
 		"offset":      msg.Offset,
 	}).Info("FollowEventConsumer handling event")
 
-	switch op {
-	case kafkainfra.FollowOpFollow:
-		return c.repo.Follow(c.ctx, ev.UserUUID, ev.TargetUUID)
-	case kafkainfra.FollowOpUnfollow:
-		return c.repo.Unfollow(c.ctx, ev.UserUUID, ev.TargetUUID)
-	default:
+	if op != kafkainfra.FollowOpFollow && op != kafkainfra.FollowOpUnfollow {
 		logger.Warnf("FollowEventConsumer ignore unknown op op=%s user=%s target=%s", ev.Op, ev.UserUUID, ev.TargetUUID)
 		return nil
 	}
+	// 状态转换带条件，重复投递的命令不会重复计数
+	_, err := c.social.ToggleFollow(c.ctx, entity.NewFollowEntity(ev.UserUUID, ev.TargetUUID, op))
+	var (
+		refused *errno.Errno
+		bizErr  errno.BizError
+	)
+	if errors.As(err, &refused) || errors.As(err, &bizErr) {
+		// 拉黑等业务规则拒绝的命令重试也不会成功，记录后丢弃
+		logger.Warnf("FollowEventConsumer drop refused command op=%s user=%s target=%s err=%v", op, ev.UserUUID, ev.TargetUUID, err)
+		return nil
+	}
+	return err
 }
 
 func init() {
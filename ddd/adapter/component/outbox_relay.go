@@ -0,0 +1,109 @@
+package component
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainservice "user-service/ddd/domain/service"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+	"user-service/pkg/manager"
+)
+
+const outboxCleanupInterval = time.Hour
+
+// OutboxRelayPlugin wires the event outbox relay into the component system.
+type OutboxRelayPlugin struct{}
+
+func (p *OutboxRelayPlugin) Name() string { return "outboxRelay" }
+
+func (p *OutboxRelayPlugin) MustCreateComponent(deps *manager.Dependencies) manager.Component {
+	interval := time.Second
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Outbox.PollInterval > 0 {
+		interval = cfg.Outbox.PollInterval
+	}
+	return &outboxRelay{
+		relay:    domainservice.NewOutboxRelay(),
+		interval: interval,
+	}
+}
+
+// outboxRelay 轮询发件箱并投递到 Kafka；整轮没有投递成功且出错时按间隔倍增退避（上限 1 分钟），
+// 单条事件的重试间隔由 OutboxRelay 控制。每小时清理一次过期事件并检查 failed 事件。
+type outboxRelay struct {
+	relay    *domainservice.OutboxRelay
+	interval time.Duration
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func (w *outboxRelay) Start() error {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil || !cfg.Kafka.Enabled {
+		logger.Info("OutboxRelay skipped because Kafka is disabled")
+		return nil
+	}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.wg.Add(1)
+	go w.loop()
+	logger.Infof("OutboxRelay started interval=%s", w.interval)
+	return nil
+}
+
+func (w *outboxRelay) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	if w.ctx != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		w.relay.Resign(ctx)
+	}
+	return nil
+}
+
+func (w *outboxRelay) GetName() string { return "outboxRelay" }
+
+func (w *outboxRelay) loop() {
+	defer w.wg.Done()
+	delay := w.interval
+	lastCleanup := time.Now()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-timer.C:
+		}
+		sent, err := w.relay.RelayPending(w.ctx)
+		if err != nil {
+			logger.Warnf("OutboxRelay relay error sent=%d error=%v", sent, err)
+		}
+		switch {
+		case err != nil && sent == 0:
+			delay = min(max(delay, w.interval)*2, time.Minute)
+		case sent > 0:
+			// 还有积压时不等待，直接处理下一批
+			delay = 0
+		default:
+			delay = w.interval
+		}
+		if time.Since(lastCleanup) >= outboxCleanupInterval {
+			lastCleanup = time.Now()
+			if n, err := w.relay.Cleanup(w.ctx); err != nil {
+				logger.Warnf("OutboxRelay cleanup error=%v", err)
+			} else if n > 0 {
+				logger.Infof("OutboxRelay cleaned sent events=%d", n)
+			}
+		}
+		timer.Reset(delay)
+	}
+}
+
+func init() {
+	manager.RegisterComponentPlugin(&OutboxRelayPlugin{})
+}
@@ -0,0 +1,100 @@
+package component
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainservice "user-service/ddd/domain/service"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+	"user-service/pkg/manager"
+)
+
+// RecommendationBuilderPlugin wires the who-to-follow precompute loop into the component system.
+type RecommendationBuilderPlugin struct{}
+
+func (p *RecommendationBuilderPlugin) Name() string { return "recommendationBuilder" }
+
+func (p *RecommendationBuilderPlugin) MustCreateComponent(deps *manager.Dependencies) manager.Component {
+	interval := 30 * time.Minute
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Recommend.RebuildInterval > 0 {
+		interval = cfg.Recommend.RebuildInterval
+	}
+	return &recommendationBuilder{
+		recommend: domainservice.NewRecommendService(),
+		interval:  interval,
+	}
+}
+
+// recommendationBuilder 定期刷新热门账号，并为近期活跃用户预计算推荐关注列表写入缓存。
+// 未覆盖到的用户在首次访问时现算。多副本部署时各副本定期争抢同一个 Redis 租约，
+// 只有持有者按 interval 预计算并持续续期，持有者退出或失联后由其他副本接手
+type recommendationBuilder struct {
+	recommend *domainservice.RecommendService
+	interval  time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+func (w *recommendationBuilder) Start() error {
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.wg.Add(1)
+	go w.loop()
+	logger.Infof("RecommendationBuilder started interval=%s", w.interval)
+	return nil
+}
+
+func (w *recommendationBuilder) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	if w.ctx != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		w.recommend.Resign(ctx)
+	}
+	return nil
+}
+
+func (w *recommendationBuilder) GetName() string { return "recommendationBuilder" }
+
+// loop 每 1/3 租约有效期检查一次租约：持有期间保持续期，距上次预计算满 interval 时再次计算。
+// 刚取得租约的副本不知道前任的进度，立即计算一轮
+func (w *recommendationBuilder) loop() {
+	defer w.wg.Done()
+	var lastBuilt time.Time
+	ticker := time.NewTicker(domainservice.RecommendLeaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		if w.recommend.Lead(w.ctx) {
+			if time.Since(lastBuilt) >= w.interval {
+				w.rebuild()
+				lastBuilt = time.Now()
+			}
+		} else {
+			lastBuilt = time.Time{}
+		}
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *recommendationBuilder) rebuild() {
+	start := time.Now()
+	n, err := w.recommend.RebuildActive(w.ctx)
+	if err != nil {
+		logger.Warnf("RecommendationBuilder rebuild error=%v users=%d", err, n)
+		return
+	}
+	logger.Infof("RecommendationBuilder rebuilt users=%d cost=%s", n, time.Since(start))
+}
+
+func init() {
+	manager.RegisterComponentPlugin(&RecommendationBuilderPlugin{})
+}
@@ -0,0 +1,37 @@
+package component
+
+import (
+	domainservice "user-service/ddd/domain/service"
+	"user-service/pkg/logger"
+	"user-service/pkg/manager"
+)
+
+// SecurityEventWriterPlugin wires the asynchronous security audit writer into the component system.
+type SecurityEventWriterPlugin struct{}
+
+func (p *SecurityEventWriterPlugin) Name() string { return "securityEventWriter" }
+
+func (p *SecurityEventWriterPlugin) MustCreateComponent(deps *manager.Dependencies) manager.Component {
+	return &securityEventWriter{recorder: domainservice.DefaultSecurityEventRecorder()}
+}
+
+type securityEventWriter struct {
+	recorder *domainservice.SecurityEventRecorder
+}
+
+func (w *securityEventWriter) Start() error {
+	w.recorder.Start()
+	logger.Info("SecurityEventWriter started")
+	return nil
+}
+
+func (w *securityEventWriter) Stop() error {
+	w.recorder.Stop()
+	return nil
+}
+
+func (w *securityEventWriter) GetName() string { return "securityEventWriter" }
+
+func init() {
+	manager.RegisterComponentPlugin(&SecurityEventWriterPlugin{})
+}
@@ -0,0 +1,78 @@
+package component
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainservice "user-service/ddd/domain/service"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+	"user-service/pkg/manager"
+)
+
+// UserExportWorkerPlugin wires the personal data export worker into the component system.
+type UserExportWorkerPlugin struct{}
+
+func (p *UserExportWorkerPlugin) Name() string { return "userExportWorker" }
+
+func (p *UserExportWorkerPlugin) MustCreateComponent(deps *manager.Dependencies) manager.Component {
+	interval := 5 * time.Second
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Export.PollInterval > 0 {
+		interval = cfg.Export.PollInterval
+	}
+	return &userExportWorker{
+		svc:      domainservice.NewExportService(),
+		interval: interval,
+	}
+}
+
+// userExportWorker 轮询待处理的导出任务并生成导出包，同时清理过期文件。
+type userExportWorker struct {
+	svc      *domainservice.ExportService
+	interval time.Duration
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func (w *userExportWorker) Start() error {
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.wg.Add(1)
+	go w.loop()
+	logger.Infof("UserExportWorker started interval=%s", w.interval)
+	return nil
+}
+
+func (w *userExportWorker) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	return nil
+}
+
+func (w *userExportWorker) GetName() string { return "userExportWorker" }
+
+func (w *userExportWorker) loop() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.svc.RunPending(w.ctx); err != nil {
+				logger.Warnf("UserExportWorker run pending error=%v", err)
+			}
+			if err := w.svc.CleanupExpired(w.ctx); err != nil {
+				logger.Warnf("UserExportWorker cleanup error=%v", err)
+			}
+		}
+	}
+}
+
+func init() {
+	manager.RegisterComponentPlugin(&UserExportWorkerPlugin{})
+}
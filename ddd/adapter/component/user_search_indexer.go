@@ -0,0 +1,85 @@
+package component
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainservice "user-service/ddd/domain/service"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+	"user-service/pkg/manager"
+)
+
+// UserSearchIndexerPlugin wires the user search index rebuild loop into the component system.
+type UserSearchIndexerPlugin struct{}
+
+func (p *UserSearchIndexerPlugin) Name() string { return "userSearchIndexer" }
+
+func (p *UserSearchIndexerPlugin) MustCreateComponent(deps *manager.Dependencies) manager.Component {
+	interval := 10 * time.Minute
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Search.RebuildInterval > 0 {
+		interval = cfg.Search.RebuildInterval
+	}
+	return &userSearchIndexer{
+		search:   domainservice.NewUserSearchService(),
+		interval: interval,
+	}
+}
+
+// userSearchIndexer 启动时全量构建用户搜索索引，之后定期重建以刷新粉丝数排序。
+// 资料变更由 UserApp 增量写入索引，重建用于修正增量失败以及其他实例上的变更。
+type userSearchIndexer struct {
+	search   *domainservice.UserSearchService
+	interval time.Duration
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func (w *userSearchIndexer) Start() error {
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.wg.Add(1)
+	go w.loop()
+	logger.Infof("UserSearchIndexer started interval=%s", w.interval)
+	return nil
+}
+
+func (w *userSearchIndexer) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	return nil
+}
+
+func (w *userSearchIndexer) GetName() string { return "userSearchIndexer" }
+
+func (w *userSearchIndexer) loop() {
+	defer w.wg.Done()
+	w.rebuild()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.rebuild()
+		}
+	}
+}
+
+func (w *userSearchIndexer) rebuild() {
+	start := time.Now()
+	n, err := w.search.Rebuild(w.ctx)
+	if err != nil {
+		logger.Warnf("UserSearchIndexer rebuild error=%v", err)
+		return
+	}
+	logger.Infof("UserSearchIndexer rebuilt users=%d cost=%s", n, time.Since(start))
+}
+
+func init() {
+	manager.RegisterComponentPlugin(&UserSearchIndexerPlugin{})
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"user-service/pkg/errno"
+	"user-service/pkg/restapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setVersionETag 以资料版本号作为 ETag，形如 "v3"
+func setVersionETag(ctx *gin.Context, version uint64) {
+	ctx.Header("ETag", `"v`+strconv.FormatUint(version, 10)+`"`)
+}
+
+// parseIfMatch 解析 If-Match 头中的版本号；未携带或为 * 时返回 nil
+func parseIfMatch(ctx *gin.Context) (*uint64, error) {
+	raw := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if raw == "" || raw == "*" {
+		return nil, nil
+	}
+	tag, _, _ := strings.Cut(raw, ",")
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	tag = strings.TrimPrefix(strings.Trim(tag, `"`), "v")
+	version, err := strconv.ParseUint(tag, 10, 64)
+	if err != nil {
+		return nil, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "If-Match")
+	}
+	return &version, nil
+}
+
+// failedWithConflict 版本冲突返回 412，其余错误按常规处理
+func failedWithConflict(ctx *gin.Context, err error) {
+	if errors.Is(err, errno.ErrUserVersionConflict) {
+		restapi.FailedWithStatus(ctx, err, http.StatusPreconditionFailed)
+		return
+	}
+	restapi.Failed(ctx, err)
+}
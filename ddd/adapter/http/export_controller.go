@@ -0,0 +1,117 @@
+package http
+
+import (
+	"fmt"
+	"sync"
+	"user-service/ddd/application/app"
+	"user-service/pkg/assert"
+	"user-service/pkg/authctx"
+	"user-service/pkg/errno"
+	"user-service/pkg/manager"
+	"user-service/pkg/middleware"
+	"user-service/pkg/restapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	exportControllerOnce      sync.Once
+	singletonExportController ExportController
+)
+
+type ExportControllerPlugin struct{}
+
+func (p *ExportControllerPlugin) Name() string {
+	return "exportControllerPlugin"
+}
+
+func (p *ExportControllerPlugin) MustCreateController() manager.Controller {
+	assert.NotCircular()
+	exportControllerOnce.Do(func() {
+		singletonExportController = &exportControllerImpl{
+			exportApp: app.DefaultExportApp(),
+		}
+	})
+	assert.NotNil(singletonExportController)
+	return singletonExportController
+}
+
+type ExportController interface {
+	manager.Controller
+	RequestExport(ctx *gin.Context)
+	GetExport(ctx *gin.Context)
+	Download(ctx *gin.Context)
+}
+
+type exportControllerImpl struct {
+	manager.Controller
+	exportApp app.ExportApp
+}
+
+func (c *exportControllerImpl) RegisterOpenApi(router *gin.RouterGroup) {
+	v1 := router.Group("user/v1/open/exports")
+	{
+		// 下载链接自带签名令牌，无需登录态
+		v1.GET("/download", c.Download)
+	}
+}
+
+func (c *exportControllerImpl) RegisterInnerApi(router *gin.RouterGroup) {
+	v1 := router.Group("user/v1/inner/exports")
+	{
+		v1.POST("", middleware.AuthRequired(), c.RequestExport)
+		v1.GET("/:export_uuid", middleware.AuthRequired(), c.GetExport)
+	}
+}
+
+func (c *exportControllerImpl) RegisterDebugApi(router *gin.RouterGroup) {}
+func (c *exportControllerImpl) RegisterOpsApi(router *gin.RouterGroup)   {}
+
+// RequestExport 发起个人数据导出，异步生成
+func (c *exportControllerImpl) RequestExport(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	res, err := c.exportApp.RequestExport(ctx.Request.Context(), userUUID)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// GetExport 查询导出任务状态
+func (c *exportControllerImpl) GetExport(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	res, err := c.exportApp.GetExport(ctx.Request.Context(), userUUID, ctx.Param("export_uuid"))
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// Download 通过签名链接下载导出包
+func (c *exportControllerImpl) Download(ctx *gin.Context) {
+	token := ctx.Query("token")
+	if token == "" {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "token"))
+		return
+	}
+	filename, rc, err := c.exportApp.OpenDownload(ctx.Request.Context(), token)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	defer rc.Close()
+	ctx.DataFromReader(200, -1, "application/zip", rc, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, filename),
+		"Cache-Control":       "no-store",
+	})
+}
@@ -8,4 +8,14 @@ func init() {
 	manager.RegisterControllerPlugin(&UserControllerPlugin{})
 	// 注册社交控制器插件
 	manager.RegisterControllerPlugin(&SocialControllerPlugin{})
+	// 注册数据导出控制器插件
+	manager.RegisterControllerPlugin(&ExportControllerPlugin{})
+	// 注册安全审计控制器插件
+	manager.RegisterControllerPlugin(&SecurityControllerPlugin{})
+	// 注册头像/封面上传控制器插件
+	manager.RegisterControllerPlugin(&MediaControllerPlugin{})
+	// 注册敏感词运维控制器插件
+	manager.RegisterControllerPlugin(&ModerationControllerPlugin{})
+	// 注册偏好设置控制器插件
+	manager.RegisterControllerPlugin(&PreferenceControllerPlugin{})
 }
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"sync"
+	"user-service/ddd/application/app"
+	"user-service/ddd/application/cqe"
+	"user-service/pkg/assert"
+	"user-service/pkg/authctx"
+	"user-service/pkg/manager"
+	"user-service/pkg/middleware"
+	"user-service/pkg/restapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	mediaControllerOnce      sync.Once
+	singletonMediaController MediaController
+)
+
+type MediaControllerPlugin struct{}
+
+func (p *MediaControllerPlugin) Name() string {
+	return "mediaControllerPlugin"
+}
+
+func (p *MediaControllerPlugin) MustCreateController() manager.Controller {
+	assert.NotCircular()
+	mediaControllerOnce.Do(func() {
+		singletonMediaController = &mediaControllerImpl{
+			mediaApp: app.DefaultMediaApp(),
+		}
+	})
+	assert.NotNil(singletonMediaController)
+	return singletonMediaController
+}
+
+type MediaController interface {
+	manager.Controller
+	RequestUpload(ctx *gin.Context)
+	CompleteUpload(ctx *gin.Context)
+}
+
+type mediaControllerImpl struct {
+	manager.Controller
+	mediaApp app.MediaApp
+}
+
+func (c *mediaControllerImpl) RegisterOpenApi(router *gin.RouterGroup) {}
+
+func (c *mediaControllerImpl) RegisterInnerApi(router *gin.RouterGroup) {
+	v1 := router.Group("user/v1/inner/users/media")
+	{
+		v1.POST("/upload-url", middleware.AuthRequired(), c.RequestUpload)
+		v1.POST("/complete", middleware.AuthRequired(), c.CompleteUpload)
+	}
+}
+
+func (c *mediaControllerImpl) RegisterDebugApi(router *gin.RouterGroup) {}
+func (c *mediaControllerImpl) RegisterOpsApi(router *gin.RouterGroup)   {}
+
+// RequestUpload 申请头像/封面预签名直传 URL
+func (c *mediaControllerImpl) RequestUpload(ctx *gin.Context) {
+	var req cqe.MediaUploadReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	result, err := c.mediaApp.RequestUpload(ctx.Request.Context(), userUUID, &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
+
+// CompleteUpload 直传完成回调，校验通过后更新资料
+func (c *mediaControllerImpl) CompleteUpload(ctx *gin.Context) {
+	var req cqe.MediaCompleteReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	result, err := c.mediaApp.CompleteUpload(ctx.Request.Context(), userUUID, &req)
+	if err != nil {
+		failedWithConflict(ctx, err)
+		return
+	}
+	setVersionETag(ctx, result.Version)
+	restapi.Success(ctx, result)
+}
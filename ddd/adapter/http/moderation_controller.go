@@ -0,0 +1,83 @@
+package http
+
+import (
+	"sync"
+	"user-service/ddd/application/app"
+	"user-service/ddd/application/cqe"
+	"user-service/pkg/assert"
+	"user-service/pkg/errno"
+	"user-service/pkg/manager"
+	"user-service/pkg/restapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	moderationControllerOnce      sync.Once
+	singletonModerationController ModerationController
+)
+
+type ModerationControllerPlugin struct{}
+
+func (p *ModerationControllerPlugin) Name() string {
+	return "moderationControllerPlugin"
+}
+
+func (p *ModerationControllerPlugin) MustCreateController() manager.Controller {
+	assert.NotCircular()
+	moderationControllerOnce.Do(func() {
+		singletonModerationController = &moderationControllerImpl{
+			moderationApp: app.DefaultModerationApp(),
+		}
+	})
+	assert.NotNil(singletonModerationController)
+	return singletonModerationController
+}
+
+type ModerationController interface {
+	manager.Controller
+	Check(ctx *gin.Context)
+	Reload(ctx *gin.Context)
+}
+
+type moderationControllerImpl struct {
+	manager.Controller
+	moderationApp app.ModerationApp
+}
+
+func (c *moderationControllerImpl) RegisterOpenApi(router *gin.RouterGroup)  {}
+func (c *moderationControllerImpl) RegisterInnerApi(router *gin.RouterGroup) {}
+func (c *moderationControllerImpl) RegisterDebugApi(router *gin.RouterGroup) {}
+
+func (c *moderationControllerImpl) RegisterOpsApi(router *gin.RouterGroup) {
+	v1 := router.Group("/user/v1/ops/moderation")
+	{
+		v1.POST("/check", c.Check)
+		v1.POST("/reload", c.Reload)
+	}
+}
+
+// Check 测试文本命中的敏感词
+func (c *moderationControllerImpl) Check(ctx *gin.Context) {
+	var req cqe.ModerationCheckReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "text"))
+		return
+	}
+	result, err := c.moderationApp.Check(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
+
+// Reload 立即重新加载敏感词词表
+func (c *moderationControllerImpl) Reload(ctx *gin.Context) {
+	result, err := c.moderationApp.Reload(ctx.Request.Context())
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/json"
+	"sync"
+	"user-service/ddd/application/app"
+	"user-service/ddd/application/cqe"
+	"user-service/pkg/assert"
+	"user-service/pkg/authctx"
+	"user-service/pkg/errno"
+	"user-service/pkg/manager"
+	"user-service/pkg/middleware"
+	"user-service/pkg/restapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	preferenceControllerOnce      sync.Once
+	singletonPreferenceController PreferenceController
+)
+
+type PreferenceControllerPlugin struct{}
+
+func (p *PreferenceControllerPlugin) Name() string {
+	return "preferenceControllerPlugin"
+}
+
+func (p *PreferenceControllerPlugin) MustCreateController() manager.Controller {
+	assert.NotCircular()
+	preferenceControllerOnce.Do(func() {
+		singletonPreferenceController = &preferenceControllerImpl{
+			preferenceApp: app.DefaultPreferenceApp(),
+		}
+	})
+	assert.NotNil(singletonPreferenceController)
+	return singletonPreferenceController
+}
+
+type PreferenceController interface {
+	manager.Controller
+	GetPreferences(ctx *gin.Context)
+	PutPreferences(ctx *gin.Context)
+	PatchPreferences(ctx *gin.Context)
+	BatchGetPreferences(ctx *gin.Context)
+}
+
+type preferenceControllerImpl struct {
+	manager.Controller
+	preferenceApp app.PreferenceApp
+}
+
+func (c *preferenceControllerImpl) RegisterOpenApi(router *gin.RouterGroup) {}
+
+func (c *preferenceControllerImpl) RegisterInnerApi(router *gin.RouterGroup) {
+	v1 := router.Group("user/v1/inner/users/preferences")
+	{
+		v1.GET("", middleware.AuthRequired(), c.GetPreferences)
+		v1.PUT("", middleware.AuthRequired(), c.PutPreferences)
+		v1.PATCH("", middleware.AuthRequired(), c.PatchPreferences)
+	}
+	// 服务间批量读取，调用方需带服务间签名（见 middleware.ServiceAuthRequired）
+	router.POST("user/v1/inner/preferences/batch", middleware.ServiceAuthRequired(), c.BatchGetPreferences)
+}
+
+func (c *preferenceControllerImpl) RegisterDebugApi(router *gin.RouterGroup) {}
+func (c *preferenceControllerImpl) RegisterOpsApi(router *gin.RouterGroup)   {}
+
+// GetPreferences 当前用户的偏好设置，未设置的项返回默认值
+func (c *preferenceControllerImpl) GetPreferences(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	result, err := c.preferenceApp.GetPreferences(ctx.Request.Context(), userUUID)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
+
+// PutPreferences 整体替换偏好设置，未传的项恢复默认值
+func (c *preferenceControllerImpl) PutPreferences(ctx *gin.Context) {
+	userUUID, values, ok := c.bindValues(ctx)
+	if !ok {
+		return
+	}
+	result, err := c.preferenceApp.PutPreferences(ctx.Request.Context(), userUUID, values)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
+
+// PatchPreferences 部分更新偏好设置，值为 null 的项恢复默认值
+func (c *preferenceControllerImpl) PatchPreferences(ctx *gin.Context) {
+	userUUID, values, ok := c.bindValues(ctx)
+	if !ok {
+		return
+	}
+	result, err := c.preferenceApp.PatchPreferences(ctx.Request.Context(), userUUID, values)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
+
+// BatchGetPreferences 批量读取多个用户的偏好设置（服务间调用）
+func (c *preferenceControllerImpl) BatchGetPreferences(ctx *gin.Context) {
+	var req cqe.PreferenceBatchReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "user_uuids"))
+		return
+	}
+	result, err := c.preferenceApp.BatchGetPreferences(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
+
+func (c *preferenceControllerImpl) bindValues(ctx *gin.Context) (string, map[string]json.RawMessage, bool) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return "", nil, false
+	}
+	var values map[string]json.RawMessage
+	if err := ctx.ShouldBindJSON(&values); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "body"))
+		return "", nil, false
+	}
+	return userUUID, values, true
+}
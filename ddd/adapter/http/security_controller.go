@@ -0,0 +1,101 @@
+package http
+
+import (
+	"sync"
+	"user-service/ddd/application/app"
+	"user-service/ddd/application/cqe"
+	"user-service/pkg/assert"
+	"user-service/pkg/authctx"
+	"user-service/pkg/errno"
+	"user-service/pkg/manager"
+	"user-service/pkg/middleware"
+	"user-service/pkg/restapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	securityControllerOnce      sync.Once
+	singletonSecurityController SecurityController
+)
+
+type SecurityControllerPlugin struct{}
+
+func (p *SecurityControllerPlugin) Name() string {
+	return "securityControllerPlugin"
+}
+
+func (p *SecurityControllerPlugin) MustCreateController() manager.Controller {
+	assert.NotCircular()
+	securityControllerOnce.Do(func() {
+		singletonSecurityController = &securityControllerImpl{
+			securityApp: app.DefaultSecurityApp(),
+		}
+	})
+	assert.NotNil(singletonSecurityController)
+	return singletonSecurityController
+}
+
+type SecurityController interface {
+	manager.Controller
+	ListMyEvents(ctx *gin.Context)
+	ListEvents(ctx *gin.Context)
+}
+
+type securityControllerImpl struct {
+	manager.Controller
+	securityApp app.SecurityApp
+}
+
+func (c *securityControllerImpl) RegisterOpenApi(router *gin.RouterGroup) {}
+
+func (c *securityControllerImpl) RegisterInnerApi(router *gin.RouterGroup) {
+	v1 := router.Group("user/v1/inner/security")
+	{
+		v1.GET("/events", middleware.AuthRequired(), c.ListMyEvents)
+	}
+}
+
+func (c *securityControllerImpl) RegisterDebugApi(router *gin.RouterGroup) {}
+
+func (c *securityControllerImpl) RegisterOpsApi(router *gin.RouterGroup) {
+	v1 := router.Group("/user/v1/ops/security")
+	{
+		v1.GET("/events", c.ListEvents)
+	}
+}
+
+// ListMyEvents 当前用户的登录历史与安全事件（分页）
+func (c *securityControllerImpl) ListMyEvents(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	var page restapi.PageQuery
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "page"))
+		return
+	}
+	list, total, err := c.securityApp.ListMyEvents(ctx.Request.Context(), userUUID, &page)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.SuccessWithPage(ctx, page, list, total)
+}
+
+// ListEvents 运维查询安全事件，按 user_uuid / ip 过滤
+func (c *securityControllerImpl) ListEvents(ctx *gin.Context) {
+	var query cqe.SecurityEventQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "query"))
+		return
+	}
+	list, total, err := c.securityApp.ListEvents(ctx.Request.Context(), &query)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.SuccessWithPage(ctx, query.PageQuery, list, total)
+}
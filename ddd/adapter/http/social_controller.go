@@ -40,6 +40,23 @@ type SocialController interface {
 	manager.Controller
 	ToggleFollow(ctx *gin.Context)
 	GetUserRelation(ctx *gin.Context)
+	ListFollowers(ctx *gin.Context)
+	ListFollowings(ctx *gin.Context)
+	ListFriends(ctx *gin.Context)
+	IsFriend(ctx *gin.Context)
+	ListFollowRequests(ctx *gin.Context)
+	ResolveFollowRequest(ctx *gin.Context)
+	CancelFollowRequest(ctx *gin.Context)
+	RemoveFollower(ctx *gin.Context)
+	BatchFollowStatus(ctx *gin.Context)
+	CommonFollowers(ctx *gin.Context)
+	BatchFollowStatusInner(ctx *gin.Context)
+	ToggleBlock(ctx *gin.Context)
+	ListBlocks(ctx *gin.Context)
+	CheckBlock(ctx *gin.Context)
+	BatchCheckBlock(ctx *gin.Context)
+	Suggestions(ctx *gin.Context)
+	DismissSuggestion(ctx *gin.Context)
 }
 
 type socialControllerImpl struct {
@@ -51,6 +68,15 @@ func (c *socialControllerImpl) RegisterOpenApi(router *gin.RouterGroup) {
 	v1 := router.Group("user/v1/open/relation")
 	{
 		v1.GET("", middleware.AuthOptional(), c.GetUserRelation)
+		v1.GET("/followers", middleware.AuthOptional(), c.ListFollowers)   // 粉丝列表，光标分页
+		v1.GET("/followings", middleware.AuthOptional(), c.ListFollowings) // 关注列表，光标分页
+		v1.GET("/friends", middleware.AuthOptional(), c.ListFriends)       // 互相关注的好友列表，光标分页
+		// 目标用户的粉丝中当前用户关注了的人
+		v1.GET("/common-followers", middleware.AuthOptional(), c.CommonFollowers)
+		// 批量查询与当前用户的关注关系
+		v1.POST("/status/batch", middleware.AuthOptional(), c.BatchFollowStatus)
+		// 推荐关注，未登录时返回热门账号
+		v1.GET("/suggestions", middleware.AuthOptional(), c.Suggestions)
 	}
 }
 
@@ -58,7 +84,22 @@ func (c *socialControllerImpl) RegisterInnerApi(router *gin.RouterGroup) {
 	v1 := router.Group("user/v1/inner/relation")
 	{
 		v1.POST("/follow/toggle", middleware.AuthRequired(), c.ToggleFollow)
+		v1.GET("/follow/requests", middleware.AuthRequired(), c.ListFollowRequests)            // 收到的关注申请，光标分页
+		v1.POST("/follow/requests/resolve", middleware.AuthRequired(), c.ResolveFollowRequest) // 同意/拒绝关注申请
+		v1.POST("/follow/requests/cancel", middleware.AuthRequired(), c.CancelFollowRequest)   // 撤回发出的关注申请
+		v1.POST("/followers/remove", middleware.AuthRequired(), c.RemoveFollower)              // 移除粉丝
+		v1.POST("/block/toggle", middleware.AuthRequired(), c.ToggleBlock)                     // 拉黑/屏蔽
+		v1.GET("/blocks", middleware.AuthRequired(), c.ListBlocks)                             // 拉黑/屏蔽列表，光标分页
+		// 对推荐关注的账号点“不感兴趣”
+		v1.POST("/suggestions/dismiss", middleware.AuthRequired(), c.DismissSuggestion)
 	}
+	// 服务间查询是否互相关注，供私信服务使用；调用方需带服务间签名（见 middleware.ServiceAuthRequired）
+	router.GET("user/v1/inner/relation/friend", middleware.ServiceAuthRequired(), c.IsFriend)
+	// 服务间批量查询关注关系，供信息流服务使用；调用方需带服务间签名
+	router.POST("user/v1/inner/relation/status/batch", middleware.ServiceAuthRequired(), c.BatchFollowStatusInner)
+	// 服务间查询拉黑/屏蔽关系，供评论、私信服务使用；调用方需带服务间签名
+	router.GET("user/v1/inner/relation/block/check", middleware.ServiceAuthRequired(), c.CheckBlock)
+	router.POST("user/v1/inner/relation/block/batch", middleware.ServiceAuthRequired(), c.BatchCheckBlock)
 }
 
 func (c *socialControllerImpl) RegisterDebugApi(router *gin.RouterGroup) {}
@@ -77,7 +118,69 @@ func (c *socialControllerImpl) ToggleFollow(ctx *gin.Context) {
 		return
 	}
 	req.UserUUID = userUUID
-	if err := c.socialApp.ToggleFollow(ctx.Request.Context(), &req); err != nil {
+	res, err := c.socialApp.ToggleFollow(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// ListFollowRequests 当前用户收到的待处理关注申请
+func (c *socialControllerImpl) ListFollowRequests(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	var req cqe.FollowRequestListQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "query"))
+		return
+	}
+	req.UserUUID = userUUID
+	res, err := c.socialApp.ListFollowRequests(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// ResolveFollowRequest 同意（action=approve）或拒绝（action=reject）关注申请
+func (c *socialControllerImpl) ResolveFollowRequest(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	var req cqe.FollowRequestResolveReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "requester_uuid"))
+		return
+	}
+	req.UserUUID = userUUID
+	if err := c.socialApp.ResolveFollowRequest(ctx.Request.Context(), &req); err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, nil)
+}
+
+// CancelFollowRequest 撤回发给 target_uuid 的关注申请
+func (c *socialControllerImpl) CancelFollowRequest(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	var req cqe.FollowReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "target_uuid"))
+		return
+	}
+	req.UserUUID = userUUID
+	if err := c.socialApp.CancelFollowRequest(ctx.Request.Context(), &req); err != nil {
 		restapi.Failed(ctx, err)
 		return
 	}
@@ -99,3 +202,252 @@ func (c *socialControllerImpl) GetUserRelation(ctx *gin.Context) {
 	}
 	restapi.Success(ctx, res)
 }
+
+// ListFollowers 查询用户的粉丝列表（target_uuid 为空时查询当前登录用户）
+func (c *socialControllerImpl) ListFollowers(ctx *gin.Context) {
+	req, ok := c.bindFollowListQuery(ctx)
+	if !ok {
+		return
+	}
+	res, err := c.socialApp.ListFollowers(ctx.Request.Context(), req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// ListFollowings 查询用户的关注列表（target_uuid 为空时查询当前登录用户）
+func (c *socialControllerImpl) ListFollowings(ctx *gin.Context) {
+	req, ok := c.bindFollowListQuery(ctx)
+	if !ok {
+		return
+	}
+	res, err := c.socialApp.ListFollowings(ctx.Request.Context(), req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// ListFriends 查询用户互相关注的好友列表（target_uuid 为空时查询当前登录用户）
+func (c *socialControllerImpl) ListFriends(ctx *gin.Context) {
+	req, ok := c.bindFollowListQuery(ctx)
+	if !ok {
+		return
+	}
+	res, err := c.socialApp.ListFriends(ctx.Request.Context(), req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// IsFriend 查询 user_uuid 与 target_uuid 是否互相关注
+func (c *socialControllerImpl) IsFriend(ctx *gin.Context) {
+	var req cqe.FriendCheckReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "query"))
+		return
+	}
+	res, err := c.socialApp.IsFriend(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// CommonFollowers “你关注的人中也关注了 TA 的”，返回示例用户与总数
+func (c *socialControllerImpl) CommonFollowers(ctx *gin.Context) {
+	var req cqe.CommonFollowersQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "query"))
+		return
+	}
+	req.ViewerUUID, _ = authctx.MustGetUserUUID(ctx)
+	res, err := c.socialApp.CommonFollowers(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// BatchFollowStatus 批量查询当前用户与 target_uuids 的双向关注关系
+func (c *socialControllerImpl) BatchFollowStatus(ctx *gin.Context) {
+	var req cqe.FollowStatusBatchReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "target_uuids"))
+		return
+	}
+	req.UserUUID, _ = authctx.MustGetUserUUID(ctx)
+	c.batchFollowStatus(ctx, &req)
+}
+
+// BatchFollowStatusInner 服务间批量查询 user_uuid 与 target_uuids 的双向关注关系
+func (c *socialControllerImpl) BatchFollowStatusInner(ctx *gin.Context) {
+	var req cqe.FollowStatusBatchReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "target_uuids"))
+		return
+	}
+	if req.UserUUID == "" {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "user_uuid"))
+		return
+	}
+	c.batchFollowStatus(ctx, &req)
+}
+
+func (c *socialControllerImpl) batchFollowStatus(ctx *gin.Context, req *cqe.FollowStatusBatchReq) {
+	res, err := c.socialApp.BatchFollowStatus(ctx.Request.Context(), req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// RemoveFollower 将 follower_uuid 移出当前用户的粉丝列表
+func (c *socialControllerImpl) RemoveFollower(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	var req cqe.RemoveFollowerReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "follower_uuid"))
+		return
+	}
+	req.UserUUID = userUUID
+	if err := c.socialApp.RemoveFollower(ctx.Request.Context(), &req); err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, nil)
+}
+
+// Suggestions 推荐关注列表
+func (c *socialControllerImpl) Suggestions(ctx *gin.Context) {
+	var req cqe.SuggestionQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "query"))
+		return
+	}
+	req.UserUUID, _ = authctx.MustGetUserUUID(ctx)
+	res, err := c.socialApp.Suggestions(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// DismissSuggestion 不再推荐 target_uuid
+func (c *socialControllerImpl) DismissSuggestion(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	var req cqe.DismissSuggestionReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "target_uuid"))
+		return
+	}
+	req.UserUUID = userUUID
+	if err := c.socialApp.DismissSuggestion(ctx.Request.Context(), &req); err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, nil)
+}
+
+// ToggleBlock 统一的拉黑/屏蔽接口，action 为 block / unblock / mute / unmute
+func (c *socialControllerImpl) ToggleBlock(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	var req cqe.BlockToggleReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "target_uuid"))
+		return
+	}
+	req.UserUUID = userUUID
+	if err := c.socialApp.ToggleBlock(ctx.Request.Context(), &req); err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, nil)
+}
+
+// ListBlocks 当前用户拉黑（kind=block）或屏蔽（kind=mute）的人
+func (c *socialControllerImpl) ListBlocks(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	var req cqe.BlockListQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "query"))
+		return
+	}
+	req.UserUUID = userUUID
+	res, err := c.socialApp.ListBlocks(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// CheckBlock 查询 user_uuid 与 target_uuid 之间的拉黑/屏蔽关系
+func (c *socialControllerImpl) CheckBlock(ctx *gin.Context) {
+	var req cqe.BlockCheckReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "query"))
+		return
+	}
+	res, err := c.socialApp.CheckBlock(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+// BatchCheckBlock 批量查询 user_uuid 与 target_uuids 之间的拉黑/屏蔽关系
+func (c *socialControllerImpl) BatchCheckBlock(ctx *gin.Context) {
+	var req cqe.BlockBatchCheckReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "target_uuids"))
+		return
+	}
+	res, err := c.socialApp.BatchCheckBlock(ctx.Request.Context(), &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, res)
+}
+
+func (c *socialControllerImpl) bindFollowListQuery(ctx *gin.Context) (*cqe.FollowListQuery, bool) {
+	var req cqe.FollowListQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "query"))
+		return nil, false
+	}
+	viewerUUID, _ := authctx.MustGetUserUUID(ctx)
+	req.ViewerUUID = viewerUUID
+	if req.TargetUUID == "" {
+		req.TargetUUID = req.TargetUserUUID
+	}
+	req.Normalize(viewerUUID)
+	return &req, true
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"sync"
 
@@ -11,14 +12,29 @@ import (
 	"user-service/ddd/application/cqe"
 	grpcinfra "user-service/ddd/infrastructure/grpc"
 	"user-service/pkg/assert"
+	"user-service/pkg/authctx"
+	"user-service/pkg/config"
 	"user-service/pkg/errno"
 	"user-service/pkg/manager"
 	"user-service/pkg/middleware"
+	"user-service/pkg/reqmeta"
 	"user-service/pkg/restapi"
 
 	"github.com/gin-gonic/gin"
 )
 
+// deviceCookieMaxAge 设备标识 Cookie 有效期（一年）
+const deviceCookieMaxAge = 365 * 24 * 3600
+
+// deviceCookieSecure 设备标识 Cookie 是否带 Secure 属性，默认开启，仅 debug 模式下关闭以便本地 HTTP 调试
+func deviceCookieSecure() bool {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil || cfg.LoginRisk.DeviceCookieSecure == nil {
+		return true
+	}
+	return *cfg.LoginRisk.DeviceCookieSecure
+}
+
 var (
 	userControllerOnce      sync.Once
 	singletonUserController UserController
@@ -50,6 +66,13 @@ type UserController interface {
 	Logout(ctx *gin.Context)
 	SaveUser(ctx *gin.Context)
 	ChangePassword(ctx *gin.Context)
+	ChangeHandle(ctx *gin.Context)
+	UpdateProfile(ctx *gin.Context)
+	GetUserByHandle(ctx *gin.Context)
+	GetPrivacySettings(ctx *gin.Context)
+	UpdatePrivacySettings(ctx *gin.Context)
+	SearchUsers(ctx *gin.Context)
+	GetUserCacheStats(ctx *gin.Context)
 }
 
 type userControllerImpl struct {
@@ -65,7 +88,12 @@ func (c *userControllerImpl) RegisterOpenApi(router *gin.RouterGroup) {
 		v1.POST("/login", c.Login)
 		v1.POST("/refresh", c.Refresh)
 		v1.POST("/logout", c.Logout)
-		v1.GET("/:user_uuid", c.GetUserBasicInfo) // 获取用户基本信息
+		v1.GET("/search", middleware.AuthOptional(), c.SearchUsers)          // 按昵称、用户名搜索用户
+		v1.GET("/:user_uuid", middleware.AuthOptional(), c.GetUserBasicInfo) // 获取用户基本信息，按隐私设置隐去资料
+	}
+	handles := router.Group("user/v1/open/handles")
+	{
+		handles.GET("/:handle", middleware.AuthOptional(), c.GetUserByHandle) // 按 @handle 查询用户，旧用户名在保留期内返回 redirected_from
 	}
 }
 
@@ -77,6 +105,10 @@ func (c *userControllerImpl) RegisterInnerApi(router *gin.RouterGroup) {
 		v1.GET("/info/:uuid", middleware.AuthRequired(), c.QueryUserInfo)
 		v1.POST("/save", middleware.AuthRequired(), c.SaveUser)
 		v1.POST("/password", middleware.AuthRequired(), c.ChangePassword)
+		v1.POST("/handle", middleware.AuthRequired(), c.ChangeHandle)
+		v1.PATCH("/profile", middleware.AuthRequired(), c.UpdateProfile)
+		v1.GET("/privacy", middleware.AuthRequired(), c.GetPrivacySettings)
+		v1.PUT("/privacy", middleware.AuthRequired(), c.UpdatePrivacySettings)
 	}
 }
 
@@ -91,7 +123,10 @@ func (c *userControllerImpl) RegisterDebugApi(router *gin.RouterGroup) {
 
 // RegisterOpsApi 注册运维API
 func (c *userControllerImpl) RegisterOpsApi(router *gin.RouterGroup) {
-	// 运维API实现
+	v1 := router.Group("/user/v1/ops/cache")
+	{
+		v1.GET("/users/stats", c.GetUserCacheStats) // 用户资料缓存命中率
+	}
 }
 
 // Register 用户注册
@@ -121,6 +156,9 @@ func (c *userControllerImpl) Login(ctx *gin.Context) {
 		restapi.Failed(ctx, err)
 		return
 	}
+	// 下发长期有效的设备标识 Cookie，用于新设备识别
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(reqmeta.DeviceCookieName, result.DeviceID, deviceCookieMaxAge, "/", "", deviceCookieSecure(), true)
 	restapi.Success(ctx, result)
 }
 
@@ -180,6 +218,7 @@ func (c *userControllerImpl) QueryUserInfo(ctx *gin.Context) {
 		return
 	}
 
+	setVersionETag(ctx, userInfo.Version)
 	restapi.Success(ctx, userInfo)
 }
 
@@ -190,6 +229,12 @@ func (c *userControllerImpl) SaveUser(ctx *gin.Context) {
 		restapi.Failed(ctx, err)
 		return
 	}
+	ifMatch, err := parseIfMatch(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	req.IfMatch = ifMatch
 
 	// 获取当前用户UUID
 	userUUID, exists := ctx.Get("user_uuid")
@@ -200,9 +245,10 @@ func (c *userControllerImpl) SaveUser(ctx *gin.Context) {
 
 	result, err := c.userApp.SaveUserInfo(ctx.Request.Context(), userUUID.(string), &req)
 	if err != nil {
-		restapi.Failed(ctx, err)
+		failedWithConflict(ctx, err)
 		return
 	}
+	setVersionETag(ctx, result.Version)
 	restapi.Success(ctx, result)
 }
 
@@ -225,6 +271,127 @@ func (c *userControllerImpl) ChangePassword(ctx *gin.Context) {
 	restapi.Success(ctx, "ok")
 }
 
+// ChangeHandle 修改公开用户名
+func (c *userControllerImpl) ChangeHandle(ctx *gin.Context) {
+	var req cqe.ChangeHandleReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	ifMatch, err := parseIfMatch(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	req.IfMatch = ifMatch
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	result, err := c.userApp.ChangeHandle(ctx.Request.Context(), userUUID, &req)
+	if err != nil {
+		failedWithConflict(ctx, err)
+		return
+	}
+	setVersionETag(ctx, result.Version)
+	restapi.Success(ctx, result)
+}
+
+// UpdateProfile 部分更新个人资料（merge-patch：缺省不改，null 清空）
+func (c *userControllerImpl) UpdateProfile(ctx *gin.Context) {
+	var req cqe.ProfilePatchReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "body"))
+		return
+	}
+	ifMatch, err := parseIfMatch(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	req.IfMatch = ifMatch
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	result, err := c.userApp.UpdateProfile(ctx.Request.Context(), userUUID, &req)
+	if err != nil {
+		failedWithConflict(ctx, err)
+		return
+	}
+	setVersionETag(ctx, result.Version)
+	restapi.Success(ctx, result)
+}
+
+// SearchUsers 搜索用户（公开接口），?q=关键词&page_num=1&page_size=20
+func (c *userControllerImpl) SearchUsers(ctx *gin.Context) {
+	var req cqe.UserSearchQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "q"))
+		return
+	}
+	viewerUUID, _ := authctx.MustGetUserUUID(ctx)
+	list, total, err := c.userApp.SearchUsers(ctx.Request.Context(), viewerUUID, &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.SuccessWithPage(ctx, req.PageQuery, list, total)
+}
+
+// GetPrivacySettings 查询当前用户的隐私设置
+func (c *userControllerImpl) GetPrivacySettings(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	result, err := c.userApp.GetPrivacySettings(ctx.Request.Context(), userUUID)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
+
+// UpdatePrivacySettings 更新当前用户的隐私设置，未传的字段保持不变
+func (c *userControllerImpl) UpdatePrivacySettings(ctx *gin.Context) {
+	userUUID, err := authctx.MustGetUserUUID(ctx)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	var req cqe.PrivacySettingsReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		restapi.Failed(ctx, errno.NewSimpleBizError(errno.ErrParameterInvalid, err, "body"))
+		return
+	}
+	result, err := c.userApp.UpdatePrivacySettings(ctx.Request.Context(), userUUID, &req)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
+
+// GetUserByHandle 按公开用户名查询用户基本信息（公开接口）
+func (c *userControllerImpl) GetUserByHandle(ctx *gin.Context) {
+	handle := ctx.Param("handle")
+	if handle == "" {
+		restapi.Failed(ctx, errno.ErrParameterInvalid)
+		return
+	}
+	viewerUUID, _ := authctx.MustGetUserUUID(ctx)
+	result, err := c.userApp.GetUserByHandle(ctx.Request.Context(), viewerUUID, handle)
+	if err != nil {
+		restapi.Failed(ctx, err)
+		return
+	}
+	restapi.Success(ctx, result)
+}
+
 // GetUserBasicInfo 获取用户基本信息（公开接口）
 func (c *userControllerImpl) GetUserBasicInfo(ctx *gin.Context) {
 	userUUID := ctx.Param("user_uuid")
@@ -233,7 +400,8 @@ func (c *userControllerImpl) GetUserBasicInfo(ctx *gin.Context) {
 		return
 	}
 
-	result, err := c.userApp.GetUserBasicInfo(ctx.Request.Context(), userUUID)
+	viewerUUID, _ := authctx.MustGetUserUUID(ctx)
+	result, err := c.userApp.GetUserBasicInfo(ctx.Request.Context(), viewerUUID, userUUID)
 	if err != nil {
 		restapi.Failed(ctx, err)
 		return
@@ -283,3 +451,8 @@ func (c *userControllerImpl) DebugNotificationGRPCLB(ctx *gin.Context) {
 		"failed":    failed,
 	})
 }
+
+// GetUserCacheStats 用户资料缓存命中统计
+func (c *userControllerImpl) GetUserCacheStats(ctx *gin.Context) {
+	restapi.Success(ctx, c.userApp.GetUserCacheStats(ctx.Request.Context()))
+}
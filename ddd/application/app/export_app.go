@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"user-service/ddd/application/dto"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/pkg/assert"
+	"user-service/pkg/blobstore"
+	"user-service/pkg/config"
+	"user-service/pkg/errno"
+	"user-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// exportDownloadPath 开放下载接口路径，与 ExportController 的路由保持一致
+const exportDownloadPath = "/api/user/v1/open/exports/download"
+
+type ExportApp interface {
+	RequestExport(ctx context.Context, userUUID string) (*dto.UserExportDto, error)
+	GetExport(ctx context.Context, userUUID, exportUUID string) (*dto.UserExportDto, error)
+	// OpenDownload 校验下载令牌并返回文件名与文件流，调用方负责关闭
+	OpenDownload(ctx context.Context, token string) (string, io.ReadCloser, error)
+}
+
+type exportAppImpl struct {
+	exportRepo repo.UserExportRepository
+	secret     []byte // 下载链接签名密钥，未配置时导出功能不可用
+	linkTTL    time.Duration
+}
+
+var (
+	onceExportApp      sync.Once
+	singletonExportApp ExportApp
+)
+
+func DefaultExportApp() ExportApp {
+	assert.NotCircular()
+	onceExportApp.Do(func() {
+		cfg := config.GetGlobalConfig()
+		singletonExportApp = &exportAppImpl{
+			exportRepo: persistence.NewUserExportRepository(),
+			secret:     resolveExportSecret(cfg),
+			linkTTL:    cfg.Export.LinkTTL,
+		}
+	})
+	assert.NotNil(singletonExportApp)
+	return singletonExportApp
+}
+
+// RequestExport 创建导出任务；若已有进行中的任务则直接返回该任务
+func (a *exportAppImpl) RequestExport(ctx context.Context, userUUID string) (*dto.UserExportDto, error) {
+	if userUUID == "" {
+		return nil, errno.ErrParameterInvalid
+	}
+	if a.secret == nil {
+		return nil, errno.ErrExportDisabled
+	}
+	active, err := a.exportRepo.GetActiveExport(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return a.toDto(active), nil
+	}
+	export := &po.UserExportPo{
+		ExportUUID: uuid.New().String(),
+		UserUUID:   userUUID,
+		Status:     vo.ExportStatusPending,
+	}
+	if err := a.exportRepo.CreateExport(ctx, export); err != nil {
+		return nil, err
+	}
+	logger.WithContext(ctx).Infof("export requested export=%s user=%s", export.ExportUUID, userUUID)
+	return a.toDto(export), nil
+}
+
+// GetExport 查询导出任务状态，完成时附带短时有效的下载链接
+func (a *exportAppImpl) GetExport(ctx context.Context, userUUID, exportUUID string) (*dto.UserExportDto, error) {
+	if exportUUID == "" {
+		return nil, errno.ErrParameterInvalid
+	}
+	export, err := a.exportRepo.GetExportByUUID(ctx, exportUUID)
+	if err != nil {
+		return nil, err
+	}
+	// 不暴露其他用户的任务是否存在
+	if export.UserUUID != userUUID {
+		return nil, errno.ErrExportNotFound
+	}
+	return a.toDto(export), nil
+}
+
+func (a *exportAppImpl) OpenDownload(ctx context.Context, token string) (string, io.ReadCloser, error) {
+	if a.secret == nil {
+		return "", nil, errno.ErrExportDisabled
+	}
+	exportUUID, ok := a.verifyToken(token, time.Now())
+	if !ok {
+		return "", nil, errno.ErrExportLinkInvalid
+	}
+	export, err := a.exportRepo.GetExportByUUID(ctx, exportUUID)
+	if err != nil {
+		return "", nil, err
+	}
+	if export.Status != vo.ExportStatusDone || export.ObjectKey == "" {
+		return "", nil, errno.ErrExportNotReady
+	}
+	store := blobstore.DefaultStore()
+	if store == nil {
+		return "", nil, errno.ErrInternalServer
+	}
+	rc, err := store.Open(ctx, export.ObjectKey)
+	if err != nil {
+		if err == blobstore.ErrNotFound {
+			return "", nil, errno.ErrExportNotReady
+		}
+		return "", nil, err
+	}
+	return fmt.Sprintf("user-data-%s.zip", export.ExportUUID), rc, nil
+}
+
+func (a *exportAppImpl) toDto(export *po.UserExportPo) *dto.UserExportDto {
+	res := &dto.UserExportDto{
+		ExportUUID: export.ExportUUID,
+		Status:     export.Status,
+		FileSize:   export.FileSize,
+		CreatedAt:  export.CreatedAt.Format(time.RFC3339),
+	}
+	if export.FinishedAt != nil {
+		res.FinishedAt = export.FinishedAt.Format(time.RFC3339)
+	}
+	if export.ExpiresAt != nil {
+		res.ExpiresAt = export.ExpiresAt.Format(time.RFC3339)
+	}
+	if export.Status == vo.ExportStatusDone && a.secret != nil {
+		expires := time.Now().Add(a.linkTTL)
+		// 链接有效期不超过导出包保留期
+		if export.ExpiresAt != nil && export.ExpiresAt.Before(expires) {
+			expires = *export.ExpiresAt
+		}
+		res.DownloadUrl = exportDownloadPath + "?token=" + url.QueryEscape(a.signToken(export.ExportUUID, expires))
+		res.LinkExpires = expires.Unix()
+	}
+	return res
+}
+
+// signToken 生成下载令牌：base64url(export_uuid:expires_unix).hex(hmac)
+func (a *exportAppImpl) signToken(exportUUID string, expires time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(exportUUID + ":" + strconv.FormatInt(expires.Unix(), 10)))
+	return payload + "." + a.mac(payload)
+}
+
+func (a *exportAppImpl) verifyToken(token string, now time.Time) (string, bool) {
+	payload, sig, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(sig), []byte(a.mac(payload))) {
+		return "", false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", false
+	}
+	exportUUID, expStr, found := strings.Cut(string(raw), ":")
+	if !found {
+		return "", false
+	}
+	exp, err := strconv.ParseInt(expStr, 10, 64)
+	if err != nil || now.Unix() > exp {
+		return "", false
+	}
+	return exportUUID, true
+}
+
+func (a *exportAppImpl) mac(payload string) string {
+	m := hmac.New(sha256.New, a.secret)
+	m.Write([]byte(payload))
+	return hex.EncodeToString(m.Sum(nil))
+}
+
+// resolveExportSecret 下载链接签名密钥只取 export.download_secret，不与 JWT 等其他用途共用；
+// 未配置时返回 nil，导出功能不可用
+func resolveExportSecret(cfg *config.Config) []byte {
+	if cfg != nil && cfg.Export.DownloadSecret != "" {
+		return []byte(cfg.Export.DownloadSecret)
+	}
+	logger.Error("export.download_secret not configured, personal data export is disabled")
+	return nil
+}
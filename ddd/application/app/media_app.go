@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"user-service/ddd/application/cqe"
+	"user-service/ddd/application/dto"
+	"user-service/ddd/domain/service"
+	"user-service/pkg/assert"
+)
+
+type MediaApp interface {
+	RequestUpload(ctx context.Context, userUUID string, req *cqe.MediaUploadReq) (*dto.MediaUploadDto, error)
+	CompleteUpload(ctx context.Context, userUUID string, req *cqe.MediaCompleteReq) (*dto.UserProfileDto, error)
+}
+
+type mediaAppImpl struct {
+	mediaSvc *service.MediaService
+}
+
+var (
+	onceMediaApp      sync.Once
+	singletonMediaApp MediaApp
+)
+
+func DefaultMediaApp() MediaApp {
+	assert.NotCircular()
+	onceMediaApp.Do(func() {
+		singletonMediaApp = &mediaAppImpl{
+			mediaSvc: service.NewMediaService(),
+		}
+	})
+	assert.NotNil(singletonMediaApp)
+	return singletonMediaApp
+}
+
+func (a *mediaAppImpl) RequestUpload(ctx context.Context, userUUID string, req *cqe.MediaUploadReq) (*dto.MediaUploadDto, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	ticket, err := a.mediaSvc.PrepareUpload(ctx, userUUID, req.Kind, req.ContentType, req.Size)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MediaUploadDto{
+		ObjectKey: ticket.ObjectKey,
+		UploadUrl: ticket.UploadURL,
+		Method:    http.MethodPut,
+		Headers:   ticket.Headers,
+		ExpiresAt: ticket.ExpiresAt.Unix(),
+	}, nil
+}
+
+func (a *mediaAppImpl) CompleteUpload(ctx context.Context, userUUID string, req *cqe.MediaCompleteReq) (*dto.UserProfileDto, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	userPo, err := a.mediaSvc.CompleteUpload(ctx, userUUID, req.Kind, req.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	return toUserProfileDto(userPo), nil
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"sync"
+
+	"user-service/ddd/application/cqe"
+	"user-service/ddd/application/dto"
+	"user-service/internal/resource"
+	"user-service/pkg/assert"
+	"user-service/pkg/errno"
+	"user-service/pkg/logger"
+	"user-service/pkg/moderation"
+)
+
+type ModerationApp interface {
+	Check(ctx context.Context, req *cqe.ModerationCheckReq) (*dto.ModerationCheckDto, error)
+	Reload(ctx context.Context) (*dto.ModerationCheckDto, error)
+}
+
+type moderationAppImpl struct{}
+
+var (
+	onceModerationApp      sync.Once
+	singletonModerationApp ModerationApp
+)
+
+func DefaultModerationApp() ModerationApp {
+	assert.NotCircular()
+	onceModerationApp.Do(func() {
+		singletonModerationApp = &moderationAppImpl{}
+	})
+	assert.NotNil(singletonModerationApp)
+	return singletonModerationApp
+}
+
+// Check 返回命中的敏感词及打码结果，便于运维验证词表
+func (a *moderationAppImpl) Check(ctx context.Context, req *cqe.ModerationCheckReq) (*dto.ModerationCheckDto, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	filter := moderation.Default()
+	hits := filter.Find(req.Text)
+	masked, hit := filter.Mask(req.Text)
+	result := &dto.ModerationCheckDto{
+		Hit:    hit,
+		Hits:   make([]dto.ModerationHitDto, 0, len(hits)),
+		Masked: masked,
+		Words:  filter.Size(),
+	}
+	for _, h := range hits {
+		result.Hits = append(result.Hits, dto.ModerationHitDto{Word: h.Word, Start: h.Start, End: h.End})
+	}
+	return result, nil
+}
+
+// Reload 立即重新加载词表，不等待文件变更检查
+func (a *moderationAppImpl) Reload(ctx context.Context) (*dto.ModerationCheckDto, error) {
+	if err := resource.DefaultModerationResource().Reload(); err != nil {
+		logger.WithContext(ctx).Warnf("reload moderation word list failed err=%v", err)
+		return nil, errno.NewSimpleBizError(errno.ErrInternalServer, err)
+	}
+	return &dto.ModerationCheckDto{Hits: []dto.ModerationHitDto{}, Words: moderation.Default().Size()}, nil
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"user-service/ddd/application/cqe"
+	"user-service/ddd/application/dto"
+	"user-service/ddd/domain/service"
+	"user-service/pkg/assert"
+)
+
+type PreferenceApp interface {
+	GetPreferences(ctx context.Context, userUUID string) (*dto.PreferencesDto, error)
+	PutPreferences(ctx context.Context, userUUID string, values map[string]json.RawMessage) (*dto.PreferencesDto, error)
+	PatchPreferences(ctx context.Context, userUUID string, values map[string]json.RawMessage) (*dto.PreferencesDto, error)
+	BatchGetPreferences(ctx context.Context, req *cqe.PreferenceBatchReq) (*dto.PreferenceBatchDto, error)
+}
+
+type preferenceAppImpl struct {
+	prefSvc *service.PreferenceService
+}
+
+var (
+	oncePreferenceApp      sync.Once
+	singletonPreferenceApp PreferenceApp
+)
+
+func DefaultPreferenceApp() PreferenceApp {
+	assert.NotCircular()
+	oncePreferenceApp.Do(func() {
+		singletonPreferenceApp = &preferenceAppImpl{
+			prefSvc: service.NewPreferenceService(),
+		}
+	})
+	assert.NotNil(singletonPreferenceApp)
+	return singletonPreferenceApp
+}
+
+func (a *preferenceAppImpl) GetPreferences(ctx context.Context, userUUID string) (*dto.PreferencesDto, error) {
+	prefs, err := a.prefSvc.Get(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PreferencesDto{Preferences: prefs}, nil
+}
+
+func (a *preferenceAppImpl) PutPreferences(ctx context.Context, userUUID string, values map[string]json.RawMessage) (*dto.PreferencesDto, error) {
+	prefs, err := a.prefSvc.Put(ctx, userUUID, values)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PreferencesDto{Preferences: prefs}, nil
+}
+
+func (a *preferenceAppImpl) PatchPreferences(ctx context.Context, userUUID string, values map[string]json.RawMessage) (*dto.PreferencesDto, error) {
+	prefs, err := a.prefSvc.Patch(ctx, userUUID, values)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PreferencesDto{Preferences: prefs}, nil
+}
+
+func (a *preferenceAppImpl) BatchGetPreferences(ctx context.Context, req *cqe.PreferenceBatchReq) (*dto.PreferenceBatchDto, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	users, err := a.prefSvc.BatchGet(ctx, dedupe(req.UserUUIDs), req.Keys)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PreferenceBatchDto{Users: users}, nil
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok || v == "" {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"user-service/ddd/application/cqe"
+	"user-service/ddd/application/dto"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/pkg/assert"
+	"user-service/pkg/errno"
+	"user-service/pkg/restapi"
+)
+
+type SecurityApp interface {
+	// ListMyEvents 用户查询自己的登录历史与安全事件
+	ListMyEvents(ctx context.Context, userUUID string, page *restapi.PageQuery) ([]*dto.SecurityEventDto, int64, error)
+	// ListEvents 运维按用户/类型/IP 查询安全事件
+	ListEvents(ctx context.Context, query *cqe.SecurityEventQuery) ([]*dto.AdminSecurityEventDto, int64, error)
+}
+
+type securityAppImpl struct {
+	eventRepo repo.SecurityEventRepository
+}
+
+var (
+	onceSecurityApp      sync.Once
+	singletonSecurityApp SecurityApp
+)
+
+func DefaultSecurityApp() SecurityApp {
+	assert.NotCircular()
+	onceSecurityApp.Do(func() {
+		singletonSecurityApp = &securityAppImpl{
+			eventRepo: persistence.NewSecurityEventRepository(),
+		}
+	})
+	assert.NotNil(singletonSecurityApp)
+	return singletonSecurityApp
+}
+
+func (a *securityAppImpl) ListMyEvents(ctx context.Context, userUUID string, page *restapi.PageQuery) ([]*dto.SecurityEventDto, int64, error) {
+	if userUUID == "" {
+		return nil, 0, errno.ErrUnauthorized
+	}
+	list, total, err := a.eventRepo.ListEvents(ctx, repo.SecurityEventQuery{
+		UserUUID: userUUID,
+		Offset:   page.Offset(),
+		Limit:    page.Limit(),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.SecurityEventDto, 0, len(list))
+	for _, ev := range list {
+		item := toSecurityEventDto(ev)
+		res = append(res, &item)
+	}
+	return res, total, nil
+}
+
+func (a *securityAppImpl) ListEvents(ctx context.Context, query *cqe.SecurityEventQuery) ([]*dto.AdminSecurityEventDto, int64, error) {
+	if err := query.Validate(); err != nil {
+		return nil, 0, err
+	}
+	list, total, err := a.eventRepo.ListEvents(ctx, repo.SecurityEventQuery{
+		UserUUID:  query.UserUUID,
+		EventType: query.EventType,
+		IP:        query.IP,
+		Offset:    query.Offset(),
+		Limit:     query.Limit(),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.AdminSecurityEventDto, 0, len(list))
+	for _, ev := range list {
+		res = append(res, &dto.AdminSecurityEventDto{UserUUID: ev.UserUUID, SecurityEventDto: toSecurityEventDto(ev)})
+	}
+	return res, total, nil
+}
+
+func toSecurityEventDto(ev *po.SecurityEventPo) dto.SecurityEventDto {
+	item := dto.SecurityEventDto{
+		EventType: ev.EventType,
+		Result:    ev.Result,
+		IP:        ev.IP,
+		UserAgent: ev.UserAgent,
+		RequestID: ev.RequestID,
+		CreatedAt: ev.CreatedAt.Format(time.RFC3339),
+	}
+	if ev.Metadata != "" && ev.Metadata != "{}" {
+		_ = json.Unmarshal([]byte(ev.Metadata), &item.Metadata)
+	}
+	return item
+}
@@ -11,6 +11,7 @@ import (
 	"user-service/ddd/domain/entity"
 	"user-service/ddd/domain/repo"
 	domainservice "user-service/ddd/domain/service"
+	"user-service/ddd/domain/vo"
 	"user-service/ddd/infrastructure/database/persistence"
 	"user-service/ddd/infrastructure/database/po"
 	"user-service/pkg/assert"
@@ -18,17 +19,35 @@ import (
 )
 
 type SocialApp interface {
-	ToggleFollow(ctx context.Context, req *cqe.FollowToggleReq) error
+	ToggleFollow(ctx context.Context, req *cqe.FollowToggleReq) (*dto.FollowStatusDto, error)
 	FollowStatus(ctx context.Context, req *cqe.FollowStatusReq) (*dto.FollowStatusDto, error)
 	ListFollowers(ctx context.Context, req *cqe.FollowListQuery) (*dto.FollowListDto, error)
 	ListFollowings(ctx context.Context, req *cqe.FollowListQuery) (*dto.FollowListDto, error)
+	ListFriends(ctx context.Context, req *cqe.FollowListQuery) (*dto.FollowListDto, error)
+	ListFollowRequests(ctx context.Context, req *cqe.FollowRequestListQuery) (*dto.FollowListDto, error)
+	ResolveFollowRequest(ctx context.Context, req *cqe.FollowRequestResolveReq) error
+	CancelFollowRequest(ctx context.Context, req *cqe.FollowReq) error
+	BatchFollowStatus(ctx context.Context, req *cqe.FollowStatusBatchReq) (*dto.FollowStatusBatchDto, error)
+	CommonFollowers(ctx context.Context, req *cqe.CommonFollowersQuery) (*dto.CommonFollowersDto, error)
+	RemoveFollower(ctx context.Context, req *cqe.RemoveFollowerReq) error
+	Suggestions(ctx context.Context, req *cqe.SuggestionQuery) (*dto.SuggestionListDto, error)
+	DismissSuggestion(ctx context.Context, req *cqe.DismissSuggestionReq) error
+	IsFriend(ctx context.Context, req *cqe.FriendCheckReq) (*dto.FriendStatusDto, error)
+	ToggleBlock(ctx context.Context, req *cqe.BlockToggleReq) error
+	ListBlocks(ctx context.Context, req *cqe.BlockListQuery) (*dto.BlockListDto, error)
+	CheckBlock(ctx context.Context, req *cqe.BlockCheckReq) (*dto.BlockStatusDto, error)
+	BatchCheckBlock(ctx context.Context, req *cqe.BlockBatchCheckReq) (*dto.BlockStatusBatchDto, error)
 	GetUserRelationStat(ctx context.Context, req *cqe.CheckFollowReq) (*dto.UserRelationStatDto, error)
 }
 
 type socialAppImpl struct {
 	userRepo   repo.UserRepository
 	followRepo repo.FollowRepository
+	blockRepo  repo.UserBlockRepository
 	socialSvc  *domainservice.SocialService
+	blockSvc   *domainservice.BlockService
+	privacy    *domainservice.PrivacyService
+	recommend  *domainservice.RecommendService
 }
 
 var (
@@ -42,7 +61,11 @@ func DefaultSocialApp() SocialApp {
 		singletonSocialApp = &socialAppImpl{
 			userRepo:   persistence.NewUserRepository(),
 			followRepo: persistence.NewFollowRepository(),
+			blockRepo:  persistence.NewUserBlockRepository(),
 			socialSvc:  domainservice.NewSocialService(),
+			blockSvc:   domainservice.NewBlockService(),
+			privacy:    domainservice.NewPrivacyService(),
+			recommend:  domainservice.NewRecommendService(),
 		}
 	})
 	assert.NotNil(singletonSocialApp)
@@ -53,76 +76,408 @@ func NewSocialApp(userRepo repo.UserRepository, followRepo repo.FollowRepository
 	return &socialAppImpl{
 		userRepo:   userRepo,
 		followRepo: followRepo,
+		blockRepo:  persistence.NewUserBlockRepository(),
+		socialSvc:  domainservice.NewSocialService(),
+		blockSvc:   domainservice.NewBlockService(),
+		privacy:    domainservice.NewPrivacyService(),
+		recommend:  domainservice.NewRecommendService(),
 	}
 }
 
-// ToggleFollow 统一处理关注/取关：通过 req.Follow 字段控制操作类型。
-// - follow=true  => 关注
-// - follow=false => 取消关注
-func (u *socialAppImpl) ToggleFollow(ctx context.Context, req *cqe.FollowToggleReq) error {
+// ToggleFollow 统一处理关注/取关：通过 req.Action 字段控制操作类型。
+// - follow   => 关注；对方为私密账号时发起关注申请
+// - unfollow => 取消关注或撤回申请
+func (u *socialAppImpl) ToggleFollow(ctx context.Context, req *cqe.FollowToggleReq) (*dto.FollowStatusDto, error) {
 	if err := req.Normalize(); err != nil {
-		return err
+		return nil, err
 	}
 	// 将请求转换为领域实体，交给领域服务处理具体业务逻辑
 	followEntity := entity.NewFollowEntity(req.UserUUID, req.TargetUUID, req.Action)
-	return u.socialSvc.ToggleFollow(ctx, followEntity)
+	state, err := u.socialSvc.ToggleFollow(ctx, followEntity)
+	if err != nil {
+		return nil, err
+	}
+	return toFollowStatusDto(state), nil
+}
+
+func toFollowStatusDto(state string) *dto.FollowStatusDto {
+	return &dto.FollowStatusDto{
+		Following: state == vo.FollowStateFollowing,
+		Requested: state == vo.FollowStatePending,
+	}
+}
+
+// BatchFollowStatus 批量查询 req.UserUUID 与多个用户的双向关注关系，供信息流渲染作者卡片；
+// 未登录时全部为 false，不查询缓存与数据库
+func (u *socialAppImpl) BatchFollowStatus(ctx context.Context, req *cqe.FollowStatusBatchReq) (*dto.FollowStatusBatchDto, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	resp := &dto.FollowStatusBatchDto{List: make([]dto.FollowStatusItem, 0, len(req.TargetUUIDs))}
+	var following, followedBy map[string]bool
+	if req.UserUUID != "" {
+		var err error
+		if following, followedBy, err = u.followRepo.RelationAmong(ctx, req.UserUUID, req.TargetUUIDs); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range req.TargetUUIDs {
+		resp.List = append(resp.List, dto.FollowStatusItem{
+			TargetUUID: t,
+			Following:  following[t],
+			FollowedBy: followedBy[t],
+		})
+	}
+	return resp, nil
+}
+
+// CommonFollowers 目标用户的粉丝中访问者关注了的人；未登录、查看自己或对方粉丝列表不可见时为空
+func (u *socialAppImpl) CommonFollowers(ctx context.Context, req *cqe.CommonFollowersQuery) (*dto.CommonFollowersDto, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	empty := &dto.CommonFollowersDto{List: []dto.FollowUser{}}
+	if req.ViewerUUID == "" || req.ViewerUUID == req.TargetUUID {
+		return empty, nil
+	}
+	access, err := u.privacy.Access(ctx, req.TargetUUID, req.ViewerUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !access.CanViewFollowers() {
+		return empty, nil
+	}
+	list, total, approx, err := u.followRepo.CommonFollowers(ctx, req.ViewerUUID, req.TargetUUID, req.Size)
+	if err != nil {
+		return nil, err
+	}
+	page, err := u.buildFollowListResp(ctx, req.ViewerUUID, list, func(p *po.FollowPo) string { return p.UserUUID }, req.Size, total)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CommonFollowersDto{List: page.List, Total: total, TotalApprox: approx}, nil
+}
+
+// ListFollowRequests 当前用户收到的待处理关注申请，按申请时间倒序
+func (u *socialAppImpl) ListFollowRequests(ctx context.Context, req *cqe.FollowRequestListQuery) (*dto.FollowListDto, error) {
+	if req == nil || req.UserUUID == "" {
+		return nil, errno.ErrParameterInvalid
+	}
+	limit := normalizeSize(req.Size)
+	list, total, err := u.followRepo.ListFollowRequests(ctx, req.UserUUID, req.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildFollowListResp(ctx, req.UserUUID, list, func(p *po.FollowPo) string { return p.UserUUID }, limit, total)
+}
+
+// ResolveFollowRequest 同意或拒绝收到的关注申请
+func (u *socialAppImpl) ResolveFollowRequest(ctx context.Context, req *cqe.FollowRequestResolveReq) error {
+	if err := req.Normalize(); err != nil {
+		return err
+	}
+	if req.Action == vo.FollowRequestApprove {
+		return u.socialSvc.ApproveRequest(ctx, req.UserUUID, req.RequesterUUID)
+	}
+	return u.socialSvc.RejectRequest(ctx, req.UserUUID, req.RequesterUUID)
+}
+
+// CancelFollowRequest 撤回自己发出的关注申请
+func (u *socialAppImpl) CancelFollowRequest(ctx context.Context, req *cqe.FollowReq) error {
+	if req == nil || req.UserUUID == "" {
+		return errno.ErrParameterInvalid
+	}
+	if req.TargetUUID == "" {
+		req.TargetUUID = req.TargetUserUUID
+	}
+	if req.TargetUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "target_uuid")
+	}
+	return u.socialSvc.CancelRequest(ctx, req.UserUUID, req.TargetUUID)
 }
 
 func (u *socialAppImpl) FollowStatus(ctx context.Context, req *cqe.FollowStatusReq) (*dto.FollowStatusDto, error) {
 	if req == nil || req.UserUUID == "" || req.TargetUUID == "" {
 		return nil, errno.ErrParameterInvalid
 	}
-	following, err := u.followRepo.IsFollowing(ctx, req.UserUUID, req.TargetUUID)
+	state, err := u.followRepo.FollowState(ctx, req.UserUUID, req.TargetUUID)
 	if err != nil {
 		return nil, err
 	}
-	return &dto.FollowStatusDto{Following: following}, nil
+	return toFollowStatusDto(state), nil
 }
 
 func (u *socialAppImpl) ListFollowers(ctx context.Context, req *cqe.FollowListQuery) (*dto.FollowListDto, error) {
 	if req == nil || req.TargetUUID == "" {
 		return nil, errno.ErrParameterInvalid
 	}
+	access, err := u.privacy.Access(ctx, req.TargetUUID, req.ViewerUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !access.CanViewFollowers() {
+		return nil, errno.ErrPrivacyRestricted
+	}
 	limit := normalizeSize(req.Size)
 	list, total, err := u.followRepo.ListFollowers(ctx, req.TargetUUID, req.Cursor, limit)
 	if err != nil {
 		return nil, err
 	}
-	return buildFollowListResp(list, limit, total), nil
+	return u.buildFollowListResp(ctx, req.ViewerUUID, list, func(p *po.FollowPo) string { return p.UserUUID }, limit, total)
 }
 
 func (u *socialAppImpl) ListFollowings(ctx context.Context, req *cqe.FollowListQuery) (*dto.FollowListDto, error) {
 	if req == nil || req.TargetUUID == "" {
 		return nil, errno.ErrParameterInvalid
 	}
+	access, err := u.privacy.Access(ctx, req.TargetUUID, req.ViewerUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !access.CanViewFollowings() {
+		return nil, errno.ErrPrivacyRestricted
+	}
 	limit := normalizeSize(req.Size)
 	list, total, err := u.followRepo.ListFollowings(ctx, req.TargetUUID, req.Cursor, limit)
 	if err != nil {
 		return nil, err
 	}
-	return buildFollowListResp(list, limit, total), nil
+	return u.buildFollowListResp(ctx, req.ViewerUUID, list, func(p *po.FollowPo) string { return p.TargetUUID }, limit, total)
+}
+
+// ListFriends 互相关注的好友列表；好友同时出现在对方的粉丝与关注列表中，两者都可见时才可查看
+func (u *socialAppImpl) ListFriends(ctx context.Context, req *cqe.FollowListQuery) (*dto.FollowListDto, error) {
+	if req == nil || req.TargetUUID == "" {
+		return nil, errno.ErrParameterInvalid
+	}
+	access, err := u.privacy.Access(ctx, req.TargetUUID, req.ViewerUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !access.CanViewFollowers() || !access.CanViewFollowings() {
+		return nil, errno.ErrPrivacyRestricted
+	}
+	limit := normalizeSize(req.Size)
+	list, total, err := u.followRepo.ListFriends(ctx, req.TargetUUID, req.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildFollowListResp(ctx, req.ViewerUUID, list, func(p *po.FollowPo) string { return p.TargetUUID }, limit, total)
+}
+
+// IsFriend 供私信等服务判断双方是否互相关注
+func (u *socialAppImpl) IsFriend(ctx context.Context, req *cqe.FriendCheckReq) (*dto.FriendStatusDto, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	isFriend, err := u.followRepo.IsFriend(ctx, req.UserUUID, req.TargetUUID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FriendStatusDto{UserUUID: req.UserUUID, TargetUUID: req.TargetUUID, IsFriend: isFriend}, nil
+}
+
+// RemoveFollower 移除粉丝
+func (u *socialAppImpl) RemoveFollower(ctx context.Context, req *cqe.RemoveFollowerReq) error {
+	if err := req.Normalize(); err != nil {
+		return err
+	}
+	return u.socialSvc.RemoveFollower(ctx, req.UserUUID, req.FollowerUUID)
+}
+
+// Suggestions 推荐关注列表：优先推荐关注的人也关注了的账号，不足时用热门账号补位；未登录时只返回热门账号
+func (u *socialAppImpl) Suggestions(ctx context.Context, req *cqe.SuggestionQuery) (*dto.SuggestionListDto, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	list, err := u.recommend.Suggestions(ctx, req.UserUUID, req.Size)
+	if err != nil {
+		return nil, err
+	}
+	uuids := make([]string, 0, len(list))
+	for _, v := range list {
+		uuids = append(uuids, v.UserUUID)
+	}
+	profiles, err := u.userRepo.GetUserProfiles(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.SuggestionListDto{List: make([]dto.SuggestedUser, 0, len(list))}
+	for _, v := range list {
+		profile, ok := profiles[v.UserUUID]
+		if !ok {
+			continue
+		}
+		item := dto.SuggestedUser{
+			UserUUID:  v.UserUUID,
+			Handle:    profile.GetHandle(),
+			Nickname:  profile.Nickname,
+			AvatarUrl: domainservice.PublicAvatarURL(profile),
+			Reason:    v.Reason,
+		}
+		if v.Reason == vo.RecommendReasonFollowedByFollowings {
+			item.FollowedByCount = v.Score
+		}
+		resp.List = append(resp.List, item)
+	}
+	return resp, nil
+}
+
+// DismissSuggestion 对推荐的账号点“不感兴趣”
+func (u *socialAppImpl) DismissSuggestion(ctx context.Context, req *cqe.DismissSuggestionReq) error {
+	if err := req.Normalize(); err != nil {
+		return err
+	}
+	return u.recommend.Dismiss(ctx, req.UserUUID, req.TargetUUID)
+}
+
+// ToggleBlock 拉黑/解除拉黑/屏蔽/解除屏蔽
+func (u *socialAppImpl) ToggleBlock(ctx context.Context, req *cqe.BlockToggleReq) error {
+	if err := req.Normalize(); err != nil {
+		return err
+	}
+	exists, err := u.userRepo.ExistsByUUID(ctx, req.TargetUUID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errno.ErrUserNotFound
+	}
+	return u.blockSvc.Apply(ctx, req.UserUUID, req.TargetUUID, req.Action)
+}
+
+// ListBlocks 当前用户拉黑/屏蔽的人，已注销的用户不出现在列表中
+func (u *socialAppImpl) ListBlocks(ctx context.Context, req *cqe.BlockListQuery) (*dto.BlockListDto, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	limit := normalizeSize(req.Size)
+	list, total, err := u.blockRepo.List(ctx, req.UserUUID, req.Kind, req.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	uuids := make([]string, 0, len(list))
+	for _, v := range list {
+		uuids = append(uuids, v.TargetUUID)
+	}
+	profiles, err := u.userRepo.GetUserProfiles(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.BlockListDto{
+		Size:  limit,
+		Total: total,
+		List:  make([]dto.BlockedUser, 0, len(list)),
+	}
+	for _, v := range list {
+		profile, ok := profiles[v.TargetUUID]
+		if !ok {
+			continue
+		}
+		resp.List = append(resp.List, dto.BlockedUser{
+			UserUUID:  v.TargetUUID,
+			Handle:    profile.GetHandle(),
+			Nickname:  profile.Nickname,
+			AvatarUrl: domainservice.PublicAvatarURL(profile),
+			CreatedAt: v.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	if len(list) > 0 {
+		last := list[len(list)-1]
+		resp.NextCursor = fmt.Sprintf("%d:%s", last.CreatedAt.UnixMilli(), last.TargetUUID)
+	}
+	return resp, nil
 }
 
-func buildFollowListResp(list []*po.FollowPo, size int, total int64) *dto.FollowListDto {
+// CheckBlock 供评论、私信等服务判断两个用户之间的拉黑/屏蔽关系
+func (u *socialAppImpl) CheckBlock(ctx context.Context, req *cqe.BlockCheckReq) (*dto.BlockStatusDto, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	relations, err := u.blockSvc.Relations(ctx, req.UserUUID, []string{req.TargetUUID})
+	if err != nil {
+		return nil, err
+	}
+	status := toBlockStatusDto(req.UserUUID, req.TargetUUID, relations[req.TargetUUID])
+	return &status, nil
+}
+
+// BatchCheckBlock 批量查询，单次 IN 查询覆盖两个方向
+func (u *socialAppImpl) BatchCheckBlock(ctx context.Context, req *cqe.BlockBatchCheckReq) (*dto.BlockStatusBatchDto, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	relations, err := u.blockSvc.Relations(ctx, req.UserUUID, req.TargetUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.BlockStatusBatchDto{List: make([]dto.BlockStatusDto, 0, len(req.TargetUUIDs))}
+	for _, t := range req.TargetUUIDs {
+		resp.List = append(resp.List, toBlockStatusDto(req.UserUUID, t, relations[t]))
+	}
+	return resp, nil
+}
+
+func toBlockStatusDto(userUUID, targetUUID string, rel *domainservice.BlockRelation) dto.BlockStatusDto {
+	status := dto.BlockStatusDto{UserUUID: userUUID, TargetUUID: targetUUID}
+	if rel != nil {
+		status.Blocking = rel.Blocking
+		status.BlockedBy = rel.BlockedBy
+		status.Muting = rel.Muting
+		status.Blocked = rel.Blocking || rel.BlockedBy
+	}
+	return status
+}
+
+// buildFollowListResp 批量补全列表用户的资料与访问者视角的关注关系（资料、关系各一次批量读取）；
+// 已注销的用户不出现在列表中，但光标仍按原始记录推进
+func (u *socialAppImpl) buildFollowListResp(ctx context.Context, viewerUUID string, list []*po.FollowPo, pick func(*po.FollowPo) string, size int, total int64) (*dto.FollowListDto, error) {
 	resp := &dto.FollowListDto{
 		Size:  size,
 		Total: total,
 		List:  make([]dto.FollowUser, 0, len(list)),
 	}
+	uuids := make([]string, 0, len(list))
+	for _, v := range list {
+		if v != nil {
+			uuids = append(uuids, pick(v))
+		}
+	}
+	profiles, err := u.userRepo.GetUserProfiles(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+	var following, followedBy map[string]bool
+	if viewerUUID != "" {
+		if following, followedBy, err = u.followRepo.RelationAmong(ctx, viewerUUID, uuids); err != nil {
+			return nil, err
+		}
+	}
 	for _, v := range list {
 		if v == nil {
 			continue
 		}
+		userUUID := pick(v)
+		profile, ok := profiles[userUUID]
+		if !ok {
+			continue
+		}
 		resp.List = append(resp.List, dto.FollowUser{
-			UserUUID:  v.UserUUID,
-			CreatedAt: v.CreatedAt.Format(time.RFC3339),
+			UserUUID:   userUUID,
+			Handle:     profile.GetHandle(),
+			Nickname:   profile.Nickname,
+			AvatarUrl:  domainservice.PublicAvatarURL(profile),
+			Following:  following[userUUID],
+			FollowedBy: followedBy[userUUID],
+			Mutual:     following[userUUID] && followedBy[userUUID],
+			CreatedAt:  v.CreatedAt.Format(time.RFC3339),
 		})
 	}
 	if len(list) > 0 {
 		last := list[len(list)-1]
-		resp.NextCursor = makeCursor(last)
+		resp.NextCursor = makeCursor(last, pick(last))
 	}
-	return resp
+	return resp, nil
 }
 
 // GetUserRelationStat 获取用户关系统计（粉丝数、关注数、关注状态）
@@ -139,28 +494,48 @@ func (u *socialAppImpl) GetUserRelationStat(ctx context.Context, req *cqe.CheckF
 		return nil, errno.ErrUserNotFound
 	}
 
-	// 获取粉丝数
-	followerTotal, err := u.followRepo.CountFollowers(ctx, req.FolloweeUUID)
+	isFollowed, err := u.followRepo.IsFollowing(ctx, req.FollowerUUID, req.FolloweeUUID)
+	if err != nil {
+		return nil, err
+	}
+	stat := &dto.UserRelationStatDto{
+		UserUUID:   req.FolloweeUUID,
+		IsFollowed: isFollowed,
+	}
+	if isFollowed {
+		if stat.IsMutual, err = u.followRepo.IsFollowing(ctx, req.FolloweeUUID, req.FollowerUUID); err != nil {
+			return nil, err
+		}
+	} else if req.FollowerUUID != "" && req.FollowerUUID != req.FolloweeUUID {
+		state, err := u.followRepo.FollowState(ctx, req.FollowerUUID, req.FolloweeUUID)
+		if err != nil {
+			return nil, err
+		}
+		stat.IsRequested = state == vo.FollowStatePending
+	}
+
+	// 对方隐藏计数时不返回粉丝数、关注数
+	access, err := u.privacy.Access(ctx, req.FolloweeUUID, req.FollowerUUID)
 	if err != nil {
 		return nil, err
 	}
+	if !access.CanViewCounts() {
+		stat.CountsHidden = true
+		return stat, nil
+	}
 
-	// 获取关注数
-	followingTotal, err := u.followRepo.CountFollowings(ctx, req.FolloweeUUID)
+	// 获取粉丝数
+	stat.FollowerCount, err = u.followRepo.CountFollowers(ctx, req.FolloweeUUID)
 	if err != nil {
 		return nil, err
 	}
 
-	isFollowed, err := u.followRepo.IsFollowing(ctx, req.FollowerUUID, req.FolloweeUUID)
+	// 获取关注数
+	stat.FollowingCount, err = u.followRepo.CountFollowings(ctx, req.FolloweeUUID)
 	if err != nil {
 		return nil, err
 	}
-	return &dto.UserRelationStatDto{
-		UserUUID:       req.FolloweeUUID,
-		FollowerCount:  followerTotal,
-		FollowingCount: followingTotal,
-		IsFollowed:     isFollowed,
-	}, nil
+	return stat, nil
 }
 
 func normalizeSize(size int) int {
@@ -170,10 +545,7 @@ func normalizeSize(size int) int {
 	return size
 }
 
-// cursor format: unixnano:id
-func makeCursor(p *po.FollowPo) string {
-	if p == nil {
-		return ""
-	}
-	return fmt.Sprintf("%d:%d", p.CreatedAt.UnixNano(), p.Id)
+// makeCursor 光标格式 "unixmilli:uuid"，uuid 为列表中对方用户，与仓储的解析保持一致
+func makeCursor(p *po.FollowPo, userUUID string) string {
+	return fmt.Sprintf("%d:%s", p.CreatedAt.UnixMilli(), userUUID)
 }
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"user-service/ddd/application/cqe"
 	"user-service/ddd/application/dto"
@@ -9,11 +10,14 @@ import (
 	"user-service/ddd/domain/repo"
 	"user-service/ddd/domain/service"
 	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/cache"
 	"user-service/ddd/infrastructure/database/persistence"
 	"user-service/ddd/infrastructure/database/po"
+	kafkainfra "user-service/ddd/infrastructure/kafka"
 	"user-service/pkg/assert"
 	"user-service/pkg/config"
 	"user-service/pkg/errno"
+	"user-service/pkg/logger"
 	"user-service/pkg/utils"
 
 	"github.com/google/uuid"
@@ -29,11 +33,20 @@ type UserApp interface {
 	Register(ctx context.Context, req *cqe.UserRegisterReq) (*dto.UserRegisterDto, error)
 	Login(ctx context.Context, req *cqe.UserLoginReq) (*dto.UserLoginDto, error)
 	GetUserInfo(ctx context.Context, userUUID string) (*dto.UserInfoDto, error)
-	GetUserBasicInfo(ctx context.Context, userUUID string) (*dto.UserBasicInfoDto, error)
+	// GetUsersByUUIDs 批量获取用户信息，返回按入参顺序排列的用户与不存在的 UUID
+	GetUsersByUUIDs(ctx context.Context, userUUIDs []string) ([]*dto.UserInfoDto, []string, error)
+	GetUserBasicInfo(ctx context.Context, viewerUUID, userUUID string) (*dto.UserBasicInfoDto, error)
 	SaveUserInfo(ctx context.Context, userUUID string, req *cqe.UserSaveReq) (*dto.UserInfoDto, error)
 	RefreshToken(ctx context.Context, req *cqe.TokenRefreshReq) (*dto.TokenRefreshDto, error)
 	ChangePassword(ctx context.Context, userUUID string, req *cqe.ChangePasswordReq) error
 	Logout(ctx context.Context, req *cqe.TokenRefreshReq) error
+	ChangeHandle(ctx context.Context, userUUID string, req *cqe.ChangeHandleReq) (*dto.UserInfoDto, error)
+	GetUserByHandle(ctx context.Context, viewerUUID, handle string) (*dto.UserByHandleDto, error)
+	UpdateProfile(ctx context.Context, userUUID string, req *cqe.ProfilePatchReq) (*dto.UserProfileDto, error)
+	GetPrivacySettings(ctx context.Context, userUUID string) (*dto.PrivacySettingsDto, error)
+	UpdatePrivacySettings(ctx context.Context, userUUID string, req *cqe.PrivacySettingsReq) (*dto.PrivacySettingsDto, error)
+	SearchUsers(ctx context.Context, viewerUUID string, req *cqe.UserSearchQuery) ([]*dto.UserBasicInfoDto, int64, error)
+	GetUserCacheStats(ctx context.Context) *dto.UserCacheStatsDto
 }
 
 type userAppImpl struct {
@@ -41,6 +54,15 @@ type userAppImpl struct {
 	jwtUtil  *utils.JWTUtil
 	cfg      *config.Config
 	authSvc  *service.AuthService
+	audit    *service.SecurityEventRecorder
+	handles  *service.HandleService
+	profiles *service.ProfileService
+	avatars  *service.AvatarProcessor
+	content  *service.ContentModerator
+	privacy  *service.PrivacyService
+	blocks   *service.BlockService
+	search   *service.UserSearchService
+	events   *service.ProfileEventEmitter
 }
 
 func DefaultUserApp() UserApp {
@@ -51,6 +73,15 @@ func DefaultUserApp() UserApp {
 			jwtUtil:  utils.DefaultJWTUtil(),
 			cfg:      config.GetGlobalConfig(),
 			authSvc:  service.NewAuthService(),
+			audit:    service.DefaultSecurityEventRecorder(),
+			handles:  service.NewHandleService(),
+			profiles: service.NewProfileService(),
+			avatars:  service.NewAvatarProcessor(),
+			content:  service.NewContentModerator(),
+			privacy:  service.NewPrivacyService(),
+			blocks:   service.NewBlockService(),
+			search:   service.NewUserSearchService(),
+			events:   service.NewProfileEventEmitter(),
 		}
 	})
 	assert.NotNil(singletonUserApp)
@@ -64,11 +95,23 @@ func NewUserApp(jwtUtil *utils.JWTUtil, cfg *config.Config) UserApp {
 		jwtUtil:  jwtUtil,
 		cfg:      cfg,
 		authSvc:  service.NewAuthService(),
+		audit:    service.DefaultSecurityEventRecorder(),
+		handles:  service.NewHandleService(),
+		profiles: service.NewProfileService(),
+		avatars:  service.NewAvatarProcessor(),
+		content:  service.NewContentModerator(),
+		privacy:  service.NewPrivacyService(),
+		blocks:   service.NewBlockService(),
+		search:   service.NewUserSearchService(),
+		events:   service.NewProfileEventEmitter(),
 	}
 }
 
 // Register 用户注册
 func (u *userAppImpl) Register(ctx context.Context, req *cqe.UserRegisterReq) (*dto.UserRegisterDto, error) {
+	if err := u.content.CheckIdentifier("account", req.Account); err != nil {
+		return nil, err
+	}
 	// 检查账号是否已存在
 	exists, err := u.userRepo.ExistsByAccount(ctx, req.Account)
 	if err != nil {
@@ -101,6 +144,7 @@ func (u *userAppImpl) Register(ctx context.Context, req *cqe.UserRegisterReq) (*
 	if err := u.userRepo.CreateUser(ctx, userPo); err != nil {
 		return nil, err
 	}
+	u.search.Index(ctx, userPo)
 
 	return &dto.UserRegisterDto{
 		UserUUID: userUUID,
@@ -150,8 +194,8 @@ func (u *userAppImpl) validatePassword(password string) error {
 
 // GetUserInfo 获取用户信息
 func (u *userAppImpl) GetUserInfo(ctx context.Context, userUUID string) (*dto.UserInfoDto, error) {
-	// 从数据库获取用户PO
-	userPo, err := u.userRepo.GetUserByUUID(ctx, userUUID)
+	// 经缓存读取用户PO
+	userPo, err := u.userRepo.GetUserProfile(ctx, userUUID)
 	if err != nil {
 		return nil, errno.ErrUserNotFound
 	}
@@ -160,11 +204,43 @@ func (u *userAppImpl) GetUserInfo(ctx context.Context, userUUID string) (*dto.Us
 	userEntity := entity.DefaultUserEntity(userPo.UserUUID, userPo.Account, userPo.Password)
 
 	// 将实体转换为响应DTO
-	return &dto.UserInfoDto{
-		UserUUID:  userEntity.GetUserUUID(),
-		Nickname:  userPo.Nickname,
-		AvatarUrl: userPo.AvatarUrl,
-	}, nil
+	info := toUserInfoDto(userPo)
+	info.UserUUID = userEntity.GetUserUUID()
+	return info, nil
+}
+
+// GetUsersByUUIDs 批量获取用户信息：去重后一次性读缓存/回源，超过批量上限直接拒绝
+func (u *userAppImpl) GetUsersByUUIDs(ctx context.Context, userUUIDs []string) ([]*dto.UserInfoDto, []string, error) {
+	userUUIDs = dedupe(userUUIDs)
+	if limit := u.cfg.UserCache.MaxBatch; limit > 0 && len(userUUIDs) > limit {
+		return nil, nil, errno.NewSimpleBizError(errno.ErrParameterInvalid,
+			fmt.Errorf("at most %d users per batch, got %d", limit, len(userUUIDs)), "user_uuids")
+	}
+	profiles, err := u.userRepo.GetUserProfiles(ctx, userUUIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	users := make([]*dto.UserInfoDto, 0, len(profiles))
+	var missing []string
+	for _, userUUID := range userUUIDs {
+		userPo, ok := profiles[userUUID]
+		if !ok {
+			missing = append(missing, userUUID)
+			continue
+		}
+		users = append(users, toUserInfoDto(userPo))
+	}
+	return users, missing, nil
+}
+
+// GetUserCacheStats 用户资料缓存命中统计，命中率 = (Hits + NegativeHits) / 总查询数
+func (u *userAppImpl) GetUserCacheStats(ctx context.Context) *dto.UserCacheStatsDto {
+	stats := &dto.UserCacheStatsDto{}
+	stats.Hits, stats.NegativeHits, stats.Misses = cache.UserCacheCounters()
+	if total := stats.Hits + stats.NegativeHits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits+stats.NegativeHits) / float64(total)
+	}
+	return stats
 }
 
 // SaveUserInfo 保存用户信息（部分字段）
@@ -177,8 +253,20 @@ func (u *userAppImpl) SaveUserInfo(ctx context.Context, userUUID string, req *cq
 	if userPo == nil {
 		return nil, errno.ErrUserNotFound
 	}
+	if req.IfMatch != nil && *req.IfMatch != userPo.Version {
+		return nil, errno.ErrUserVersionConflict
+	}
+	before := service.SnapshotProfile(userPo)
+	// 昵称、头像与 PATCH 接口使用同一套清洗与校验（控制字符、长度、敏感词、头像地址白名单）
+	nickname, avatarUrl, err := u.profiles.CheckSaveFields(req.Nickname, req.AvatarUrl)
+	if err != nil {
+		return nil, err
+	}
 	// 更新账号（如果变更）
 	if req.Account != "" && req.Account != userPo.Account {
+		if err := u.content.CheckIdentifier("account", req.Account); err != nil {
+			return nil, err
+		}
 		exists, err := u.userRepo.ExistsByAccount(ctx, req.Account)
 		if err != nil {
 			return nil, err
@@ -188,19 +276,35 @@ func (u *userAppImpl) SaveUserInfo(ctx context.Context, userUUID string, req *cq
 		}
 		userPo.Account = req.Account
 	}
-	if req.Nickname != "" {
-		userPo.Nickname = req.Nickname
+	if nickname != "" {
+		userPo.Nickname = nickname
 	}
-	if req.AvatarUrl != "" {
-		userPo.AvatarUrl = req.AvatarUrl
+	avatarChanged := avatarUrl != "" && avatarUrl != userPo.AvatarUrl
+	if avatarChanged {
+		userPo.AvatarUrl = avatarUrl
+		userPo.AvatarVariants = ""
 	}
-	if err := u.userRepo.UpdateUser(ctx, userPo); err != nil {
+	source := kafkainfra.ProfileChangeSourceProfile
+	if userPo.Account != before["account"] {
+		source = kafkainfra.ProfileChangeSourceAccount
+	}
+	events := u.events.Events(ctx, source, before, userPo)
+	if err := u.userRepo.UpdateUser(ctx, userPo, events...); err != nil {
 		return nil, err
 	}
+	u.search.Index(ctx, userPo)
+	if avatarChanged {
+		if err := u.avatars.Enqueue(ctx, userUUID, userPo.AvatarUrl); err != nil {
+			logger.WithContext(ctx).Errorf("enqueue avatar job failed user=%s err=%v", userUUID, err)
+		}
+	}
 	return &dto.UserInfoDto{
-		UserUUID:  userPo.UserUUID,
-		Nickname:  userPo.Nickname,
-		AvatarUrl: userPo.AvatarUrl,
+		UserUUID:   userPo.UserUUID,
+		Nickname:   userPo.Nickname,
+		AvatarUrl:  service.PublicAvatarURL(userPo),
+		AvatarUrls: service.AvatarVariantURLs(userPo.AvatarUrl, userPo.AvatarVariants),
+		Handle:     userPo.GetHandle(),
+		Version:    userPo.Version,
 	}, nil
 }
 
@@ -215,6 +319,7 @@ func (u *userAppImpl) ChangePassword(ctx context.Context, userUUID string, req *
 	}
 	// 校验旧密码
 	if err := bcrypt.CompareHashAndPassword([]byte(userPo.Password), []byte(req.OldPassword)); err != nil {
+		u.audit.Record(ctx, userUUID, vo.SecurityEventPasswordChange, vo.SecurityResultFailure, map[string]string{"reason": "password_incorrect"})
 		return errno.ErrPasswordIncorrect
 	}
 	// 校验新密码
@@ -227,7 +332,11 @@ func (u *userAppImpl) ChangePassword(ctx context.Context, userUUID string, req *
 		return errno.ErrPasswordEncrypt
 	}
 	userPo.Password = string(hashedPassword)
-	return u.userRepo.UpdateUser(ctx, userPo)
+	if err := u.userRepo.UpdateUser(ctx, userPo); err != nil {
+		return err
+	}
+	u.audit.Record(ctx, userUUID, vo.SecurityEventPasswordChange, vo.SecurityResultSuccess, nil)
+	return nil
 }
 
 func (u *userAppImpl) Logout(ctx context.Context, req *cqe.TokenRefreshReq) error {
@@ -237,21 +346,197 @@ func (u *userAppImpl) Logout(ctx context.Context, req *cqe.TokenRefreshReq) erro
 	return u.authSvc.Logout(ctx, req)
 }
 
-// GetUserBasicInfo 获取用户基本信息（公开接口）
-func (u *userAppImpl) GetUserBasicInfo(ctx context.Context, userUUID string) (*dto.UserBasicInfoDto, error) {
-	// 从数据库获取用户PO
-	userPo, err := u.userRepo.GetUserByUUID(ctx, userUUID)
+// GetUserBasicInfo 获取用户基本信息（公开接口），按隐私设置对访问者隐去资料
+func (u *userAppImpl) GetUserBasicInfo(ctx context.Context, viewerUUID, userUUID string) (*dto.UserBasicInfoDto, error) {
+	// 经缓存读取用户PO
+	userPo, err := u.userRepo.GetUserProfile(ctx, userUUID)
 	if err != nil {
 		return nil, errno.ErrUserNotFound
 	}
 
 	// 将PO转换为公开DTO
+	return u.visibleBasicInfo(ctx, viewerUUID, userPo)
+}
+
+// visibleBasicInfo 转换为公开 DTO，访问者无权查看主页时仅保留展示身份所需的字段；
+// 双方存在拉黑关系时不返回资料
+func (u *userAppImpl) visibleBasicInfo(ctx context.Context, viewerUUID string, userPo *po.UserPo) (*dto.UserBasicInfoDto, error) {
+	blocked, err := u.blocks.IsBlockedBetween(ctx, viewerUUID, userPo.UserUUID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, errno.ErrUserBlocked
+	}
+	access, err := u.privacy.Access(ctx, userPo.UserUUID, viewerUUID)
+	if err != nil {
+		return nil, err
+	}
+	return maskBasicInfo(userPo, access), nil
+}
+
+// maskBasicInfo 按可见性判定隐去对方的资料
+func maskBasicInfo(userPo *po.UserPo, access *service.PrivacyAccess) *dto.UserBasicInfoDto {
+	info := toUserBasicInfoDto(userPo)
+	info.PrivateAccount = access.Setting.PrivateAccount
+	if !access.CanViewProfile() {
+		info.Description = ""
+		info.CoverUrl = ""
+		info.CreatedAt = ""
+		info.Restricted = true
+	}
+	return info
+}
+
+// ChangeHandle 修改公开用户名
+func (u *userAppImpl) ChangeHandle(ctx context.Context, userUUID string, req *cqe.ChangeHandleReq) (*dto.UserInfoDto, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	userPo, err := u.handles.Change(ctx, userUUID, req.Handle, req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	u.search.Index(ctx, userPo)
+	return &dto.UserInfoDto{
+		UserUUID:   userPo.UserUUID,
+		Nickname:   userPo.Nickname,
+		AvatarUrl:  service.PublicAvatarURL(userPo),
+		AvatarUrls: service.AvatarVariantURLs(userPo.AvatarUrl, userPo.AvatarVariants),
+		Handle:     userPo.GetHandle(),
+		Version:    userPo.Version,
+	}, nil
+}
+
+// GetUserByHandle 按公开用户名查询用户基本信息，旧用户名在保留期内仍可命中
+func (u *userAppImpl) GetUserByHandle(ctx context.Context, viewerUUID, handle string) (*dto.UserByHandleDto, error) {
+	userPo, redirected, err := u.handles.Resolve(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+	info, err := u.visibleBasicInfo(ctx, viewerUUID, userPo)
+	if err != nil {
+		return nil, err
+	}
+	res := &dto.UserByHandleDto{UserBasicInfoDto: *info}
+	if redirected {
+		res.RedirectedFrom = service.NormalizeHandle(handle)
+	}
+	return res, nil
+}
+
+// UpdateProfile 按 merge-patch 语义更新个人资料，null 表示清空字段
+func (u *userAppImpl) UpdateProfile(ctx context.Context, userUUID string, req *cqe.ProfilePatchReq) (*dto.UserProfileDto, error) {
+	userPo, err := u.profiles.Patch(ctx, userUUID, req)
+	if err != nil {
+		return nil, err
+	}
+	u.search.Index(ctx, userPo)
+	return toUserProfileDto(userPo), nil
+}
+
+func toUserProfileDto(userPo *po.UserPo) *dto.UserProfileDto {
+	return &dto.UserProfileDto{
+		UserUUID:    userPo.UserUUID,
+		Handle:      userPo.GetHandle(),
+		Nickname:    userPo.Nickname,
+		AvatarUrl:   service.PublicAvatarURL(userPo),
+		AvatarUrls:  service.AvatarVariantURLs(userPo.AvatarUrl, userPo.AvatarVariants),
+		Description: userPo.Description,
+		CoverUrl:    userPo.CoverUrl,
+		UpdatedAt:   userPo.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Version:     userPo.Version,
+	}
+}
+
+// SearchUsers 按昵称、用户名（含拼音）搜索用户，结果按访问者的可见范围隐去资料
+func (u *userAppImpl) SearchUsers(ctx context.Context, viewerUUID string, req *cqe.UserSearchQuery) ([]*dto.UserBasicInfoDto, int64, error) {
+	if err := req.Validate(); err != nil {
+		return nil, 0, err
+	}
+	users, total, err := u.search.Search(ctx, req.Keyword, req.Offset(), req.Limit())
+	if err != nil {
+		return nil, 0, err
+	}
+	list := make([]*dto.UserBasicInfoDto, 0, len(users))
+	if len(users) == 0 {
+		return list, total, nil
+	}
+	uuids := make([]string, 0, len(users))
+	for _, userPo := range users {
+		uuids = append(uuids, userPo.UserUUID)
+	}
+	// 拉黑关系与隐私设置各批量查询一次；任一方拉黑的用户直接从结果中去掉
+	relations := map[string]*service.BlockRelation{}
+	if viewerUUID != "" {
+		if relations, err = u.blocks.Relations(ctx, viewerUUID, uuids); err != nil {
+			return nil, 0, err
+		}
+	}
+	access, err := u.privacy.AccessBatch(ctx, uuids, viewerUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, userPo := range users {
+		if rel := relations[userPo.UserUUID]; rel != nil && (rel.Blocking || rel.BlockedBy) {
+			continue
+		}
+		list = append(list, maskBasicInfo(userPo, access[userPo.UserUUID]))
+	}
+	return list, total, nil
+}
+
+// GetPrivacySettings 查询当前用户的隐私设置
+func (u *userAppImpl) GetPrivacySettings(ctx context.Context, userUUID string) (*dto.PrivacySettingsDto, error) {
+	setting, err := u.privacy.Get(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	return toPrivacySettingsDto(setting), nil
+}
+
+// UpdatePrivacySettings 更新当前用户的隐私设置
+func (u *userAppImpl) UpdatePrivacySettings(ctx context.Context, userUUID string, req *cqe.PrivacySettingsReq) (*dto.PrivacySettingsDto, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	setting, err := u.privacy.Update(ctx, userUUID, req)
+	if err != nil {
+		return nil, err
+	}
+	return toPrivacySettingsDto(setting), nil
+}
+
+func toPrivacySettingsDto(setting *po.UserPrivacyPo) *dto.PrivacySettingsDto {
+	return &dto.PrivacySettingsDto{
+		PrivateAccount:    setting.PrivateAccount,
+		HideFollowerList:  setting.HideFollowerList,
+		HideFollowingList: setting.HideFollowingList,
+		HideCounts:        setting.HideCounts,
+		ProfileVisibility: setting.ProfileVisibility,
+	}
+}
+
+func toUserInfoDto(userPo *po.UserPo) *dto.UserInfoDto {
+	return &dto.UserInfoDto{
+		UserUUID:   userPo.UserUUID,
+		Nickname:   userPo.Nickname,
+		AvatarUrl:  service.PublicAvatarURL(userPo),
+		AvatarUrls: service.AvatarVariantURLs(userPo.AvatarUrl, userPo.AvatarVariants),
+		Handle:     userPo.GetHandle(),
+		Version:    userPo.Version,
+	}
+}
+
+func toUserBasicInfoDto(userPo *po.UserPo) *dto.UserBasicInfoDto {
 	return &dto.UserBasicInfoDto{
 		UserUUID:    userPo.UserUUID,
+		Handle:      userPo.GetHandle(),
 		Nickname:    userPo.Nickname,
-		AvatarUrl:   userPo.AvatarUrl,
+		AvatarUrl:   service.PublicAvatarURL(userPo),
+		AvatarUrls:  service.AvatarVariantURLs(userPo.AvatarUrl, userPo.AvatarVariants),
 		Description: userPo.Description,
 		CoverUrl:    userPo.CoverUrl,
 		CreatedAt:   userPo.CreatedAt.Format("2006-01-02 15:04:05"),
-	}, nil
+	}
 }
@@ -0,0 +1,100 @@
+package cqe
+
+import (
+	"strings"
+
+	"user-service/ddd/domain/vo"
+	"user-service/pkg/errno"
+)
+
+const blockBatchMaxTargets = 100
+
+// BlockToggleReq 拉黑/屏蔽统一请求，通过 action 控制操作类型：block / unblock / mute / unmute
+type BlockToggleReq struct {
+	UserUUID   string `json:"-"`
+	TargetUUID string `json:"target_uuid"`
+	Action     string `json:"action"`
+}
+
+func (req *BlockToggleReq) Normalize() error {
+	if req == nil || req.UserUUID == "" {
+		return errno.ErrParameterInvalid
+	}
+	if req.TargetUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "target_uuid")
+	}
+	if req.UserUUID == req.TargetUUID {
+		return errno.ErrBlockSelf
+	}
+	req.Action = strings.ToLower(strings.TrimSpace(req.Action))
+	if !vo.CheckBlockAction(req.Action) {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "action")
+	}
+	return nil
+}
+
+// BlockListQuery 当前用户拉黑/屏蔽的人
+type BlockListQuery struct {
+	UserUUID string `form:"-"`
+	Kind     string `form:"kind"` // block（默认）/ mute
+	// Cursor 为上一页最后一条记录的 "unixmilli:uuid" 光标，空表示从最新开始
+	Cursor string `form:"cursor"`
+	Size   int    `form:"size"`
+}
+
+func (q *BlockListQuery) Normalize() error {
+	if q == nil || q.UserUUID == "" {
+		return errno.ErrParameterInvalid
+	}
+	q.Kind = strings.ToLower(strings.TrimSpace(q.Kind))
+	if q.Kind == "" {
+		q.Kind = vo.BlockKindBlock
+	}
+	if !vo.CheckBlockKind(q.Kind) {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "kind")
+	}
+	return nil
+}
+
+// BlockCheckReq 服务间查询两个用户之间的拉黑/屏蔽关系
+type BlockCheckReq struct {
+	UserUUID   string `form:"user_uuid"`
+	TargetUUID string `form:"target_uuid"`
+}
+
+func (q *BlockCheckReq) Normalize() error {
+	if q.UserUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "user_uuid")
+	}
+	if q.TargetUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "target_uuid")
+	}
+	return nil
+}
+
+// BlockBatchCheckReq 服务间批量查询 user_uuid 与多个用户之间的拉黑/屏蔽关系
+type BlockBatchCheckReq struct {
+	UserUUID    string   `json:"user_uuid" binding:"required"`
+	TargetUUIDs []string `json:"target_uuids" binding:"required"`
+}
+
+// Normalize 去重并校验数量
+func (r *BlockBatchCheckReq) Normalize() error {
+	if r == nil || r.UserUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "user_uuid")
+	}
+	seen := make(map[string]bool, len(r.TargetUUIDs))
+	targets := make([]string, 0, len(r.TargetUUIDs))
+	for _, t := range r.TargetUUIDs {
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		targets = append(targets, t)
+	}
+	if len(targets) == 0 || len(targets) > blockBatchMaxTargets {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "target_uuids")
+	}
+	r.TargetUUIDs = targets
+	return nil
+}
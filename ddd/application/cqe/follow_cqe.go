@@ -51,10 +51,90 @@ type FollowStatusReq struct {
 	TargetUserUUID string `json:"target_user_uuid" form:"target_user_uuid"`
 }
 
+const followStatusBatchMaxTargets = 100
+
+// FollowStatusBatchReq 批量查询关注关系。开放接口中 user_uuid 取自登录态（未登录为空），
+// 服务间调用时由调用方传入
+type FollowStatusBatchReq struct {
+	UserUUID    string   `json:"user_uuid"`
+	TargetUUIDs []string `json:"target_uuids" binding:"required"`
+}
+
+// Normalize 去重、去掉调用方自己并校验数量
+func (r *FollowStatusBatchReq) Normalize() error {
+	if r == nil {
+		return errno.ErrParameterInvalid
+	}
+	seen := make(map[string]bool, len(r.TargetUUIDs))
+	targets := make([]string, 0, len(r.TargetUUIDs))
+	for _, t := range r.TargetUUIDs {
+		if t == "" || t == r.UserUUID || seen[t] {
+			continue
+		}
+		seen[t] = true
+		targets = append(targets, t)
+	}
+	if len(r.TargetUUIDs) == 0 || len(targets) > followStatusBatchMaxTargets {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "target_uuids")
+	}
+	r.TargetUUIDs = targets
+	return nil
+}
+
+// RemoveFollowerReq 将某个粉丝移出自己的粉丝列表
+type RemoveFollowerReq struct {
+	UserUUID     string `json:"-"`
+	FollowerUUID string `json:"follower_uuid"`
+}
+
+func (req *RemoveFollowerReq) Normalize() error {
+	if req == nil || req.UserUUID == "" {
+		return errno.ErrParameterInvalid
+	}
+	if req.FollowerUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "follower_uuid")
+	}
+	if req.FollowerUUID == req.UserUUID {
+		return errno.ErrNotFollower
+	}
+	return nil
+}
+
+// FollowRequestResolveReq 处理收到的关注申请，action 为 approve / reject
+type FollowRequestResolveReq struct {
+	UserUUID      string `json:"-"` // 被申请人，即当前用户
+	RequesterUUID string `json:"requester_uuid"`
+	Action        string `json:"action"`
+}
+
+func (req *FollowRequestResolveReq) Normalize() error {
+	if req == nil || req.UserUUID == "" {
+		return errno.ErrParameterInvalid
+	}
+	if req.RequesterUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "requester_uuid")
+	}
+	req.Action = strings.ToLower(strings.TrimSpace(req.Action))
+	if req.Action != vo.FollowRequestApprove && req.Action != vo.FollowRequestReject {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "action")
+	}
+	return nil
+}
+
+// FollowRequestListQuery 当前用户收到的待处理关注申请
+type FollowRequestListQuery struct {
+	UserUUID string `form:"-"`
+	// Cursor 为上一页最后一条记录的 "unixmilli:uuid" 光标，空表示从最新开始
+	Cursor string `form:"cursor"`
+	Size   int    `form:"size"`
+}
+
 type FollowListQuery struct {
+	// ViewerUUID 当前访问者，未登录为空，用于隐私设置判定
+	ViewerUUID     string `form:"-"`
 	TargetUUID     string `form:"target_uuid"`
 	TargetUserUUID string `form:"target_user_uuid"`
-	// Cursor 为上一页最后一条记录的 "unixnano:id" 光标，空表示从最新开始
+	// Cursor 为上一页最后一条记录的 "unixmilli:uuid" 光标，空表示从最新开始
 	Cursor string `form:"cursor"`
 	Size   int    `form:"size"`
 }
@@ -68,6 +148,42 @@ func (q *FollowListQuery) Normalize(defaultTarget string) {
 	}
 }
 
+// FriendCheckReq 服务间查询两个用户是否互相关注
+type FriendCheckReq struct {
+	UserUUID   string `form:"user_uuid"`
+	TargetUUID string `form:"target_uuid"`
+}
+
+func (q *FriendCheckReq) Normalize() error {
+	if q.UserUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "user_uuid")
+	}
+	if q.TargetUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "target_uuid")
+	}
+	return nil
+}
+
+// CommonFollowersQuery 目标用户的粉丝中，访问者关注了的人
+type CommonFollowersQuery struct {
+	ViewerUUID string `form:"-"`
+	TargetUUID string `form:"target_uuid"`
+	Size       int    `form:"size"` // 返回的示例用户数，默认 3，最多 20
+}
+
+func (q *CommonFollowersQuery) Normalize() error {
+	if q == nil || q.TargetUUID == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "target_uuid")
+	}
+	if q.Size <= 0 {
+		q.Size = 3
+	}
+	if q.Size > 20 {
+		q.Size = 20
+	}
+	return nil
+}
+
 type CheckFollowReq struct {
 	// 关注者
 	FollowerUUID string
@@ -0,0 +1,45 @@
+package cqe
+
+import (
+	"user-service/ddd/domain/vo"
+	"user-service/pkg/errno"
+)
+
+// MediaUploadReq 申请头像/封面直传 URL
+type MediaUploadReq struct {
+	Kind        string `json:"kind" binding:"required" example:"avatar"` // avatar / cover
+	ContentType string `json:"content_type" binding:"required" example:"image/jpeg"`
+	Size        int64  `json:"size" binding:"required" example:"204800"` // 文件字节数
+}
+
+func (r *MediaUploadReq) Validate() error {
+	if r == nil {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "request")
+	}
+	if r.Kind != vo.MediaKindAvatar && r.Kind != vo.MediaKindCover {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "kind")
+	}
+	if r.Size <= 0 {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "size")
+	}
+	return nil
+}
+
+// MediaCompleteReq 直传完成回调
+type MediaCompleteReq struct {
+	Kind      string `json:"kind" binding:"required" example:"avatar"`
+	ObjectKey string `json:"object_key" binding:"required" example:"image/avatar/550e.../9f1c....jpg"`
+}
+
+func (r *MediaCompleteReq) Validate() error {
+	if r == nil {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "request")
+	}
+	if r.Kind != vo.MediaKindAvatar && r.Kind != vo.MediaKindCover {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "kind")
+	}
+	if r.ObjectKey == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "object_key")
+	}
+	return nil
+}
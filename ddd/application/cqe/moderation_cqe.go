@@ -0,0 +1,21 @@
+package cqe
+
+import (
+	"unicode/utf8"
+
+	"user-service/pkg/errno"
+)
+
+const moderationCheckMaxLen = 2000
+
+// ModerationCheckReq 运维测试文本是否命中敏感词
+type ModerationCheckReq struct {
+	Text string `json:"text" binding:"required" example:"测试文本"`
+}
+
+func (r *ModerationCheckReq) Validate() error {
+	if r == nil || r.Text == "" || utf8.RuneCountInString(r.Text) > moderationCheckMaxLen {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "text")
+	}
+	return nil
+}
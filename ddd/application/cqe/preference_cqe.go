@@ -0,0 +1,18 @@
+package cqe
+
+import "user-service/pkg/errno"
+
+const preferenceBatchMaxUsers = 100
+
+// PreferenceBatchReq 服务间批量读取用户偏好
+type PreferenceBatchReq struct {
+	UserUUIDs []string `json:"user_uuids" binding:"required"`
+	Keys      []string `json:"keys"` // 为空表示全部偏好项
+}
+
+func (r *PreferenceBatchReq) Validate() error {
+	if r == nil || len(r.UserUUIDs) == 0 || len(r.UserUUIDs) > preferenceBatchMaxUsers {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "user_uuids")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package cqe
+
+import (
+	"user-service/ddd/domain/vo"
+	"user-service/pkg/errno"
+)
+
+// PrivacySettingsReq 更新隐私设置，未出现的字段保持不变
+type PrivacySettingsReq struct {
+	PrivateAccount    *bool   `json:"private_account" example:"false"`     // 私密账号：关注需经本人同意，关系列表仅粉丝可见
+	HideFollowerList  *bool   `json:"hide_follower_list" example:"false"`  // 粉丝列表仅本人可见
+	HideFollowingList *bool   `json:"hide_following_list" example:"false"` // 关注列表仅本人可见
+	HideCounts        *bool   `json:"hide_counts" example:"false"`         // 粉丝数、关注数仅本人可见
+	ProfileVisibility *string `json:"profile_visibility" example:"public"` // public / followers / private
+}
+
+func (r *PrivacySettingsReq) Validate() error {
+	if r == nil {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "request")
+	}
+	if r.ProfileVisibility != nil && !vo.CheckProfileVisibility(*r.ProfileVisibility) {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "profile_visibility")
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package cqe
+
+import "encoding/json"
+
+// PatchString JSON merge-patch 字段：未出现表示不修改，null 表示清空，字符串表示设置新值
+type PatchString struct {
+	Set   bool
+	Null  bool
+	Value string
+}
+
+func (p *PatchString) UnmarshalJSON(b []byte) error {
+	p.Set = true
+	if string(b) == "null" {
+		p.Null = true
+		p.Value = ""
+		return nil
+	}
+	return json.Unmarshal(b, &p.Value)
+}
+
+// ProfilePatchReq 个人资料部分更新（application/merge-patch+json 语义）
+type ProfilePatchReq struct {
+	Nickname    PatchString `json:"nickname"`
+	AvatarUrl   PatchString `json:"avatar_url"`
+	Description PatchString `json:"description"`
+	CoverUrl    PatchString `json:"cover_url"`
+	// IfMatch 客户端期望的资料版本（来自 If-Match 头），为空表示不校验
+	IfMatch *uint64 `json:"-"`
+}
@@ -0,0 +1,38 @@
+package cqe
+
+import "user-service/pkg/errno"
+
+// SuggestionQuery 推荐关注列表，未登录时返回热门账号
+type SuggestionQuery struct {
+	UserUUID string `form:"-"`
+	Size     int    `form:"size"` // 默认 10，最多 50
+}
+
+func (q *SuggestionQuery) Normalize() error {
+	if q == nil {
+		return errno.ErrParameterInvalid
+	}
+	if q.Size <= 0 {
+		q.Size = 10
+	}
+	if q.Size > 50 {
+		q.Size = 50
+	}
+	return nil
+}
+
+// DismissSuggestionReq 对推荐的账号点“不感兴趣”，之后不再推荐
+type DismissSuggestionReq struct {
+	UserUUID   string `json:"-"`
+	TargetUUID string `json:"target_uuid"`
+}
+
+func (req *DismissSuggestionReq) Normalize() error {
+	if req == nil || req.UserUUID == "" {
+		return errno.ErrParameterInvalid
+	}
+	if req.TargetUUID == "" || req.TargetUUID == req.UserUUID {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "target_uuid")
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package cqe
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"user-service/pkg/errno"
+	"user-service/pkg/restapi"
+)
+
+const (
+	searchKeywordMaxLen = 64
+	searchPageSizeMax   = 50
+)
+
+// UserSearchQuery 用户搜索，按昵称、用户名（含昵称拼音）前缀/模糊匹配，不匹配登录账号
+type UserSearchQuery struct {
+	restapi.PageQuery
+	Keyword string `form:"q" binding:"required" example:"zhangsan"`
+}
+
+func (q *UserSearchQuery) Validate() error {
+	q.Keyword = strings.TrimPrefix(strings.TrimSpace(q.Keyword), "@")
+	if q.Keyword == "" || utf8.RuneCountInString(q.Keyword) > searchKeywordMaxLen {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "q")
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = 20
+	}
+	if q.PageSize > searchPageSizeMax {
+		q.PageSize = searchPageSizeMax
+	}
+	if q.PageNum <= 0 {
+		q.PageNum = 1
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package cqe
+
+import (
+	"user-service/pkg/errno"
+	"user-service/pkg/restapi"
+)
+
+// SecurityEventQuery 运维查询安全事件
+type SecurityEventQuery struct {
+	restapi.PageQuery
+	UserUUID  string `form:"user_uuid"`
+	EventType string `form:"event_type"`
+	IP        string `form:"ip"`
+}
+
+func (q *SecurityEventQuery) Validate() error {
+	if q.UserUUID == "" && q.IP == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "user_uuid or ip")
+	}
+	return nil
+}
@@ -54,6 +54,22 @@ type UserSaveReq struct {
 	Account   string `json:"account,omitempty" example:"new_account"`
 	Nickname  string `json:"nickname,omitempty" example:"昵称"`
 	AvatarUrl string `json:"avatar_url,omitempty" example:"image/avatar/user-550e..."`
+	// IfMatch 客户端期望的资料版本（来自 If-Match 头），为空表示不校验
+	IfMatch *uint64 `json:"-"`
+}
+
+// ChangeHandleReq 修改公开用户名（@handle），可带前导 @
+type ChangeHandleReq struct {
+	Handle string `json:"handle" binding:"required" example:"alice_01"`
+	// IfMatch 客户端期望的资料版本（来自 If-Match 头），为空表示不校验
+	IfMatch *uint64 `json:"-"`
+}
+
+func (r *ChangeHandleReq) Validate() error {
+	if r == nil || r.Handle == "" {
+		return errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "handle")
+	}
+	return nil
 }
 
 // ChangePasswordReq 修改密码
@@ -0,0 +1,34 @@
+package dto
+
+// BlockStatusDto user_uuid 视角下与 target_uuid 的拉黑/屏蔽关系
+type BlockStatusDto struct {
+	UserUUID   string `json:"user_uuid"`
+	TargetUUID string `json:"target_uuid"`
+	Blocking   bool   `json:"blocking"`   // user_uuid 已拉黑 target_uuid
+	BlockedBy  bool   `json:"blocked_by"` // user_uuid 被 target_uuid 拉黑
+	Muting     bool   `json:"muting"`     // user_uuid 已屏蔽 target_uuid
+	// Blocked 任一方拉黑了另一方，评论、私信等服务据此拒绝互动
+	Blocked bool `json:"blocked"`
+}
+
+// BlockStatusBatchDto 批量关系查询结果，顺序与请求一致（已去重）
+type BlockStatusBatchDto struct {
+	List []BlockStatusDto `json:"list"`
+}
+
+// BlockedUser 拉黑/屏蔽列表中的用户
+type BlockedUser struct {
+	UserUUID  string `json:"user_uuid"`
+	Handle    string `json:"handle,omitempty"`
+	Nickname  string `json:"nickname"`
+	AvatarUrl string `json:"avatar_url"`
+	CreatedAt string `json:"created_at"` // 拉黑/屏蔽时间
+}
+
+// BlockListDto 拉黑/屏蔽列表
+type BlockListDto struct {
+	List       []BlockedUser `json:"list"`
+	Size       int           `json:"size"`
+	Total      int64         `json:"total"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
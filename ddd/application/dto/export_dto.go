@@ -0,0 +1,57 @@
+package dto
+
+// UserExportDto 数据导出任务状态
+type UserExportDto struct {
+	ExportUUID  string `json:"export_uuid"`
+	Status      string `json:"status"`
+	FileSize    int64  `json:"file_size,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	FinishedAt  string `json:"finished_at,omitempty"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+	DownloadUrl string `json:"download_url,omitempty"` // 仅 done 状态返回，短时有效
+	LinkExpires int64  `json:"link_expires_at,omitempty"`
+}
+
+// UserDataArchiveDto 导出包中 user_data.json 的内容。
+// 关注、粉丝、安全事件超出导出上限时只导出一部分，Complete 为 false，Sections 中对应部分 Truncated 为 true
+type UserDataArchiveDto struct {
+	ExportedAt     string               `json:"exported_at"`
+	Complete       bool                 `json:"complete"`
+	Sections       []UserDataSectionDto `json:"sections"`
+	Profile        UserDataProfileDto   `json:"profile"`
+	Followers      []UserDataFollowDto  `json:"followers"`
+	Followings     []UserDataFollowDto  `json:"followings"`
+	SecurityEvents []SecurityEventDto   `json:"security_events"`
+	Settings       UserDataSettingsDto  `json:"settings"`
+}
+
+// UserDataSettingsDto 用户的隐私设置与偏好设置（偏好含未设置项的默认值）
+type UserDataSettingsDto struct {
+	Privacy     PrivacySettingsDto     `json:"privacy"`
+	Preferences map[string]interface{} `json:"preferences"`
+}
+
+type UserDataProfileDto struct {
+	UserUUID    string `json:"user_uuid"`
+	Account     string `json:"account"`
+	Handle      string `json:"handle"`
+	Nickname    string `json:"nickname"`
+	AvatarUrl   string `json:"avatar_url"`
+	Description string `json:"description"`
+	CoverUrl    string `json:"cover_url"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// UserDataSectionDto 导出包中某部分的导出条数与实际总数，同时写入 manifest.csv
+type UserDataSectionDto struct {
+	Name      string `json:"name"`
+	Exported  int    `json:"exported"`
+	Total     int64  `json:"total"`
+	Truncated bool   `json:"truncated"`
+}
+
+type UserDataFollowDto struct {
+	UserUUID   string `json:"user_uuid"`
+	FollowedAt string `json:"followed_at"`
+}
@@ -3,12 +3,47 @@ package dto
 // 关注状态
 type FollowStatusDto struct {
 	Following bool `json:"following"`
+	Requested bool `json:"requested"` // 已向私密账号发起关注申请，待对方同意
 }
 
-// 简单关注用户
+// 关注/粉丝列表中的用户，附带展示资料与访问者视角的关注关系
 type FollowUser struct {
 	UserUUID  string `json:"user_uuid"`
-	CreatedAt string `json:"created_at"`
+	Handle    string `json:"handle,omitempty"`
+	Nickname  string `json:"nickname"`
+	AvatarUrl string `json:"avatar_url"`
+	// Following 访问者是否关注了该用户，FollowedBy 该用户是否关注了访问者；未登录时均为 false
+	Following  bool   `json:"following"`
+	FollowedBy bool   `json:"followed_by"`
+	Mutual     bool   `json:"mutual"`     // 与访问者互相关注
+	CreatedAt  string `json:"created_at"` // 关注时间
+}
+
+// FollowStatusItem 调用方与某个用户的双向关注关系
+type FollowStatusItem struct {
+	TargetUUID string `json:"target_uuid"`
+	Following  bool   `json:"following"`   // 调用方关注了该用户
+	FollowedBy bool   `json:"followed_by"` // 该用户关注了调用方
+}
+
+// FollowStatusBatchDto 批量关注关系，顺序与请求一致（已去重）
+type FollowStatusBatchDto struct {
+	List []FollowStatusItem `json:"list"`
+}
+
+// CommonFollowersDto “你关注的 A、B 等 N 人也关注了 TA”，List 为示例用户，Total 为总人数。
+// 只统计访问者最近关注的 200 人，访问者关注更多人时 TotalApprox 为 true，Total 可能偏小（应展示为“N+”）
+type CommonFollowersDto struct {
+	List        []FollowUser `json:"list"`
+	Total       int64        `json:"total"`
+	TotalApprox bool         `json:"total_approx"`
+}
+
+// FriendStatusDto 两个用户是否互相关注
+type FriendStatusDto struct {
+	UserUUID   string `json:"user_uuid"`
+	TargetUUID string `json:"target_uuid"`
+	IsFriend   bool   `json:"is_friend"`
 }
 
 // 关注/粉丝列表
@@ -0,0 +1,10 @@
+package dto
+
+// MediaUploadDto 直传凭证：客户端以 Method 和 Headers 请求 UploadUrl，完成后调用 complete 接口
+type MediaUploadDto struct {
+	ObjectKey string            `json:"object_key"`
+	UploadUrl string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt int64             `json:"expires_at"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+// ModerationHitDto 敏感词命中，Start/End 为原文字符（rune）下标，闭区间
+type ModerationHitDto struct {
+	Word  string `json:"word"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// ModerationCheckDto 敏感词测试结果
+type ModerationCheckDto struct {
+	Hit    bool               `json:"hit"`
+	Hits   []ModerationHitDto `json:"hits"`
+	Masked string             `json:"masked"`
+	Words  int                `json:"words"` // 当前词表词条数
+}
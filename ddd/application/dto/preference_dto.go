@@ -0,0 +1,11 @@
+package dto
+
+// PreferencesDto 用户偏好设置，未设置的项为默认值
+type PreferencesDto struct {
+	Preferences map[string]interface{} `json:"preferences"`
+}
+
+// PreferenceBatchDto 批量偏好设置，key 为用户 UUID
+type PreferenceBatchDto struct {
+	Users map[string]map[string]interface{} `json:"users"`
+}
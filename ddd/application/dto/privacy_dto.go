@@ -0,0 +1,10 @@
+package dto
+
+// PrivacySettingsDto 隐私设置
+type PrivacySettingsDto struct {
+	PrivateAccount    bool   `json:"private_account"`
+	HideFollowerList  bool   `json:"hide_follower_list"`
+	HideFollowingList bool   `json:"hide_following_list"`
+	HideCounts        bool   `json:"hide_counts"`
+	ProfileVisibility string `json:"profile_visibility"`
+}
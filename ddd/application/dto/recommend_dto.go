@@ -0,0 +1,17 @@
+package dto
+
+// SuggestedUser 推荐关注的账号
+type SuggestedUser struct {
+	UserUUID  string `json:"user_uuid"`
+	Handle    string `json:"handle,omitempty"`
+	Nickname  string `json:"nickname"`
+	AvatarUrl string `json:"avatar_url"`
+	Reason    string `json:"reason"` // followed_by_followings / popular
+	// FollowedByCount 当前用户关注的人中有多少人关注了 TA（不计隐藏了关注列表的人），reason 为 followed_by_followings 时有值
+	FollowedByCount int64 `json:"followed_by_count,omitempty"`
+}
+
+// SuggestionListDto 推荐关注列表
+type SuggestionListDto struct {
+	List []SuggestedUser `json:"list"`
+}
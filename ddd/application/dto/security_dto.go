@@ -0,0 +1,18 @@
+package dto
+
+// SecurityEventDto 安全事件
+type SecurityEventDto struct {
+	EventType string            `json:"event_type"`
+	Result    string            `json:"result"`
+	IP        string            `json:"ip"`
+	UserAgent string            `json:"user_agent"`
+	RequestID string            `json:"request_id,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt string            `json:"created_at"`
+}
+
+// AdminSecurityEventDto 运维视角的安全事件，额外带用户标识
+type AdminSecurityEventDto struct {
+	UserUUID string `json:"user_uuid"`
+	SecurityEventDto
+}
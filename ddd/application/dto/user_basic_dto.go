@@ -2,18 +2,34 @@ package dto
 
 // UserBasicInfoDto 用户基本信息（公开）
 type UserBasicInfoDto struct {
-	UserUUID    string `json:"user_uuid"`
-	Nickname    string `json:"nickname,omitempty"`
-	AvatarUrl   string `json:"avatar_url,omitempty"`
-	Description string `json:"description,omitempty"`
-	CoverUrl    string `json:"cover_url,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
+	UserUUID  string `json:"user_uuid"`
+	Handle    string `json:"handle,omitempty"`
+	Nickname  string `json:"nickname,omitempty"`
+	AvatarUrl string `json:"avatar_url,omitempty"`
+	// AvatarUrls 处理后的各尺寸头像（尺寸 -> URL），处理完成前为空
+	AvatarUrls  map[string]string `json:"avatar_urls,omitempty"`
+	Description string            `json:"description,omitempty"`
+	CoverUrl    string            `json:"cover_url,omitempty"`
+	CreatedAt   string            `json:"created_at,omitempty"`
+	// PrivateAccount 私密账号，关注需经对方同意
+	PrivateAccount bool `json:"private_account,omitempty"`
+	// Restricted 访问者无权查看完整资料，简介、封面等字段已隐去
+	Restricted bool `json:"restricted,omitempty"`
+}
+
+// UserByHandleDto 按用户名查询结果；命中旧用户名时 RedirectedFrom 为请求的旧用户名，客户端应跳转到 Handle
+type UserByHandleDto struct {
+	UserBasicInfoDto
+	RedirectedFrom string `json:"redirected_from,omitempty"`
 }
 
 // UserRelationStatDto 用户关系统计
 type UserRelationStatDto struct {
 	UserUUID       string `json:"user_uuid"`
-	FollowerCount  int64  `json:"follower_count"`  // 粉丝数
-	FollowingCount int64  `json:"following_count"` // 关注数
-	IsFollowed     bool   `json:"is_followed"`     // 当前用户是否已关注此用户
+	FollowerCount  int64  `json:"follower_count"`          // 粉丝数
+	FollowingCount int64  `json:"following_count"`         // 关注数
+	IsFollowed     bool   `json:"is_followed"`             // 当前用户是否已关注此用户
+	IsMutual       bool   `json:"is_mutual"`               // 是否互相关注
+	IsRequested    bool   `json:"is_requested"`            // 当前用户的关注申请待对方同意
+	CountsHidden   bool   `json:"counts_hidden,omitempty"` // 对方隐藏了计数，此时计数为 0
 }
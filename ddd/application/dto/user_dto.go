@@ -14,6 +14,7 @@ type UserLoginDto struct {
 	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 	ExpiresIn    int64  `json:"expires_in" example:"7200"`
 	AvatarURL    string `json:"avatar_url" example:"image/avatar/user-550e..."`
+	DeviceID     string `json:"device_id"` // 设备标识，客户端后续请求通过 X-Device-ID 或 Cookie 回传
 }
 
 type TokenRefreshDto struct {
@@ -26,4 +27,30 @@ type UserInfoDto struct {
 	UserUUID  string `json:"user_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
 	Nickname  string `json:"nickname,omitempty" example:"昵称"`
 	AvatarUrl string `json:"avatar_url" example:"image/avatar/user-550e..."`
+	// AvatarUrls 处理后的各尺寸头像（尺寸 -> URL），处理完成前为空
+	AvatarUrls map[string]string `json:"avatar_urls,omitempty"`
+	Handle     string            `json:"handle,omitempty" example:"alice_01"`
+	Version    uint64            `json:"version"` // 资料版本，与 ETag 一致
+}
+
+// UserProfileDto 当前用户完整资料
+type UserProfileDto struct {
+	UserUUID  string `json:"user_uuid"`
+	Handle    string `json:"handle"`
+	Nickname  string `json:"nickname"`
+	AvatarUrl string `json:"avatar_url"`
+	// AvatarUrls 处理后的各尺寸头像（尺寸 -> URL），处理完成前为空
+	AvatarUrls  map[string]string `json:"avatar_urls,omitempty"`
+	Description string            `json:"description"`
+	CoverUrl    string            `json:"cover_url"`
+	UpdatedAt   string            `json:"updated_at"`
+	Version     uint64            `json:"version"` // 资料版本，与 ETag 一致
+}
+
+// UserCacheStatsDto 用户资料缓存命中统计（进程维度，重启清零）
+type UserCacheStatsDto struct {
+	Hits         uint64  `json:"hits"`
+	NegativeHits uint64  `json:"negative_hits"` // 命中不存在占位
+	Misses       uint64  `json:"misses"`
+	HitRatio     float64 `json:"hit_ratio"`
 }
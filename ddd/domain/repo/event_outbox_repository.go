@@ -0,0 +1,17 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+// EventOutboxRepository 事件发件箱仓储接口；事件本身随业务写操作一并写入（见各仓储的 events 参数）
+type EventOutboxRepository interface {
+	ListPending(ctx context.Context, limit int) ([]*po.EventOutboxPo, error)
+	MarkSent(ctx context.Context, id uint64, now time.Time) error
+	MarkAttempt(ctx context.Context, id uint64, attempts int, status, errMsg string) error
+	DeleteSentBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	CountFailed(ctx context.Context) (int64, error)
+	DeleteFailedBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
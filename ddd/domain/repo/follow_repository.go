@@ -6,11 +6,45 @@ import (
 )
 
 type FollowRepository interface {
-	Follow(ctx context.Context, userUUID, targetUUID string) error
-	Unfollow(ctx context.Context, userUUID, targetUUID string) error
+	// Follow 从无记录或已取关转为关注，Unfollow 从关注转为已取关；返回本次是否改变了状态。
+	// events 与状态变更在同一事务内写入发件箱，未改变时不写入，缓存计数也只在改变时调整
+	Follow(ctx context.Context, userUUID, targetUUID string, events ...*po.EventOutboxPo) (bool, error)
+	Unfollow(ctx context.Context, userUUID, targetUUID string, events ...*po.EventOutboxPo) (bool, error)
 	IsFollowing(ctx context.Context, userUUID, targetUUID string) (bool, error)
+	// FollowState 返回关注关系的当前状态（vo.FollowState*），没有记录时为空
+	FollowState(ctx context.Context, userUUID, targetUUID string) (string, error)
+	// RemoveFollower 由 userUUID 移除粉丝 followerUUID：关注转为已取关，audit 与 events 同一事务写入；
+	// 对方当前未关注时返回 false，不写入任何内容
+	RemoveFollower(ctx context.Context, userUUID, followerUUID string, audit *po.RelationAuditPo, events ...*po.EventOutboxPo) (bool, error)
+	// Request 从无记录或已取关发起关注申请（Pending），不计入计数与列表；返回本次是否改变了状态
+	Request(ctx context.Context, userUUID, targetUUID string) (bool, error)
+	// ClaimRequestNotice 同一申请人对同一账号的申请通知在窗口期内只发一次，返回本次是否应发送；
+	// 缓存不可用时返回 true
+	ClaimRequestNotice(ctx context.Context, userUUID, targetUUID string) bool
+	// ResolveRequest 处理待处理的申请：approve 为 true 时转为关注，否则撤销。
+	// 申请不存在或已处理时返回 false；events 仅在状态变更成功时写入发件箱
+	ResolveRequest(ctx context.Context, userUUID, targetUUID string, approve bool, events ...*po.EventOutboxPo) (bool, error)
+	// ListFollowRequests 收到的待处理申请，按申请时间倒序，光标格式与关注列表一致
+	ListFollowRequests(ctx context.Context, targetUUID string, cursor string, limit int) ([]*po.FollowPo, int64, error)
 	ListFollowers(ctx context.Context, targetUUID string, cursor string, limit int) ([]*po.FollowPo, int64, error)
 	ListFollowings(ctx context.Context, userUUID string, cursor string, limit int) ([]*po.FollowPo, int64, error)
 	CountFollowers(ctx context.Context, targetUUID string) (int64, error)
 	CountFollowings(ctx context.Context, userUUID string) (int64, error)
+	// CountFollowersBatch 批量统计粉丝数，没有粉丝的用户不在结果中
+	CountFollowersBatch(ctx context.Context, targetUUIDs []string) (map[string]int64, error)
+	// ListFriends 互相关注的好友列表，按当前用户关注对方的时间倒序，光标格式与关注列表一致
+	ListFriends(ctx context.Context, userUUID string, cursor string, limit int) ([]*po.FollowPo, int64, error)
+	CountFriends(ctx context.Context, userUUID string) (int64, error)
+	// IsFriend 两个用户是否互相关注
+	IsFriend(ctx context.Context, userUUID, otherUUID string) (bool, error)
+	// FollowedAmong 返回 targetUUIDs 中被 userUUID 关注的用户集合
+	FollowedAmong(ctx context.Context, userUUID string, targetUUIDs []string) (map[string]bool, error)
+	// CommonFollowers targetUUID 的粉丝中 viewerUUID 关注了的人，返回最多 limit 条（对方关注 targetUUID 的记录）与总数；
+	// 只在 viewerUUID 最近关注的一部分人中求交集，viewerUUID 关注的人更多时 approx 为 true，总数为下限
+	CommonFollowers(ctx context.Context, viewerUUID, targetUUID string, limit int) (list []*po.FollowPo, total int64, approx bool, err error)
+	// RelationAmong 批量返回 userUUID 与 targetUUIDs 的双向关注关系：following 为 userUUID 关注了谁，
+	// followedBy 为谁关注了 userUUID；优先读关系缓存，未命中的统一一次查库
+	RelationAmong(ctx context.Context, userUUID string, targetUUIDs []string) (following, followedBy map[string]bool, err error)
+	// FollowersAmong 返回 userUUIDs 中关注了 targetUUID 的用户集合
+	FollowersAmong(ctx context.Context, targetUUID string, userUUIDs []string) (map[string]bool, error)
 }
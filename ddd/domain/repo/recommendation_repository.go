@@ -0,0 +1,23 @@
+package repo
+
+import (
+	"context"
+	"user-service/ddd/domain/vo"
+)
+
+// RecommendationRepository 推荐关注仓储接口：候选计算、预计算结果缓存与“不感兴趣”记录
+type RecommendationRepository interface {
+	// FriendsOfFriends 用户最近关注的 sampleSize 个人还关注了谁，按关注人数倒序；
+	// 隐藏了关注列表的人不参与展开，已关注、已申请关注的账号与本人不在结果中
+	FriendsOfFriends(ctx context.Context, userUUID string, sampleSize, limit int) ([]vo.SuggestedUser, error)
+	// Popular 粉丝数最多的 poolSize 个账号，整个候选池全局共享缓存，调用方按需截取；
+	// 同一部署内 poolSize 应保持不变。refresh 为 true 时跳过缓存重新统计
+	Popular(ctx context.Context, poolSize int, refresh bool) ([]vo.SuggestedUser, error)
+	// GetSuggestions 读取预计算的推荐列表，未缓存时 ok 为 false
+	GetSuggestions(ctx context.Context, userUUID string) (list []vo.SuggestedUser, ok bool, err error)
+	SaveSuggestions(ctx context.Context, userUUID string, list []vo.SuggestedUser) error
+	// Dismiss 记录用户不再希望被推荐 targetUUID，重复操作幂等
+	Dismiss(ctx context.Context, userUUID, targetUUID string) error
+	// DismissedAmong 返回 targetUUIDs 中被用户忽略过的账号
+	DismissedAmong(ctx context.Context, userUUID string, targetUUIDs []string) (map[string]bool, error)
+}
@@ -0,0 +1,21 @@
+package repo
+
+import (
+	"context"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+// SecurityEventQuery 安全事件查询条件，空字段不参与过滤
+type SecurityEventQuery struct {
+	UserUUID  string
+	EventType string
+	IP        string
+	Offset    int
+	Limit     int
+}
+
+// SecurityEventRepository 安全审计事件仓储接口
+type SecurityEventRepository interface {
+	SaveEvents(ctx context.Context, events []*po.SecurityEventPo) error
+	ListEvents(ctx context.Context, query SecurityEventQuery) ([]*po.SecurityEventPo, int64, error)
+}
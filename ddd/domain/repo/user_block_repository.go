@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+// UserBlockRepository 拉黑/屏蔽关系仓储接口
+type UserBlockRepository interface {
+	Add(ctx context.Context, userUUID, targetUUID, kind string) error
+	Remove(ctx context.Context, userUUID, targetUUID, kind string) error
+	// IsBlockedBetween 任一方拉黑了另一方
+	IsBlockedBetween(ctx context.Context, userUUID, otherUUID string) (bool, error)
+	// Relations 返回 userUUID 与 targetUUIDs 之间两个方向的全部关系
+	Relations(ctx context.Context, userUUID string, targetUUIDs []string) ([]*po.UserBlockPo, error)
+	// List 用户拉黑/屏蔽的人，光标格式与关注列表一致
+	List(ctx context.Context, userUUID, kind string, cursor string, limit int) ([]*po.UserBlockPo, int64, error)
+}
@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+// UserDeviceRepository 登录设备仓储接口，查询不到时返回 nil
+type UserDeviceRepository interface {
+	GetDevice(ctx context.Context, userUUID, deviceID string) (*po.UserDevicePo, error)
+	GetLatestDevice(ctx context.Context, userUUID string) (*po.UserDevicePo, error)
+	SaveDevice(ctx context.Context, device *po.UserDevicePo) error
+	// ListActiveUsers 按 user_uuid 升序分批返回 since 之后登录过的用户
+	ListActiveUsers(ctx context.Context, since time.Time, afterUUID string, limit int) ([]string, error)
+}
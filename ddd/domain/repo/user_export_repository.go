@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+// UserExportRepository 个人数据导出任务仓储接口
+type UserExportRepository interface {
+	CreateExport(ctx context.Context, export *po.UserExportPo) error
+	GetExportByUUID(ctx context.Context, exportUUID string) (*po.UserExportPo, error)
+	GetActiveExport(ctx context.Context, userUUID string) (*po.UserExportPo, error)
+	ListPending(ctx context.Context, limit int) ([]*po.UserExportPo, error)
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*po.UserExportPo, error)
+	TransitStatus(ctx context.Context, id uint64, from, to string, fields map[string]interface{}) (bool, error)
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"user-service/ddd/infrastructure/database/po"
+)
+
+// UserHandleRepository 公开用户名仓储接口
+type UserHandleRepository interface {
+	// GetUserByHandle 按当前用户名查询，不存在时返回 nil
+	GetUserByHandle(ctx context.Context, handle string) (*po.UserPo, error)
+	// GetActiveHistory 查询保留期内的旧用户名，不存在时返回 nil
+	GetActiveHistory(ctx context.Context, handle string, now time.Time) (*po.UserHandleHistoryPo, error)
+	// ChangeHandle 以 version 为条件更新用户名并记录旧用户名，events 在同一事务内写入发件箱；
+	// 版本不一致返回 errno.ErrUserVersionConflict
+	ChangeHandle(ctx context.Context, userUUID string, version uint64, oldHandle, newHandle string, now, redirectUntil time.Time, events ...*po.EventOutboxPo) error
+}
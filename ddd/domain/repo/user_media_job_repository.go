@@ -0,0 +1,15 @@
+package repo
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+// UserMediaJobRepository 头像处理任务仓储接口
+type UserMediaJobRepository interface {
+	CreateJob(ctx context.Context, job *po.UserMediaJobPo) error
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*po.UserMediaJobPo, error)
+	TransitStatus(ctx context.Context, id uint64, from, to string, fields map[string]interface{}) (bool, error)
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,10 @@
+package repo
+
+import "context"
+
+// UserPreferenceRepository 偏好设置仓储接口，值均为 JSON 编码字符串，只包含用户显式设置过的项
+type UserPreferenceRepository interface {
+	GetPreferences(ctx context.Context, userUUIDs []string) (map[string]map[string]string, error)
+	// SavePreferences 写入 set 并删除 deleteKeys；replace 为 true 时以 set 整体替换
+	SavePreferences(ctx context.Context, userUUID string, set map[string]string, deleteKeys []string, replace bool) error
+}
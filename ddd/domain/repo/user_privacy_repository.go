@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"context"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+// UserPrivacyRepository 隐私设置仓储接口，未设置过时返回 nil
+type UserPrivacyRepository interface {
+	GetPrivacy(ctx context.Context, userUUID string) (*po.UserPrivacyPo, error)
+	// GetPrivacyBatch 批量读取设置，未设置过的用户不在结果中
+	GetPrivacyBatch(ctx context.Context, userUUIDs []string) (map[string]*po.UserPrivacyPo, error)
+	SavePrivacy(ctx context.Context, setting *po.UserPrivacyPo) error
+}
@@ -10,7 +10,17 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, userPo *po.UserPo) error
 	GetUserByAccount(ctx context.Context, account string) (*po.UserPo, error)
 	GetUserByUUID(ctx context.Context, userUUID string) (*po.UserPo, error)
-	UpdateUser(ctx context.Context, userPo *po.UserPo) error
+	// UpdateUser 以 userPo.Version 为条件更新，版本不一致返回 errno.ErrUserVersionConflict；
+	// events 与更新在同一事务内写入发件箱
+	UpdateUser(ctx context.Context, userPo *po.UserPo, events ...*po.EventOutboxPo) error
 	ExistsByAccount(ctx context.Context, account string) (bool, error)
 	ExistsByUUID(ctx context.Context, userUUID string) (bool, error)
+	// GetUsersByUUIDs 批量查询，不存在的用户不在结果中
+	GetUsersByUUIDs(ctx context.Context, userUUIDs []string) ([]*po.UserPo, error)
+	// GetUserProfile 经缓存读取单个用户的展示用资料（不含密码），不存在返回 errno.ErrUserNotFound
+	GetUserProfile(ctx context.Context, userUUID string) (*po.UserPo, error)
+	// GetUserProfiles 经缓存批量读取展示用资料（不含密码），不存在的用户不在结果中
+	GetUserProfiles(ctx context.Context, userUUIDs []string) (map[string]*po.UserPo, error)
+	// ScanActiveUsers 按 id 升序分批读取未删除的用户
+	ScanActiveUsers(ctx context.Context, afterID uint64, limit int) ([]*po.UserPo, error)
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"strconv"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -20,25 +22,39 @@ import (
 type AuthService struct {
 	userRepo repo.UserRepository
 	jwtUtil  *utils.JWTUtil
+	audit    *SecurityEventRecorder
+	risk     *LoginRiskService
 }
 
 func NewAuthService() *AuthService {
-	return &AuthService{userRepo: persistence.NewUserRepository(), jwtUtil: utils.DefaultJWTUtil()}
+	return &AuthService{
+		userRepo: persistence.NewUserRepository(),
+		jwtUtil:  utils.DefaultJWTUtil(),
+		audit:    DefaultSecurityEventRecorder(),
+		risk:     NewLoginRiskService(),
+	}
 }
 
 func (s *AuthService) Login(ctx context.Context, req *cqe.UserLoginReq, opts vo.AuthOptions) (*dto.UserLoginDto, error) {
 	user, err := s.userRepo.GetUserByAccount(ctx, req.Account)
 	if err != nil {
+		// 不记录尝试的账号原文（可能是误输入的密码，也便于撞库枚举）；只有账号确实不存在才记为 user_not_found
+		if errors.Is(err, errno.ErrUserNotFound) {
+			s.audit.Record(ctx, "", vo.SecurityEventLoginFailure, vo.SecurityResultFailure, map[string]string{"reason": "user_not_found"})
+		}
 		return nil, err
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		s.audit.Record(ctx, user.UserUUID, vo.SecurityEventLoginFailure, vo.SecurityResultFailure, map[string]string{"reason": "password_incorrect"})
 		return nil, errno.ErrPasswordIncorrect
 	}
 
 	store := revocation.DefaultRevocationStore()
 	if store != nil {
-		// 简单单端登录：每次登录前清理该用户旧的刷新令牌记录
-		_ = store.DeleteAllRefreshTokens(ctx, user.UserUUID)
+		// 简单单端登录：每次登录前清理该用户旧的刷新令牌记录，确有令牌被吊销时才记录
+		if n, err := store.DeleteAllRefreshTokens(ctx, user.UserUUID); err == nil && n > 0 {
+			s.audit.Record(ctx, user.UserUUID, vo.SecurityEventTokenRevoked, vo.SecurityResultSuccess, map[string]string{"reason": "new_login", "count": strconv.FormatInt(n, 10)})
+		}
 	}
 
 	accessToken, err := s.jwtUtil.GenerateAccessTokenWithUUID(user.UserUUID, user.Id)
@@ -56,6 +72,8 @@ func (s *AuthService) Login(ctx context.Context, req *cqe.UserLoginReq, opts vo.
 			return nil, errno.ErrRefreshTokenGenerate
 		}
 	}
+	s.audit.Record(ctx, user.UserUUID, vo.SecurityEventLoginSuccess, vo.SecurityResultSuccess, nil)
+	deviceID := s.risk.Assess(ctx, user.UserUUID)
 	expiresIn := int64(opts.AccessTTL.Seconds())
 	return &dto.UserLoginDto{
 		UserUUID:     user.UserUUID,
@@ -63,7 +81,8 @@ func (s *AuthService) Login(ctx context.Context, req *cqe.UserLoginReq, opts vo.
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		ExpiresIn:    expiresIn,
-		AvatarURL:    user.AvatarUrl,
+		AvatarURL:    PublicAvatarURL(user),
+		DeviceID:     deviceID,
 	}, nil
 }
 
@@ -78,6 +97,7 @@ func (s *AuthService) Refresh(ctx context.Context, req *cqe.TokenRefreshReq, opt
 		oldHash := hex.EncodeToString(h[:])
 		ok, err := store.ExistsRefreshToken(ctx, userUUID, oldHash)
 		if err != nil || !ok {
+			s.audit.Record(ctx, userUUID, vo.SecurityEventTokenRefresh, vo.SecurityResultFailure, map[string]string{"reason": "refresh_token_revoked"})
 			return nil, errno.ErrUnauthorized
 		}
 	}
@@ -101,6 +121,7 @@ func (s *AuthService) Refresh(ctx context.Context, req *cqe.TokenRefreshReq, opt
 			return nil, errno.ErrRefreshTokenGenerate
 		}
 	}
+	s.audit.Record(ctx, userUUID, vo.SecurityEventTokenRefresh, vo.SecurityResultSuccess, nil)
 	expiresIn := int64(opts.AccessTTL.Seconds())
 	return &dto.TokenRefreshDto{
 		AccessToken:  accessToken,
@@ -119,8 +140,10 @@ func (s *AuthService) Logout(ctx context.Context, req *cqe.TokenRefreshReq) erro
 		h := sha256.Sum256([]byte(req.RefreshToken))
 		tokenHash := hex.EncodeToString(h[:])
 		if err := store.DeleteRefreshToken(ctx, userUUID, tokenHash); err != nil {
+			s.audit.Record(ctx, userUUID, vo.SecurityEventLogout, vo.SecurityResultFailure, nil)
 			return errno.ErrUnauthorized
 		}
 	}
+	s.audit.Record(ctx, userUUID, vo.SecurityEventLogout, vo.SecurityResultSuccess, nil)
 	return nil
 }
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	kafkainfra "user-service/ddd/infrastructure/kafka"
+	"user-service/internal/resource"
+	"user-service/pkg/config"
+	"user-service/pkg/errno"
+	"user-service/pkg/imageproc"
+	"user-service/pkg/logger"
+)
+
+const (
+	avatarJobBatchSize  = 10
+	avatarJobStaleAfter = 5 * time.Minute
+	avatarJobBaseDelay  = 10 * time.Second
+	// avatarSaveAttempts 写回尺寸遇到资料并发修改时的重读次数
+	avatarSaveAttempts = 3
+)
+
+// AvatarProcessor 头像异步处理：解码、去除元数据、居中裁剪并生成多尺寸 JPEG，写回可用尺寸。
+// 写回与资料变更事件同一事务提交，随后删除原图，避免带 EXIF/GPS 的原始文件留在存储桶中。
+// 任务失败按指数退避重试，图片本身无法解码时直接失败。
+type AvatarProcessor struct {
+	jobRepo     repo.UserMediaJobRepository
+	userRepo    repo.UserRepository
+	events      *ProfileEventEmitter
+	sizes       []int
+	maxSize     int64
+	maxAttempts int
+}
+
+func NewAvatarProcessor() *AvatarProcessor {
+	p := &AvatarProcessor{
+		jobRepo:     persistence.NewUserMediaJobRepository(),
+		userRepo:    persistence.NewUserRepository(),
+		events:      NewProfileEventEmitter(),
+		sizes:       []int{64, 256, 512},
+		maxSize:     5 << 20,
+		maxAttempts: 5,
+	}
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		p.sizes = cfg.Profile.AvatarSizes
+		p.maxSize = cfg.Profile.AvatarMaxSize
+		p.maxAttempts = cfg.Profile.AvatarJobMaxAttempts
+	}
+	return p
+}
+
+// AvatarVariantKey 头像某尺寸的对象 key，与原图同目录：<name>_<size>.jpg
+func AvatarVariantKey(avatarKey string, size int) string {
+	return strings.TrimSuffix(avatarKey, path.Ext(avatarKey)) + "_" + strconv.Itoa(size) + ".jpg"
+}
+
+// AvatarVariantURLs 按已生成的尺寸返回 size -> key，未处理完成时为 nil
+func AvatarVariantURLs(avatarKey, variants string) map[string]string {
+	if avatarKey == "" || variants == "" {
+		return nil
+	}
+	res := make(map[string]string)
+	for _, s := range strings.Split(variants, ",") {
+		if size, err := strconv.Atoi(s); err == nil && size > 0 {
+			res[s] = AvatarVariantKey(avatarKey, size)
+		}
+	}
+	return res
+}
+
+// PublicAvatarURL 对外展示的头像：处理完成后使用最大尺寸（已去除元数据），否则使用原值。
+// 处理完成后原图即被删除，接口、事件与缓存中的头像都应经此取值
+func PublicAvatarURL(user *po.UserPo) string {
+	best, bestSize := user.AvatarUrl, 0
+	for size, url := range AvatarVariantURLs(user.AvatarUrl, user.AvatarVariants) {
+		if n, err := strconv.Atoi(size); err == nil && n > bestSize {
+			best, bestSize = url, n
+		}
+	}
+	return best
+}
+
+// Enqueue 为新头像创建处理任务
+func (p *AvatarProcessor) Enqueue(ctx context.Context, userUUID, avatarKey string) error {
+	return p.jobRepo.CreateJob(ctx, &po.UserMediaJobPo{
+		UserUUID:  userUUID,
+		ObjectKey: avatarKey,
+		Status:    vo.MediaJobStatusPending,
+		NextRunAt: time.Now(),
+	})
+}
+
+// RunPending 抢占并处理一批到期任务，返回处理数量
+func (p *AvatarProcessor) RunPending(ctx context.Context) (int, error) {
+	claim := jobClaim{
+		store:      p.jobRepo,
+		name:       "avatar",
+		pending:    vo.MediaJobStatusPending,
+		running:    vo.MediaJobStatusRunning,
+		staleAfter: avatarJobStaleAfter,
+	}
+	return runClaimed(ctx, claim,
+		func() ([]*po.UserMediaJobPo, error) { return p.jobRepo.ListDue(ctx, time.Now(), avatarJobBatchSize) },
+		func(job *po.UserMediaJobPo) (uint64, *int) { return job.Id, &job.Attempts },
+		func(job *po.UserMediaJobPo) { p.process(ctx, job) })
+}
+
+func (p *AvatarProcessor) process(ctx context.Context, job *po.UserMediaJobPo) {
+	variants, err := p.generate(ctx, job)
+	if err != nil {
+		next := vo.MediaJobStatusPending
+		fields := map[string]interface{}{"error_msg": truncate(err.Error(), 255)}
+		if _, permanent := err.(permanentError); permanent || job.Attempts >= p.maxAttempts {
+			next = vo.MediaJobStatusFailed
+		} else {
+			fields["next_run_at"] = time.Now().Add(avatarJobBaseDelay << (job.Attempts - 1))
+		}
+		logger.WithContext(ctx).Warnf("avatar job failed job=%d user=%s attempts=%d err=%v", job.Id, job.UserUUID, job.Attempts, err)
+		_, _ = p.jobRepo.TransitStatus(ctx, job.Id, vo.MediaJobStatusRunning, next, fields)
+		return
+	}
+	matched, err := p.saveVariants(ctx, job, variants)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("save avatar variants failed job=%d err=%v", job.Id, err)
+		_, _ = p.jobRepo.TransitStatus(ctx, job.Id, vo.MediaJobStatusRunning, vo.MediaJobStatusPending,
+			map[string]interface{}{"error_msg": truncate(err.Error(), 255), "next_run_at": time.Now().Add(avatarJobBaseDelay)})
+		return
+	}
+	if matched {
+		p.removeOriginal(ctx, job)
+	} else {
+		// 处理期间头像已被替换，生成的文件随旧头像一并清理
+		logger.WithContext(ctx).Infof("avatar replaced during processing job=%d user=%s", job.Id, job.UserUUID)
+	}
+	_, _ = p.jobRepo.TransitStatus(ctx, job.Id, vo.MediaJobStatusRunning, vo.MediaJobStatusDone, map[string]interface{}{"error_msg": ""})
+	logger.WithContext(ctx).Infof("avatar processed job=%d user=%s sizes=%s", job.Id, job.UserUUID, variants)
+}
+
+// saveVariants 头像未被替换时写入已生成的尺寸，对外头像地址随之变化，同事务写入资料变更事件。
+// 按读取时的 version 条件更新，与用户的资料编辑并发时重读后重试；返回是否命中
+func (p *AvatarProcessor) saveVariants(ctx context.Context, job *po.UserMediaJobPo, variants string) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		user, err := p.userRepo.GetUserByUUID(ctx, job.UserUUID)
+		if errors.Is(err, errno.ErrUserNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if user.AvatarUrl != job.ObjectKey {
+			return false, nil
+		}
+		before := SnapshotProfile(user)
+		user.AvatarVariants = variants
+		events := p.events.Events(ctx, kafkainfra.ProfileChangeSourceAvatar, before, user)
+		err = p.userRepo.UpdateUser(ctx, user, events...)
+		if errors.Is(err, errno.ErrUserVersionConflict) && attempt < avatarSaveAttempts {
+			continue
+		}
+		return err == nil, err
+	}
+}
+
+// generate 读取原图并上传各尺寸，返回逗号分隔的尺寸列表
+func (p *AvatarProcessor) generate(ctx context.Context, job *po.UserMediaJobPo) (string, error) {
+	// 只处理本人上传目录下的对象，外部 URL 或他人的 key 不读取也不覆盖
+	if !strings.HasPrefix(job.ObjectKey, MediaKeyPrefix(vo.MediaKindAvatar, job.UserUUID)) || strings.Contains(job.ObjectKey, "..") {
+		return "", permanentError{fmt.Errorf("avatar key %q outside user prefix", job.ObjectKey)}
+	}
+	client := resource.DefaultMinioResource().Client()
+	if client == nil {
+		return "", fmt.Errorf("object storage not configured")
+	}
+	rc, err := client.GetObject(ctx, job.ObjectKey)
+	if err != nil {
+		return "", err
+	}
+	data, err := imageproc.ReadLimited(rc, p.maxSize)
+	rc.Close()
+	if err != nil {
+		return "", permanentError{err}
+	}
+	outputs, err := imageproc.SquareVariants(data, p.sizes)
+	if err != nil {
+		return "", permanentError{err}
+	}
+	sizes := make([]int, 0, len(outputs))
+	for size, img := range outputs {
+		if err := client.PutObject(ctx, AvatarVariantKey(job.ObjectKey, size), img, "image/jpeg"); err != nil {
+			return "", err
+		}
+		sizes = append(sizes, size)
+	}
+	sort.Ints(sizes)
+	parts := make([]string, 0, len(sizes))
+	for _, s := range sizes {
+		parts = append(parts, strconv.Itoa(s))
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// removeOriginal 删除已生成尺寸的原图，失败只记录日志，头像展示不依赖原图
+func (p *AvatarProcessor) removeOriginal(ctx context.Context, job *po.UserMediaJobPo) {
+	client := resource.DefaultMinioResource().Client()
+	if client == nil {
+		return
+	}
+	if err := client.DeleteObject(ctx, job.ObjectKey); err != nil {
+		logger.WithContext(ctx).Warnf("delete avatar original failed job=%d key=%s err=%v", job.Id, job.ObjectKey, err)
+	}
+}
+
+// permanentError 重试无意义的错误（图片损坏、格式不支持等）
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/persistence"
+)
+
+// BlockService 拉黑/屏蔽：拉黑时双向解除关注；拉黑关系存在期间双方不能互相关注、查看对方主页。
+// 屏蔽只记录关系，由评论、动态等服务据此过滤内容
+type BlockService struct {
+	blockRepo repo.UserBlockRepository
+	social    *SocialService
+}
+
+func NewBlockService() *BlockService {
+	return &BlockService{
+		blockRepo: persistence.NewUserBlockRepository(),
+		social:    NewSocialService(),
+	}
+}
+
+// Apply 执行拉黑/解除拉黑/屏蔽/解除屏蔽，重复操作幂等
+func (s *BlockService) Apply(ctx context.Context, userUUID, targetUUID, action string) error {
+	switch action {
+	case vo.BlockActionBlock:
+		// 先写拉黑记录再解除双向关注。关注写入在事务内对拉黑记录加锁复查，
+		// 与本次拉黑并发的关注要么因看到拉黑记录而回滚，要么先提交、再被下面的解除关注清理
+		if err := s.blockRepo.Add(ctx, userUUID, targetUUID, vo.BlockKindBlock); err != nil {
+			return err
+		}
+		if err := s.social.removeFollow(ctx, userUUID, targetUUID); err != nil {
+			return err
+		}
+		return s.social.removeFollow(ctx, targetUUID, userUUID)
+	case vo.BlockActionUnblock:
+		return s.blockRepo.Remove(ctx, userUUID, targetUUID, vo.BlockKindBlock)
+	case vo.BlockActionMute:
+		return s.blockRepo.Add(ctx, userUUID, targetUUID, vo.BlockKindMute)
+	case vo.BlockActionUnmute:
+		return s.blockRepo.Remove(ctx, userUUID, targetUUID, vo.BlockKindMute)
+	default:
+		// 理论上不会走到这里，Action 已在 CQE 层校验。
+		return fmt.Errorf("unsupported block action: %s", action)
+	}
+}
+
+// IsBlockedBetween 任一方拉黑了另一方；未登录访客或查看自己时为 false
+func (s *BlockService) IsBlockedBetween(ctx context.Context, userUUID, otherUUID string) (bool, error) {
+	if userUUID == "" || otherUUID == "" || userUUID == otherUUID {
+		return false, nil
+	}
+	return s.blockRepo.IsBlockedBetween(ctx, userUUID, otherUUID)
+}
+
+// BlockRelation userUUID 视角下与某个用户的关系
+type BlockRelation struct {
+	Blocking  bool // 已拉黑对方
+	BlockedBy bool // 被对方拉黑
+	Muting    bool // 已屏蔽对方
+}
+
+// Relations 批量查询 userUUID 与 targetUUIDs 的关系，每个 target 都有结果
+func (s *BlockService) Relations(ctx context.Context, userUUID string, targetUUIDs []string) (map[string]*BlockRelation, error) {
+	rows, err := s.blockRepo.Relations(ctx, userUUID, targetUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*BlockRelation, len(targetUUIDs))
+	for _, t := range targetUUIDs {
+		res[t] = &BlockRelation{}
+	}
+	for _, row := range rows {
+		switch {
+		case row.UserUUID == userUUID && res[row.TargetUUID] != nil:
+			rel := res[row.TargetUUID]
+			if row.Kind == vo.BlockKindBlock {
+				rel.Blocking = true
+			} else if row.Kind == vo.BlockKindMute {
+				rel.Muting = true
+			}
+		case row.TargetUUID == userUUID && res[row.UserUUID] != nil:
+			if row.Kind == vo.BlockKindBlock {
+				res[row.UserUUID].BlockedBy = true
+			}
+		}
+	}
+	return res, nil
+}
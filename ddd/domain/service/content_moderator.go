@@ -0,0 +1,54 @@
+package service
+
+import (
+	"user-service/pkg/config"
+	"user-service/pkg/errno"
+	"user-service/pkg/moderation"
+)
+
+const (
+	moderationModeReject = "reject"
+	moderationModeMask   = "mask"
+
+	reasonSensitive = "sensitive"
+)
+
+// ContentModerator 用户文本敏感词检查。账号、用户名等标识类字段整词命中即拒绝；
+// 昵称、简介等展示类字段按配置拒绝或打码
+type ContentModerator struct {
+	mode string
+}
+
+func NewContentModerator() *ContentModerator {
+	m := &ContentModerator{mode: moderationModeReject}
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Moderation.Mode == moderationModeMask {
+		m.mode = moderationModeMask
+	}
+	return m
+}
+
+// CheckIdentifier 标识类字段整词命中敏感词时返回 ErrContentSensitive
+func (m *ContentModerator) CheckIdentifier(field, text string) error {
+	if moderation.Default().ContainsWord(text) {
+		return errno.NewSimpleBizError(errno.ErrContentSensitive, nil, field)
+	}
+	return nil
+}
+
+// Sanitize 展示类字段：mask 模式返回打码后的文本；reject 模式命中时 ok 为 false
+func (m *ContentModerator) Sanitize(text string) (string, bool) {
+	if m.mode == moderationModeMask {
+		masked, _ := moderation.Default().Mask(text)
+		return masked, true
+	}
+	return text, !moderation.Default().Contains(text)
+}
+
+// SanitizeField 同 Sanitize，reject 模式命中时返回 ErrContentSensitive
+func (m *ContentModerator) SanitizeField(field, text string) (string, error) {
+	out, ok := m.Sanitize(text)
+	if !ok {
+		return text, errno.NewSimpleBizError(errno.ErrContentSensitive, nil, field)
+	}
+	return out, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/cache"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	kafkainfra "user-service/ddd/infrastructure/kafka"
+	"user-service/internal/resource"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+)
+
+const (
+	outboxCleanupBatch = 500
+	// outboxLeaseTTL 中继租约有效期；投递一批耗时超过 1/3 时续期，续期失败即停止本轮
+	outboxLeaseTTL = 30 * time.Second
+	// outboxMaxRetryDelay 单条事件重试间隔的上限
+	outboxMaxRetryDelay = time.Minute
+)
+
+// outboxEnabled 未启用 Kafka 时不写发件箱，避免事件无人投递而堆积
+func outboxEnabled() bool {
+	cfg := config.GetGlobalConfig()
+	return cfg != nil && cfg.Kafka.Enabled
+}
+
+// newOutboxEvent 编码事件，交给仓储与业务数据在同一事务内写入
+func newOutboxEvent(topic, key, eventID string, payload interface{}) (*po.EventOutboxPo, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &po.EventOutboxPo{
+		EventID: eventID,
+		Topic:   topic,
+		MsgKey:  key,
+		Payload: string(data),
+	}, nil
+}
+
+// OutboxRelay 按写入顺序把发件箱事件投递到 Kafka，并清理旧记录。
+// 多副本部署时只有持有 Redis 租约的副本投递，避免同一批事件被重复读取、同一 key 的事件乱序；
+// 未配置 Redis 时视为单副本部署，直接投递。投递为至少一次语义，消费方按 event_id 去重
+type OutboxRelay struct {
+	outboxRepo      repo.EventOutboxRepository
+	leader          *cache.LeaderLock
+	batchSize       int
+	maxAttempts     int
+	retention       time.Duration
+	failedRetention time.Duration
+}
+
+func NewOutboxRelay() *OutboxRelay {
+	r := &OutboxRelay{
+		outboxRepo:      persistence.NewEventOutboxRepository(),
+		batchSize:       100,
+		maxAttempts:     10,
+		retention:       72 * time.Hour,
+		failedRetention: 7 * 24 * time.Hour,
+	}
+	if cli := resource.DefaultRedisResource().Client(); cli != nil {
+		r.leader = cache.NewLeaderLock(cli, "outbox_relay", outboxLeaseTTL)
+	}
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		r.batchSize = cfg.Outbox.BatchSize
+		r.maxAttempts = cfg.Outbox.MaxAttempts
+		r.retention = cfg.Outbox.Retention
+		r.failedRetention = cfg.Outbox.FailedRetention
+	}
+	return r
+}
+
+// lead 取得或续期中继租约；Redis 出错时不投递，宁可延迟也不与其他副本并发
+func (r *OutboxRelay) lead(ctx context.Context) bool {
+	if r.leader == nil {
+		return true
+	}
+	ok, err := r.leader.Acquire(ctx)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("outbox relay acquire lease failed err=%v", err)
+	}
+	return ok && err == nil
+}
+
+// Resign 停止时主动释放租约，其他副本无需等待过期即可接手
+func (r *OutboxRelay) Resign(ctx context.Context) {
+	if r.leader == nil {
+		return
+	}
+	if err := r.leader.Release(ctx); err != nil {
+		logger.WithContext(ctx).Warnf("outbox relay release lease failed err=%v", err)
+	}
+}
+
+// outboxRetryDelay 第 attempts 次失败后的重试间隔，按 2 的幂递增
+func outboxRetryDelay(attempts int) time.Duration {
+	return min(time.Second<<min(attempts, 6), outboxMaxRetryDelay)
+}
+
+// RelayPending 投递一批待发布事件，返回成功条数与本轮遇到的第一个错误。
+// 某条失败或仍在重试间隔内时只跳过同一 topic、同一 key 的后续事件，保证它们不越过失败的前序事件，
+// 其他 key 照常投递；超过最大重试次数的事件标记为 failed，同 key 的后续事件随之放行
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	if !r.lead(ctx) {
+		return 0, nil
+	}
+	renewedAt := time.Now()
+	events, err := r.outboxRepo.ListPending(ctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	var firstErr error
+	blocked := make(map[string]bool)
+	for _, ev := range events {
+		if time.Since(renewedAt) > outboxLeaseTTL/3 {
+			if !r.lead(ctx) {
+				break
+			}
+			renewedAt = time.Now()
+		}
+		orderKey := ev.Topic + "\x00" + ev.MsgKey
+		if ev.MsgKey != "" && blocked[orderKey] {
+			continue
+		}
+		if ev.Attempts > 0 && time.Since(ev.UpdatedAt) < outboxRetryDelay(ev.Attempts) {
+			blocked[orderKey] = true
+			continue
+		}
+		if err := kafkainfra.Publish(ctx, ev.Topic, ev.MsgKey, []byte(ev.Payload)); err != nil {
+			attempts := ev.Attempts + 1
+			status := vo.OutboxStatusPending
+			if attempts >= r.maxAttempts {
+				status = vo.OutboxStatusFailed
+				logger.WithContext(ctx).Errorf("outbox event give up id=%d event=%s topic=%s key=%s attempts=%d err=%v", ev.Id, ev.EventID, ev.Topic, ev.MsgKey, attempts, err)
+			} else {
+				blocked[orderKey] = true
+			}
+			if markErr := r.outboxRepo.MarkAttempt(ctx, ev.Id, attempts, status, truncate(err.Error(), 255)); markErr != nil {
+				logger.WithContext(ctx).Warnf("outbox mark attempt failed id=%d err=%v", ev.Id, markErr)
+				blocked[orderKey] = true
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if err := r.outboxRepo.MarkSent(ctx, ev.Id, time.Now()); err != nil {
+			// 下一轮会重复投递，由消费方去重；同 key 的后续事件留到下一轮，保持顺序
+			blocked[orderKey] = true
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		sent++
+	}
+	return sent, firstErr
+}
+
+// Cleanup 删除超过保留期的已发布事件与 failed 事件，返回删除条数；
+// 仍在保留期内的 failed 事件每次清理时告警，需人工排查后重放或等待过期
+func (r *OutboxRelay) Cleanup(ctx context.Context) (int64, error) {
+	if !r.lead(ctx) {
+		return 0, nil
+	}
+	total, err := r.deleteBatches(ctx, time.Now().Add(-r.retention), r.outboxRepo.DeleteSentBefore)
+	if err != nil {
+		return total, err
+	}
+	n, err := r.deleteBatches(ctx, time.Now().Add(-r.failedRetention), r.outboxRepo.DeleteFailedBefore)
+	total += n
+	if err != nil {
+		return total, err
+	}
+	if n > 0 {
+		logger.WithContext(ctx).Warnf("outbox dropped failed events older than %s count=%d", r.failedRetention, n)
+	}
+	failed, err := r.outboxRepo.CountFailed(ctx)
+	if err != nil {
+		return total, err
+	}
+	if failed > 0 {
+		logger.WithContext(ctx).Errorf("outbox has failed events count=%d, check error_msg in event_outbox", failed)
+	}
+	return total, nil
+}
+
+func (r *OutboxRelay) deleteBatches(ctx context.Context, before time.Time, del func(context.Context, time.Time, int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		n, err := del(ctx, before, outboxCleanupBatch)
+		total += n
+		if err != nil || n < outboxCleanupBatch {
+			return total, err
+		}
+	}
+}
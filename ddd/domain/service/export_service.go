@@ -0,0 +1,372 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"user-service/ddd/application/dto"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/pkg/blobstore"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+)
+
+const (
+	exportBatchSize    = 5
+	exportPageSize     = 100
+	exportMaxRelations = 100000
+	exportMaxEvents    = 1000
+	exportStaleAfter   = 10 * time.Minute
+)
+
+// ExportService 负责生成个人数据导出包：收集资料、关注/粉丝等数据，打包为 zip（JSON + CSV）写入对象存储。
+type ExportService struct {
+	userRepo    repo.UserRepository
+	followRepo  repo.FollowRepository
+	exportRepo  repo.UserExportRepository
+	eventRepo   repo.SecurityEventRepository
+	privacy     *PrivacyService
+	prefs       *PreferenceService
+	retention   time.Duration
+	maxAttempts int
+}
+
+func NewExportService() *ExportService {
+	s := &ExportService{
+		userRepo:    persistence.NewUserRepository(),
+		followRepo:  persistence.NewFollowRepository(),
+		exportRepo:  persistence.NewUserExportRepository(),
+		eventRepo:   persistence.NewSecurityEventRepository(),
+		privacy:     NewPrivacyService(),
+		prefs:       NewPreferenceService(),
+		retention:   72 * time.Hour,
+		maxAttempts: 3,
+	}
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		s.retention = cfg.Export.Retention
+		s.maxAttempts = cfg.Export.MaxAttempts
+	}
+	return s
+}
+
+// ExportObjectKey 返回导出包在对象存储中的 key
+func ExportObjectKey(exportUUID string) string {
+	return fmt.Sprintf("exports/%s.zip", exportUUID)
+}
+
+// RunPending 抢占并处理一批待导出任务，返回处理数量
+func (s *ExportService) RunPending(ctx context.Context) (int, error) {
+	claim := jobClaim{
+		store:      s.exportRepo,
+		name:       "export",
+		pending:    vo.ExportStatusPending,
+		running:    vo.ExportStatusRunning,
+		staleAfter: exportStaleAfter,
+	}
+	return runClaimed(ctx, claim,
+		func() ([]*po.UserExportPo, error) { return s.exportRepo.ListPending(ctx, exportBatchSize) },
+		func(export *po.UserExportPo) (uint64, *int) { return export.Id, &export.Attempts },
+		func(export *po.UserExportPo) { s.process(ctx, export) })
+}
+
+// CleanupExpired 删除超过保留期的导出包
+func (s *ExportService) CleanupExpired(ctx context.Context) error {
+	list, err := s.exportRepo.ListExpired(ctx, time.Now(), exportBatchSize*10)
+	if err != nil {
+		return err
+	}
+	store := blobstore.DefaultStore()
+	for _, export := range list {
+		if store != nil && export.ObjectKey != "" {
+			if err := store.Delete(ctx, export.ObjectKey); err != nil {
+				logger.WithContext(ctx).Warnf("delete expired export blob failed export=%s err=%v", export.ExportUUID, err)
+				continue
+			}
+		}
+		_, _ = s.exportRepo.TransitStatus(ctx, export.Id, vo.ExportStatusDone, vo.ExportStatusExpired, nil)
+	}
+	return nil
+}
+
+func (s *ExportService) process(ctx context.Context, export *po.UserExportPo) {
+	size, err := s.buildAndStore(ctx, export)
+	if err != nil {
+		next := vo.ExportStatusPending
+		if export.Attempts >= s.maxAttempts {
+			next = vo.ExportStatusFailed
+		}
+		logger.WithContext(ctx).Warnf("export failed export=%s user=%s attempts=%d err=%v", export.ExportUUID, export.UserUUID, export.Attempts, err)
+		_, _ = s.exportRepo.TransitStatus(ctx, export.Id, vo.ExportStatusRunning, next, map[string]interface{}{"error_msg": truncate(err.Error(), 255)})
+		return
+	}
+	now := time.Now()
+	expiresAt := now.Add(s.retention)
+	_, err = s.exportRepo.TransitStatus(ctx, export.Id, vo.ExportStatusRunning, vo.ExportStatusDone, map[string]interface{}{
+		"object_key":  ExportObjectKey(export.ExportUUID),
+		"file_size":   size,
+		"error_msg":   "",
+		"finished_at": now,
+		"expires_at":  expiresAt,
+	})
+	if err != nil {
+		logger.WithContext(ctx).Errorf("mark export done failed export=%s err=%v", export.ExportUUID, err)
+		return
+	}
+	logger.WithContext(ctx).Infof("export done export=%s user=%s size=%d", export.ExportUUID, export.UserUUID, size)
+}
+
+func (s *ExportService) buildAndStore(ctx context.Context, export *po.UserExportPo) (int64, error) {
+	store := blobstore.DefaultStore()
+	if store == nil {
+		return 0, fmt.Errorf("blob store not initialized")
+	}
+	archive, err := s.collect(ctx, export.UserUUID)
+	if err != nil {
+		return 0, err
+	}
+	buf, err := writeArchive(archive)
+	if err != nil {
+		return 0, err
+	}
+	return store.Put(ctx, ExportObjectKey(export.ExportUUID), buf)
+}
+
+// collect 收集用户的全部可导出数据
+func (s *ExportService) collect(ctx context.Context, userUUID string) (*dto.UserDataArchiveDto, error) {
+	user, err := s.userRepo.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	followers, followerTotal, err := s.collectRelations(ctx, userUUID, s.followRepo.ListFollowers, func(p *po.FollowPo) string { return p.UserUUID })
+	if err != nil {
+		return nil, err
+	}
+	followings, followingTotal, err := s.collectRelations(ctx, userUUID, s.followRepo.ListFollowings, func(p *po.FollowPo) string { return p.TargetUUID })
+	if err != nil {
+		return nil, err
+	}
+	events, eventTotal, err := s.eventRepo.ListEvents(ctx, repo.SecurityEventQuery{UserUUID: userUUID, Limit: exportMaxEvents})
+	if err != nil {
+		return nil, err
+	}
+	securityEvents := make([]dto.SecurityEventDto, 0, len(events))
+	for _, ev := range events {
+		securityEvents = append(securityEvents, dto.SecurityEventDto{
+			EventType: ev.EventType,
+			Result:    ev.Result,
+			IP:        ev.IP,
+			UserAgent: ev.UserAgent,
+			CreatedAt: ev.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	settings, err := s.collectSettings(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	sections := []dto.UserDataSectionDto{
+		exportSection("followers", len(followers), followerTotal),
+		exportSection("followings", len(followings), followingTotal),
+		exportSection("security_events", len(securityEvents), eventTotal),
+	}
+	complete := true
+	for _, sec := range sections {
+		if sec.Truncated {
+			complete = false
+			logger.WithContext(ctx).Warnf("export truncated user=%s section=%s exported=%d total=%d", userUUID, sec.Name, sec.Exported, sec.Total)
+		}
+	}
+	return &dto.UserDataArchiveDto{
+		ExportedAt: time.Now().Format(time.RFC3339),
+		Complete:   complete,
+		Sections:   sections,
+		Profile: dto.UserDataProfileDto{
+			UserUUID:    user.UserUUID,
+			Account:     user.Account,
+			Handle:      user.GetHandle(),
+			Nickname:    user.Nickname,
+			AvatarUrl:   PublicAvatarURL(user),
+			Description: user.Description,
+			CoverUrl:    user.CoverUrl,
+			CreatedAt:   user.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:   user.UpdatedAt.Format(time.RFC3339),
+		},
+		Followers:      followers,
+		Followings:     followings,
+		SecurityEvents: securityEvents,
+		Settings:       *settings,
+	}, nil
+}
+
+// collectSettings 收集隐私设置与偏好设置
+func (s *ExportService) collectSettings(ctx context.Context, userUUID string) (*dto.UserDataSettingsDto, error) {
+	privacy, err := s.privacy.Get(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := s.prefs.Get(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.UserDataSettingsDto{
+		Privacy: dto.PrivacySettingsDto{
+			PrivateAccount:    privacy.PrivateAccount,
+			HideFollowerList:  privacy.HideFollowerList,
+			HideFollowingList: privacy.HideFollowingList,
+			HideCounts:        privacy.HideCounts,
+			ProfileVisibility: privacy.ProfileVisibility,
+		},
+		Preferences: prefs,
+	}, nil
+}
+
+// exportSection 记录某部分导出的条数与实际总数，超出导出上限时标记为不完整
+func exportSection(name string, exported int, total int64) dto.UserDataSectionDto {
+	return dto.UserDataSectionDto{Name: name, Exported: exported, Total: max(total, int64(exported)), Truncated: total > int64(exported)}
+}
+
+type listFollowFunc func(ctx context.Context, userUUID string, cursor string, limit int) ([]*po.FollowPo, int64, error)
+
+// collectRelations 按游标遍历关注/粉丝列表，最多 exportMaxRelations 条，同时返回列表的实际总数
+func (s *ExportService) collectRelations(ctx context.Context, userUUID string, list listFollowFunc, peer func(p *po.FollowPo) string) ([]dto.UserDataFollowDto, int64, error) {
+	res := make([]dto.UserDataFollowDto, 0)
+	cursor := ""
+	var total int64
+	for len(res) < exportMaxRelations {
+		page, n, err := list(ctx, userUUID, cursor, min(exportPageSize, exportMaxRelations-len(res)))
+		if err != nil {
+			return nil, 0, err
+		}
+		total = n
+		if len(page) == 0 {
+			break
+		}
+		for _, p := range page {
+			res = append(res, dto.UserDataFollowDto{UserUUID: peer(p), FollowedAt: p.CreatedAt.Format(time.RFC3339)})
+		}
+		last := page[len(page)-1]
+		cursor = fmt.Sprintf("%d:%s", last.CreatedAt.UnixMilli(), peer(last))
+	}
+	if len(res) < exportMaxRelations {
+		// 列表已遍历完，缓存计数的偏差不应让导出被标记为不完整
+		total = int64(len(res))
+	}
+	return res, total, nil
+}
+
+// writeArchive 生成 zip：user_data.json + 各部分 CSV
+func writeArchive(archive *dto.UserDataArchiveDto) (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+
+	jw, err := zw.Create("user_data.json")
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(jw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(archive); err != nil {
+		return nil, err
+	}
+
+	p := archive.Profile
+	if err := writeCSV(zw, "profile.csv", [][]string{
+		{"field", "value"},
+		{"user_uuid", p.UserUUID},
+		{"account", p.Account},
+		{"handle", p.Handle},
+		{"nickname", p.Nickname},
+		{"avatar_url", p.AvatarUrl},
+		{"description", p.Description},
+		{"cover_url", p.CoverUrl},
+		{"created_at", p.CreatedAt},
+		{"updated_at", p.UpdatedAt},
+	}); err != nil {
+		return nil, err
+	}
+	if err := writeCSV(zw, "followers.csv", followRows(archive.Followers)); err != nil {
+		return nil, err
+	}
+	if err := writeCSV(zw, "followings.csv", followRows(archive.Followings)); err != nil {
+		return nil, err
+	}
+	eventRows := [][]string{{"event_type", "result", "ip", "user_agent", "created_at"}}
+	for _, ev := range archive.SecurityEvents {
+		eventRows = append(eventRows, []string{ev.EventType, ev.Result, ev.IP, ev.UserAgent, ev.CreatedAt})
+	}
+	if err := writeCSV(zw, "security_events.csv", eventRows); err != nil {
+		return nil, err
+	}
+	if err := writeCSV(zw, "settings.csv", settingRows(archive.Settings)); err != nil {
+		return nil, err
+	}
+	manifestRows := [][]string{{"section", "exported", "total", "truncated"}}
+	for _, sec := range archive.Sections {
+		manifestRows = append(manifestRows, []string{sec.Name, fmt.Sprint(sec.Exported), fmt.Sprint(sec.Total), fmt.Sprint(sec.Truncated)})
+	}
+	if err := writeCSV(zw, "manifest.csv", manifestRows); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func writeCSV(zw *zip.Writer, name string, rows [][]string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func followRows(list []dto.UserDataFollowDto) [][]string {
+	rows := make([][]string, 0, len(list)+1)
+	rows = append(rows, []string{"user_uuid", "followed_at"})
+	for _, v := range list {
+		rows = append(rows, []string{v.UserUUID, v.FollowedAt})
+	}
+	return rows
+}
+
+// settingRows 隐私设置以 privacy. 为前缀、偏好项以 preferences. 为前缀，按 key 排序
+func settingRows(settings dto.UserDataSettingsDto) [][]string {
+	p := settings.Privacy
+	rows := [][]string{
+		{"key", "value"},
+		{"privacy.private_account", fmt.Sprint(p.PrivateAccount)},
+		{"privacy.hide_follower_list", fmt.Sprint(p.HideFollowerList)},
+		{"privacy.hide_following_list", fmt.Sprint(p.HideFollowingList)},
+		{"privacy.hide_counts", fmt.Sprint(p.HideCounts)},
+		{"privacy.profile_visibility", p.ProfileVisibility},
+	}
+	keys := make([]string, 0, len(settings.Preferences))
+	for k := range settings.Preferences {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		rows = append(rows, []string{"preferences." + k, fmt.Sprint(settings.Preferences[k])})
+	}
+	return rows
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
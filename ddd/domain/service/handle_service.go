@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	kafkainfra "user-service/ddd/infrastructure/kafka"
+	"user-service/pkg/config"
+	"user-service/pkg/errno"
+	"user-service/pkg/logger"
+)
+
+// handlePattern 用户名：3-30 位，字母开头，仅含字母、数字和下划线
+var handlePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{2,29}$`)
+
+// defaultReservedHandles 内置保留词，与路由、系统账号及常见品牌词冲突的用户名不可注册
+var defaultReservedHandles = []string{
+	"admin", "administrator", "root", "system", "support", "help", "official",
+	"api", "www", "user", "users", "me", "settings", "login", "logout", "register",
+	"signup", "signin", "search", "explore", "home", "about", "terms", "privacy",
+	"security", "notification", "notifications", "video", "videos", "null", "undefined",
+}
+
+// HandleService 公开用户名（@handle）管理：格式与保留字校验、修改冷却、旧用户名保留跳转
+type HandleService struct {
+	userRepo       repo.UserRepository
+	handleRepo     repo.UserHandleRepository
+	moderator      *ContentModerator
+	events         *ProfileEventEmitter
+	reserved       map[string]struct{}
+	changeCooldown time.Duration
+	redirectPeriod time.Duration
+}
+
+func NewHandleService() *HandleService {
+	s := &HandleService{
+		userRepo:       persistence.NewUserRepository(),
+		handleRepo:     persistence.NewUserHandleRepository(),
+		moderator:      NewContentModerator(),
+		events:         NewProfileEventEmitter(),
+		reserved:       make(map[string]struct{}, len(defaultReservedHandles)),
+		changeCooldown: 30 * 24 * time.Hour,
+		redirectPeriod: 90 * 24 * time.Hour,
+	}
+	for _, w := range defaultReservedHandles {
+		s.reserved[w] = struct{}{}
+	}
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		s.changeCooldown = cfg.Handle.ChangeCooldown
+		s.redirectPeriod = cfg.Handle.RedirectPeriod
+		for _, w := range cfg.Handle.Reserved {
+			s.reserved[strings.ToLower(strings.TrimSpace(w))] = struct{}{}
+		}
+	}
+	return s
+}
+
+// NormalizeHandle 去掉首尾空白和前导 @
+func NormalizeHandle(raw string) string {
+	return strings.TrimPrefix(strings.TrimSpace(raw), "@")
+}
+
+// Validate 校验用户名格式与保留字
+func (s *HandleService) Validate(handle string) error {
+	if !handlePattern.MatchString(handle) {
+		return errno.ErrHandleInvalid
+	}
+	if _, ok := s.reserved[strings.ToLower(handle)]; ok {
+		return errno.ErrHandleReserved
+	}
+	return nil
+}
+
+// Change 修改用户名。仅大小写变化时不受冷却限制，也不产生历史记录；
+// ifMatch 非空时要求与当前资料版本一致
+func (s *HandleService) Change(ctx context.Context, userUUID, raw string, ifMatch *uint64) (*po.UserPo, error) {
+	handle := NormalizeHandle(raw)
+	if err := s.Validate(handle); err != nil {
+		return nil, err
+	}
+	if err := s.moderator.CheckIdentifier("handle", handle); err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if ifMatch != nil && *ifMatch != user.Version {
+		return nil, errno.ErrUserVersionConflict
+	}
+	current := user.GetHandle()
+	if current == handle {
+		return user, nil
+	}
+	now := time.Now()
+	caseOnly := strings.EqualFold(current, handle)
+	if !caseOnly {
+		if user.HandleChangedAt != nil && now.Sub(*user.HandleChangedAt) < s.changeCooldown {
+			return nil, errno.ErrHandleChangeTooSoon
+		}
+		if err := s.ensureAvailable(ctx, userUUID, handle, now); err != nil {
+			return nil, err
+		}
+	}
+	oldHandle := current
+	if caseOnly {
+		oldHandle = ""
+	}
+	next := *user
+	next.Handle = &handle
+	events := s.events.Events(ctx, kafkainfra.ProfileChangeSourceHandle, SnapshotProfile(user), &next)
+	if err := s.handleRepo.ChangeHandle(ctx, userUUID, user.Version, oldHandle, handle, now, now.Add(s.redirectPeriod), events...); err != nil {
+		return nil, err
+	}
+	logger.WithContext(ctx).Infof("handle changed user=%s from=%q to=%q", userUUID, current, handle)
+	user.Handle = &handle
+	user.Version++
+	if !caseOnly {
+		user.HandleChangedAt = &now
+	}
+	return user, nil
+}
+
+// Resolve 按用户名查找用户；命中保留期内的旧用户名时返回当前用户并标记 redirected
+func (s *HandleService) Resolve(ctx context.Context, raw string) (*po.UserPo, bool, error) {
+	handle := NormalizeHandle(raw)
+	if !handlePattern.MatchString(handle) {
+		return nil, false, errno.ErrUserNotFound
+	}
+	user, err := s.handleRepo.GetUserByHandle(ctx, handle)
+	if err != nil {
+		return nil, false, err
+	}
+	if user != nil {
+		return user, false, nil
+	}
+	history, err := s.handleRepo.GetActiveHistory(ctx, handle, time.Now())
+	if err != nil {
+		return nil, false, err
+	}
+	if history == nil {
+		return nil, false, errno.ErrUserNotFound
+	}
+	user, err = s.userRepo.GetUserByUUID(ctx, history.UserUUID)
+	if err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
+
+// ensureAvailable 用户名未被他人使用，且不在他人的跳转保留期内
+func (s *HandleService) ensureAvailable(ctx context.Context, userUUID, handle string, now time.Time) error {
+	owner, err := s.handleRepo.GetUserByHandle(ctx, handle)
+	if err != nil {
+		return err
+	}
+	if owner != nil && owner.UserUUID != userUUID {
+		return errno.ErrHandleTaken
+	}
+	history, err := s.handleRepo.GetActiveHistory(ctx, handle, now)
+	if err != nil {
+		return err
+	}
+	if history != nil && history.UserUUID != userUUID {
+		return errno.ErrHandleTaken
+	}
+	return nil
+}
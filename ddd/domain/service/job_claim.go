@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-service/pkg/logger"
+)
+
+// jobStore 后台任务表的抢占操作，导出任务与头像处理任务的仓储均满足
+type jobStore interface {
+	TransitStatus(ctx context.Context, id uint64, from, to string, fields map[string]interface{}) (bool, error)
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+}
+
+// jobClaim 一类后台任务的抢占参数
+type jobClaim struct {
+	store      jobStore
+	name       string // 日志中的任务类型
+	pending    string
+	running    string
+	staleAfter time.Duration // running 超过该时长视为实例崩溃，放回 pending
+}
+
+// runClaimed 先回收超时的 running 任务，再对 list 返回的任务逐个条件更新 pending -> running（attempts+1），
+// 抢占成功的交给 handle，已被其他实例抢占的跳过；返回处理数量
+func runClaimed[T any](ctx context.Context, c jobClaim, list func() ([]T, error), job func(T) (id uint64, attempts *int), handle func(T)) (int, error) {
+	if n, err := c.store.RequeueStale(ctx, time.Now().Add(-c.staleAfter)); err == nil && n > 0 {
+		logger.WithContext(ctx).Warnf("requeued %d stale %s jobs", n, c.name)
+	}
+	items, err := list()
+	if err != nil {
+		return 0, err
+	}
+	processed := 0
+	for _, item := range items {
+		id, attempts := job(item)
+		ok, err := c.store.TransitStatus(ctx, id, c.pending, c.running, map[string]interface{}{"attempts": *attempts + 1})
+		if err != nil || !ok {
+			continue
+		}
+		*attempts++
+		handle(item)
+		processed++
+	}
+	return processed, nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	grpcinfra "user-service/ddd/infrastructure/grpc"
+	"user-service/pkg/config"
+	"user-service/pkg/georegion"
+	"user-service/pkg/logger"
+	"user-service/pkg/reqmeta"
+
+	"github.com/google/uuid"
+	notificationpb "github.com/jiangqiao2/go-video-proto/proto/notification/notification"
+)
+
+const (
+	securityAlertNotificationType = "security_alert"
+	securityAlertTimeout          = 3 * time.Second
+)
+
+// deviceIDPattern 客户端上报的设备标识只接受字母数字、下划线和短横线，过长或非法时重新下发
+var deviceIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{8,64}$`)
+
+// LoginRiskService 登录风险检测：识别新设备登录与短时间内的异地登录，记录安全事件并通知用户。
+// 检测失败只记日志，不影响登录结果。
+type LoginRiskService struct {
+	deviceRepo   repo.UserDeviceRepository
+	audit        *SecurityEventRecorder
+	enabled      bool
+	travelWindow time.Duration
+}
+
+func NewLoginRiskService() *LoginRiskService {
+	s := &LoginRiskService{
+		deviceRepo:   persistence.NewUserDeviceRepository(),
+		audit:        DefaultSecurityEventRecorder(),
+		enabled:      true,
+		travelWindow: 2 * time.Hour,
+	}
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		s.enabled = cfg.LoginRisk.Enabled
+		s.travelWindow = cfg.LoginRisk.TravelWindow
+	}
+	return s
+}
+
+// Assess 评估一次成功登录，返回本次登录使用的设备标识（客户端未携带时新生成）
+func (s *LoginRiskService) Assess(ctx context.Context, userUUID string) string {
+	client := reqmeta.ClientInfoFromContext(ctx)
+	deviceID := client.DeviceID
+	if !deviceIDPattern.MatchString(deviceID) {
+		deviceID = uuid.New().String()
+	}
+	if !s.enabled {
+		return deviceID
+	}
+
+	known, err := s.deviceRepo.GetDevice(ctx, userUUID, deviceID)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("query login device failed user=%s err=%v", userUUID, err)
+		return deviceID
+	}
+	latest, err := s.deviceRepo.GetLatestDevice(ctx, userUUID)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("query latest login device failed user=%s err=%v", userUUID, err)
+		return deviceID
+	}
+
+	now := time.Now()
+	region, _ := georegion.DefaultResolver().Lookup(client.IP)
+	// 首次登录的用户没有历史设备，不视为新设备
+	newDevice := known == nil && latest != nil
+	suspicious := latest != nil && region != "" && latest.LastRegion != "" &&
+		latest.LastRegion != region && now.Sub(latest.LastSeenAt) < s.travelWindow
+
+	if err := s.deviceRepo.SaveDevice(ctx, &po.UserDevicePo{
+		UserUUID:   userUUID,
+		DeviceID:   deviceID,
+		UserAgent:  truncate(client.UserAgent, securityEventUserAgentMax),
+		LastIP:     client.IP,
+		LastRegion: region,
+		LastSeenAt: now,
+	}); err != nil {
+		logger.WithContext(ctx).Warnf("save login device failed user=%s err=%v", userUUID, err)
+	}
+
+	if newDevice {
+		s.audit.Record(ctx, userUUID, vo.SecurityEventNewDevice, vo.SecurityResultSuccess, map[string]string{"device_id": deviceID, "region": region})
+		s.alert(ctx, userUUID, "新设备登录提醒",
+			fmt.Sprintf("您的账号于 %s 在新设备上登录（IP %s），如非本人操作请尽快修改密码。", now.Format("2006-01-02 15:04:05"), client.IP),
+			map[string]string{"event": vo.SecurityEventNewDevice, "ip": client.IP, "region": region})
+	}
+	if suspicious {
+		s.audit.Record(ctx, userUUID, vo.SecurityEventSuspicious, vo.SecurityResultSuccess, map[string]string{"from_region": latest.LastRegion, "region": region})
+		s.alert(ctx, userUUID, "异地登录提醒",
+			fmt.Sprintf("您的账号在 %s 内从 %s 切换到 %s 登录，如非本人操作请尽快修改密码。", s.travelWindow, latest.LastRegion, region),
+			map[string]string{"event": vo.SecurityEventSuspicious, "ip": client.IP, "from_region": latest.LastRegion, "region": region})
+	}
+	return deviceID
+}
+
+// alert 异步发送安全提醒通知
+func (s *LoginRiskService) alert(ctx context.Context, userUUID, title, content string, extra map[string]string) {
+	client := grpcinfra.DefaultNotificationServiceClient()
+	if client == nil {
+		return
+	}
+	extraJson := "{}"
+	if b, err := json.Marshal(extra); err == nil {
+		extraJson = string(b)
+	}
+	log := logger.WithContext(ctx)
+	go func() {
+		nctx, cancel := context.WithTimeout(context.Background(), securityAlertTimeout)
+		defer cancel()
+		_, err := client.CreateNotification(nctx, &notificationpb.CreateNotificationRequest{
+			UserUuid:  userUUID,
+			Type:      securityAlertNotificationType,
+			Title:     title,
+			Content:   content,
+			ExtraJson: extraJson,
+		})
+		if err != nil {
+			log.Warnf("send security alert failed user=%s err=%v", userUUID, err)
+		}
+	}()
+}
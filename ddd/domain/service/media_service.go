@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	kafkainfra "user-service/ddd/infrastructure/kafka"
+	"user-service/internal/resource"
+	"user-service/pkg/config"
+	"user-service/pkg/errno"
+	"user-service/pkg/logger"
+	"user-service/pkg/s3"
+
+	"github.com/google/uuid"
+)
+
+// UploadTicket 预签名直传凭证
+type UploadTicket struct {
+	ObjectKey string
+	UploadURL string
+	Headers   map[string]string
+	ExpiresAt time.Time
+}
+
+// MediaService 头像、封面上传：签发预签名直传 URL，上传完成后校验对象并写回资料
+type MediaService struct {
+	userRepo      repo.UserRepository
+	storage       func() *s3.Client
+	avatars       *AvatarProcessor
+	events        *ProfileEventEmitter
+	uploadTTL     time.Duration
+	avatarMaxSize int64
+	coverMaxSize  int64
+}
+
+func NewMediaService() *MediaService {
+	s := &MediaService{
+		userRepo:      persistence.NewUserRepository(),
+		storage:       resource.DefaultMinioResource().Client,
+		avatars:       NewAvatarProcessor(),
+		events:        NewProfileEventEmitter(),
+		uploadTTL:     10 * time.Minute,
+		avatarMaxSize: 5 << 20,
+		coverMaxSize:  10 << 20,
+	}
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		s.uploadTTL = cfg.Profile.UploadURLTTL
+		s.avatarMaxSize = cfg.Profile.AvatarMaxSize
+		s.coverMaxSize = cfg.Profile.CoverMaxSize
+	}
+	return s
+}
+
+// MediaKeyPrefix 用户某类媒体的对象 key 前缀，如 image/avatar/<user_uuid>/
+func MediaKeyPrefix(kind, userUUID string) string {
+	return fmt.Sprintf("image/%s/%s/", kind, userUUID)
+}
+
+// PrepareUpload 校验声明的类型与大小，签发直传 URL
+func (s *MediaService) PrepareUpload(ctx context.Context, userUUID, kind, contentType string, size int64) (*UploadTicket, error) {
+	maxSize, err := s.maxSize(kind)
+	if err != nil {
+		return nil, err
+	}
+	ext, ok := vo.ImageExtensions[contentType]
+	if !ok {
+		return nil, errno.ErrMediaTypeInvalid
+	}
+	if size <= 0 || size > maxSize {
+		return nil, errno.ErrMediaTooLarge
+	}
+	client := s.storage()
+	if client == nil {
+		return nil, errno.ErrInternalServer
+	}
+	key := MediaKeyPrefix(kind, userUUID) + uuid.New().String() + "." + ext
+	return &UploadTicket{
+		ObjectKey: key,
+		UploadURL: client.PresignPut(key, contentType, s.uploadTTL),
+		Headers:   map[string]string{"Content-Type": contentType},
+		ExpiresAt: time.Now().Add(s.uploadTTL),
+	}, nil
+}
+
+// CompleteUpload 上传完成回调：校验对象归属、类型与大小，写入资料并清理旧文件
+func (s *MediaService) CompleteUpload(ctx context.Context, userUUID, kind, objectKey string) (*po.UserPo, error) {
+	maxSize, err := s.maxSize(kind)
+	if err != nil {
+		return nil, err
+	}
+	prefix := MediaKeyPrefix(kind, userUUID)
+	if !strings.HasPrefix(objectKey, prefix) || strings.Contains(objectKey, "..") {
+		return nil, errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "object_key")
+	}
+	client := s.storage()
+	if client == nil {
+		return nil, errno.ErrInternalServer
+	}
+	info, err := client.StatObject(ctx, objectKey)
+	if err != nil {
+		if errors.Is(err, s3.ErrNotFound) {
+			return nil, errno.ErrMediaNotUploaded
+		}
+		return nil, err
+	}
+	contentType, _, _ := strings.Cut(info.ContentType, ";")
+	if _, ok := vo.ImageExtensions[strings.TrimSpace(contentType)]; !ok {
+		s.deleteObject(ctx, client, objectKey)
+		return nil, errno.ErrMediaTypeInvalid
+	}
+	if info.Size <= 0 || info.Size > maxSize {
+		s.deleteObject(ctx, client, objectKey)
+		return nil, errno.ErrMediaTooLarge
+	}
+
+	user, err := s.userRepo.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	before := SnapshotProfile(user)
+	var previous, previousVariants string
+	switch kind {
+	case vo.MediaKindAvatar:
+		previous, user.AvatarUrl = user.AvatarUrl, objectKey
+		previousVariants, user.AvatarVariants = user.AvatarVariants, ""
+	case vo.MediaKindCover:
+		previous, user.CoverUrl = user.CoverUrl, objectKey
+	}
+	events := s.events.Events(ctx, kafkainfra.ProfileChangeSourceProfile, before, user)
+	if err := s.userRepo.UpdateUser(ctx, user, events...); err != nil {
+		return nil, err
+	}
+	if kind == vo.MediaKindAvatar {
+		if err := s.avatars.Enqueue(ctx, userUUID, objectKey); err != nil {
+			logger.WithContext(ctx).Errorf("enqueue avatar job failed user=%s key=%s err=%v", userUUID, objectKey, err)
+		}
+	}
+	// 只清理本人目录下的旧文件，历史数据中外部 URL 不处理
+	if previous != "" && previous != objectKey && strings.HasPrefix(previous, prefix) {
+		s.deleteObject(ctx, client, previous)
+		for _, key := range AvatarVariantURLs(previous, previousVariants) {
+			s.deleteObject(ctx, client, key)
+		}
+	}
+	logger.WithContext(ctx).Infof("media uploaded user=%s kind=%s key=%s size=%d", userUUID, kind, objectKey, info.Size)
+	return user, nil
+}
+
+func (s *MediaService) maxSize(kind string) (int64, error) {
+	switch kind {
+	case vo.MediaKindAvatar:
+		return s.avatarMaxSize, nil
+	case vo.MediaKindCover:
+		return s.coverMaxSize, nil
+	}
+	return 0, errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "kind")
+}
+
+func (s *MediaService) deleteObject(ctx context.Context, client *s3.Client, key string) {
+	if err := client.DeleteObject(ctx, key); err != nil {
+		logger.WithContext(ctx).Warnf("delete media object failed key=%s err=%v", key, err)
+	}
+}
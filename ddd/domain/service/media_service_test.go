@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"user-service/ddd/domain/vo"
+	"user-service/pkg/errno"
+	"user-service/pkg/s3"
+	"user-service/pkg/s3/s3test"
+)
+
+func newTestMediaService(t *testing.T) (*MediaService, *s3test.Server) {
+	t.Helper()
+	srv := s3test.NewServer("media")
+	t.Cleanup(srv.Close)
+	client, err := s3.NewClient(s3.Config{Endpoint: srv.URL, AccessKey: "ak", SecretKey: "sk", Bucket: "media"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &MediaService{
+		storage:       func() *s3.Client { return client },
+		avatarMaxSize: 16,
+		coverMaxSize:  32,
+	}, srv
+}
+
+// 以下用例均在读取用户之前被拒绝，无需数据库
+func TestCompleteUploadRejects(t *testing.T) {
+	const user = "user-1"
+	prefix := MediaKeyPrefix(vo.MediaKindAvatar, user)
+	cases := []struct {
+		name    string
+		key     string
+		stored  []byte
+		ctype   string
+		want    *errno.Errno
+		removed bool
+	}{
+		{name: "missing object", key: prefix + "none.png", want: errno.ErrMediaNotUploaded},
+		{name: "wrong content type", key: prefix + "a.png", stored: []byte("<html>"), ctype: "text/html", want: errno.ErrMediaTypeInvalid, removed: true},
+		{name: "oversize", key: prefix + "b.png", stored: make([]byte, 17), ctype: "image/png", want: errno.ErrMediaTooLarge, removed: true},
+		{name: "empty object", key: prefix + "c.png", stored: []byte{}, ctype: "image/png", want: errno.ErrMediaTooLarge, removed: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, srv := newTestMediaService(t)
+			if tc.stored != nil {
+				srv.Put(tc.key, tc.stored, tc.ctype)
+			}
+			_, err := svc.CompleteUpload(context.Background(), user, vo.MediaKindAvatar, tc.key)
+			if err != tc.want {
+				t.Fatalf("err = %v, want %v", err, tc.want)
+			}
+			if _, ok := srv.Object(tc.key); tc.removed && ok {
+				t.Fatal("rejected object was not deleted")
+			}
+		})
+	}
+}
+
+func TestCompleteUploadRejectsForeignKey(t *testing.T) {
+	svc, srv := newTestMediaService(t)
+	for _, key := range []string{
+		MediaKeyPrefix(vo.MediaKindAvatar, "user-2") + "a.png",
+		MediaKeyPrefix(vo.MediaKindAvatar, "user-1") + "../user-2/a.png",
+		MediaKeyPrefix(vo.MediaKindCover, "user-1") + "a.png",
+	} {
+		srv.Put(key, []byte("png"), "image/png")
+		_, err := svc.CompleteUpload(context.Background(), "user-1", vo.MediaKindAvatar, key)
+		if bizErr, ok := err.(errno.BizError); !ok || bizErr.Code() != errno.ErrParameterInvalid.Code {
+			t.Fatalf("key %s: err = %v, want ErrParameterInvalid", key, err)
+		}
+		if _, ok := srv.Object(key); !ok {
+			t.Fatalf("key %s: foreign object must not be touched", key)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"unicode/utf8"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/pkg/config"
+	"user-service/pkg/errno"
+	"user-service/pkg/logger"
+)
+
+const (
+	preferenceTypeBool   = "bool"
+	preferenceTypeInt    = "int"
+	preferenceTypeString = "string"
+	preferenceTypeEnum   = "enum"
+
+	preferenceStringMaxLen = 256
+
+	reasonUnknownKey  = "unknown_key"
+	reasonInvalidType = "invalid_type"
+	reasonNotInEnum   = "not_in_enum"
+	reasonOutOfRange  = "out_of_range"
+)
+
+// PreferenceService 用户偏好设置：按配置中的 schema 校验类型与取值，读取时以默认值补齐未设置的项
+type PreferenceService struct {
+	prefRepo repo.UserPreferenceRepository
+	schema   map[string]config.PreferenceDef
+	defaults map[string]interface{}
+}
+
+func NewPreferenceService() *PreferenceService {
+	s := &PreferenceService{
+		prefRepo: persistence.NewUserPreferenceRepository(),
+		schema:   map[string]config.PreferenceDef{},
+		defaults: map[string]interface{}{},
+	}
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		s.schema = cfg.Preferences.Schema
+	}
+	for key, def := range s.schema {
+		s.defaults[key] = s.defaultValue(key, def)
+	}
+	return s
+}
+
+// defaultValue 配置中的默认值同样按 schema 校验，不合法时退化为该类型的零值
+func (s *PreferenceService) defaultValue(key string, def config.PreferenceDef) interface{} {
+	if def.Default != nil {
+		if raw, err := json.Marshal(def.Default); err == nil {
+			if v, reason := validatePreference(def, raw); reason == "" {
+				return v
+			}
+		}
+		logger.Warnf("invalid default for preference %s: %v", key, def.Default)
+	}
+	switch def.Type {
+	case preferenceTypeBool:
+		return false
+	case preferenceTypeInt:
+		return int64(0)
+	case preferenceTypeEnum:
+		if len(def.Enum) > 0 {
+			return def.Enum[0]
+		}
+	}
+	return ""
+}
+
+// Get 返回用户的全部偏好项
+func (s *PreferenceService) Get(ctx context.Context, userUUID string) (map[string]interface{}, error) {
+	result, err := s.BatchGet(ctx, []string{userUUID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result[userUUID], nil
+}
+
+// BatchGet 批量读取多个用户的偏好项，keys 为空表示全部
+func (s *PreferenceService) BatchGet(ctx context.Context, userUUIDs []string, keys []string) (map[string]map[string]interface{}, error) {
+	if len(keys) == 0 {
+		keys = make([]string, 0, len(s.schema))
+		for key := range s.schema {
+			keys = append(keys, key)
+		}
+	}
+	var errs errno.ValidationErrors
+	for _, key := range keys {
+		if _, ok := s.schema[key]; !ok {
+			errs.Add(key, reasonUnknownKey)
+		}
+	}
+	if err := errs.OrNil(); err != nil {
+		return nil, err
+	}
+	stored, err := s.prefRepo.GetPreferences(ctx, userUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]interface{}, len(userUUIDs))
+	for _, u := range userUUIDs {
+		prefs := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			prefs[key] = s.defaults[key]
+			raw, ok := stored[u][key]
+			if !ok {
+				continue
+			}
+			// schema 收紧后已保存的旧值可能不再合法，此时使用默认值
+			if v, reason := validatePreference(s.schema[key], []byte(raw)); reason == "" {
+				prefs[key] = v
+			}
+		}
+		result[u] = prefs
+	}
+	return result, nil
+}
+
+// Put 整体替换用户偏好，未出现或为 null 的项恢复默认值
+func (s *PreferenceService) Put(ctx context.Context, userUUID string, values map[string]json.RawMessage) (map[string]interface{}, error) {
+	set, _, err := s.validate(values)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.prefRepo.SavePreferences(ctx, userUUID, set, nil, true); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, userUUID)
+}
+
+// Patch 按 merge-patch 语义更新偏好：出现的项覆盖，null 恢复默认值，未出现的项保持不变
+func (s *PreferenceService) Patch(ctx context.Context, userUUID string, values map[string]json.RawMessage) (map[string]interface{}, error) {
+	set, reset, err := s.validate(values)
+	if err != nil {
+		return nil, err
+	}
+	if len(set) > 0 || len(reset) > 0 {
+		if err := s.prefRepo.SavePreferences(ctx, userUUID, set, reset, false); err != nil {
+			return nil, err
+		}
+	}
+	return s.Get(ctx, userUUID)
+}
+
+// validate 校验全部字段，返回待写入的规范化 JSON 值与需恢复默认的 key
+func (s *PreferenceService) validate(values map[string]json.RawMessage) (map[string]string, []string, error) {
+	var errs errno.ValidationErrors
+	set := make(map[string]string, len(values))
+	var reset []string
+	for key, raw := range values {
+		def, ok := s.schema[key]
+		if !ok {
+			errs.Add(key, reasonUnknownKey)
+			continue
+		}
+		if isJSONNull(raw) {
+			reset = append(reset, key)
+			continue
+		}
+		v, reason := validatePreference(def, raw)
+		if reason != "" {
+			errs.Add(key, reason)
+			continue
+		}
+		canonical, _ := json.Marshal(v)
+		set[key] = string(canonical)
+	}
+	if err := errs.OrNil(); err != nil {
+		return nil, nil, err
+	}
+	return set, reset, nil
+}
+
+// validatePreference 按类型解码并校验取值，返回解码后的值；不合法时返回原因
+func validatePreference(def config.PreferenceDef, raw []byte) (interface{}, string) {
+	switch def.Type {
+	case preferenceTypeBool:
+		var v bool
+		if json.Unmarshal(raw, &v) != nil {
+			return nil, reasonInvalidType
+		}
+		return v, ""
+	case preferenceTypeInt:
+		var f float64
+		if json.Unmarshal(raw, &f) != nil || f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+			return nil, reasonInvalidType
+		}
+		v := int64(f)
+		if (def.Min != nil && v < *def.Min) || (def.Max != nil && v > *def.Max) {
+			return nil, reasonOutOfRange
+		}
+		return v, ""
+	case preferenceTypeString, preferenceTypeEnum:
+		var v string
+		if json.Unmarshal(raw, &v) != nil {
+			return nil, reasonInvalidType
+		}
+		if def.Type == preferenceTypeEnum {
+			for _, option := range def.Enum {
+				if v == option {
+					return v, ""
+				}
+			}
+			return nil, reasonNotInEnum
+		}
+		maxLen := def.MaxLength
+		if maxLen <= 0 {
+			maxLen = preferenceStringMaxLen
+		}
+		if utf8.RuneCountInString(v) > maxLen {
+			return nil, reasonTooLong
+		}
+		return StripControlChars(v, false), ""
+	}
+	return nil, reasonInvalidType
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+
+	"user-service/ddd/application/cqe"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+// PrivacyService 用户隐私设置：读写设置，并判定访问者对主页、关注列表、计数的可见性
+type PrivacyService struct {
+	privacyRepo repo.UserPrivacyRepository
+	followRepo  repo.FollowRepository
+}
+
+func NewPrivacyService() *PrivacyService {
+	return &PrivacyService{
+		privacyRepo: persistence.NewUserPrivacyRepository(),
+		followRepo:  persistence.NewFollowRepository(),
+	}
+}
+
+// Get 返回用户隐私设置，未设置过时返回默认值（全部公开）
+func (s *PrivacyService) Get(ctx context.Context, userUUID string) (*po.UserPrivacyPo, error) {
+	setting, err := s.privacyRepo.GetPrivacy(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	return withPrivacyDefaults(userUUID, setting), nil
+}
+
+func withPrivacyDefaults(userUUID string, setting *po.UserPrivacyPo) *po.UserPrivacyPo {
+	if setting == nil {
+		setting = &po.UserPrivacyPo{UserUUID: userUUID, ProfileVisibility: vo.ProfileVisibilityPublic}
+	}
+	if !vo.CheckProfileVisibility(setting.ProfileVisibility) {
+		setting.ProfileVisibility = vo.ProfileVisibilityPublic
+	}
+	return setting
+}
+
+// Update 按请求中出现的字段更新隐私设置
+func (s *PrivacyService) Update(ctx context.Context, userUUID string, req *cqe.PrivacySettingsReq) (*po.UserPrivacyPo, error) {
+	setting, err := s.Get(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if req.PrivateAccount != nil {
+		setting.PrivateAccount = *req.PrivateAccount
+	}
+	if req.HideFollowerList != nil {
+		setting.HideFollowerList = *req.HideFollowerList
+	}
+	if req.HideFollowingList != nil {
+		setting.HideFollowingList = *req.HideFollowingList
+	}
+	if req.HideCounts != nil {
+		setting.HideCounts = *req.HideCounts
+	}
+	if req.ProfileVisibility != nil {
+		setting.ProfileVisibility = *req.ProfileVisibility
+	}
+	if err := s.privacyRepo.SavePrivacy(ctx, setting); err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+// Access 计算 viewerUUID 访问 ownerUUID 时的可见性，viewerUUID 为空表示未登录访客
+func (s *PrivacyService) Access(ctx context.Context, ownerUUID, viewerUUID string) (*PrivacyAccess, error) {
+	setting, err := s.Get(ctx, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	access := &PrivacyAccess{Setting: setting, self: viewerUUID != "" && viewerUUID == ownerUUID}
+	// 仅在设置依赖粉丝关系时才查询关注状态
+	needFollow := setting.PrivateAccount || setting.ProfileVisibility == vo.ProfileVisibilityFollowers
+	if !access.self && viewerUUID != "" && needFollow {
+		access.follower, err = s.followRepo.IsFollowing(ctx, viewerUUID, ownerUUID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return access, nil
+}
+
+// AccessBatch 批量计算 viewerUUID 对 ownerUUIDs 的可见性：设置一次读取，需要粉丝关系的账号一次 IN 查询
+func (s *PrivacyService) AccessBatch(ctx context.Context, ownerUUIDs []string, viewerUUID string) (map[string]*PrivacyAccess, error) {
+	settings, err := s.privacyRepo.GetPrivacyBatch(ctx, ownerUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*PrivacyAccess, len(ownerUUIDs))
+	needFollow := make([]string, 0)
+	for _, owner := range ownerUUIDs {
+		setting := withPrivacyDefaults(owner, settings[owner])
+		access := &PrivacyAccess{Setting: setting, self: viewerUUID != "" && viewerUUID == owner}
+		if !access.self && viewerUUID != "" && (setting.PrivateAccount || setting.ProfileVisibility == vo.ProfileVisibilityFollowers) {
+			needFollow = append(needFollow, owner)
+		}
+		res[owner] = access
+	}
+	if len(needFollow) > 0 {
+		followed, err := s.followRepo.FollowedAmong(ctx, viewerUUID, needFollow)
+		if err != nil {
+			return nil, err
+		}
+		for _, owner := range needFollow {
+			res[owner].follower = followed[owner]
+		}
+	}
+	return res, nil
+}
+
+// PrivacyAccess 某个访问者对某个用户的可见性判定结果
+type PrivacyAccess struct {
+	Setting  *po.UserPrivacyPo
+	self     bool
+	follower bool
+}
+
+// CanViewProfile 主页完整资料（简介、封面等）
+func (a *PrivacyAccess) CanViewProfile() bool {
+	switch {
+	case a.self:
+		return true
+	case a.Setting.ProfileVisibility == vo.ProfileVisibilityFollowers:
+		return a.follower
+	case a.Setting.ProfileVisibility == vo.ProfileVisibilityPrivate:
+		return false
+	}
+	return true
+}
+
+// CanViewFollowers 粉丝列表：隐藏后仅本人可见；私密账号仅粉丝可见
+func (a *PrivacyAccess) CanViewFollowers() bool {
+	return a.self || (!a.Setting.HideFollowerList && a.canViewRelations())
+}
+
+// CanViewFollowings 关注列表：隐藏后仅本人可见；私密账号仅粉丝可见
+func (a *PrivacyAccess) CanViewFollowings() bool {
+	return a.self || (!a.Setting.HideFollowingList && a.canViewRelations())
+}
+
+// CanViewCounts 粉丝数、关注数
+func (a *PrivacyAccess) CanViewCounts() bool {
+	return a.self || !a.Setting.HideCounts
+}
+
+func (a *PrivacyAccess) canViewRelations() bool {
+	return !a.Setting.PrivateAccount || a.follower
+}
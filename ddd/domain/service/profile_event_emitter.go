@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-service/ddd/infrastructure/database/po"
+	kafkainfra "user-service/ddd/infrastructure/kafka"
+	pkgkafka "user-service/pkg/kafka"
+	"user-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// ProfileSnapshot 变更前的公开资料字段（字段名 -> 值），用于与变更后对比
+type ProfileSnapshot map[string]string
+
+// SnapshotProfile 记录其他服务会缓存展示的字段；头像取对外展示的地址，
+// 处理完成后原图会被删除，下游不能缓存原图 key
+func SnapshotProfile(user *po.UserPo) ProfileSnapshot {
+	return ProfileSnapshot{
+		"account":     user.Account,
+		"nickname":    user.Nickname,
+		"avatar_url":  PublicAvatarURL(user),
+		"description": user.Description,
+		"cover_url":   user.CoverUrl,
+		"handle":      user.GetHandle(),
+	}
+}
+
+// ProfileEventEmitter 对比资料变更前后的快照，生成 user.profile.changed 事件，
+// 由调用方随资料更新写入发件箱，保证资料落库与事件发布的原子性
+type ProfileEventEmitter struct {
+	enabled bool
+}
+
+func NewProfileEventEmitter() *ProfileEventEmitter {
+	return &ProfileEventEmitter{enabled: outboxEnabled()}
+}
+
+// Events 在落库前调用，next 为即将写入的资料（Version 仍为更新前的值）；
+// 无字段变化或未启用 Kafka 时返回空
+func (e *ProfileEventEmitter) Events(ctx context.Context, source string, before ProfileSnapshot, next *po.UserPo) []*po.EventOutboxPo {
+	if !e.enabled {
+		return nil
+	}
+	ev := buildProfileChangedEvent(source, before, next)
+	if ev == nil {
+		return nil
+	}
+	row, err := newOutboxEvent(pkgkafka.ProfileChangedTopic, ev.UserUUID, ev.EventID, ev)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("encode profile changed event failed user=%s err=%v", ev.UserUUID, err)
+		return nil
+	}
+	return []*po.EventOutboxPo{row}
+}
+
+// buildProfileChangedEvent 生成变更事件，无字段变化时返回 nil；版本号为本次更新后的值
+func buildProfileChangedEvent(source string, before ProfileSnapshot, next *po.UserPo) *kafkainfra.ProfileChangedEvent {
+	changes := make(map[string]kafkainfra.FieldChange)
+	for field, value := range SnapshotProfile(next) {
+		if old := before[field]; old != value {
+			changes[field] = kafkainfra.FieldChange{Old: old, New: value}
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return &kafkainfra.ProfileChangedEvent{
+		SchemaVersion: kafkainfra.ProfileChangedSchemaVersion,
+		EventID:       uuid.NewString(),
+		UserUUID:      next.UserUUID,
+		Version:       next.Version + 1,
+		Source:        source,
+		Changes:       changes,
+		TS:            time.Now().UnixMilli(),
+	}
+}
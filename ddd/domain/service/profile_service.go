@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"user-service/ddd/application/cqe"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	kafkainfra "user-service/ddd/infrastructure/kafka"
+	"user-service/pkg/config"
+	"user-service/pkg/errno"
+	"user-service/pkg/logger"
+)
+
+const (
+	nicknameMaxLen    = 32
+	descriptionMaxLen = 500
+	profileURLMaxLen  = 512
+
+	reasonTooLong       = "too_long"
+	reasonBlank         = "blank"
+	reasonInvalidURL    = "invalid_url"
+	reasonURLNotAllowed = "url_not_allowed"
+)
+
+// ProfileService 个人资料编辑：按 merge-patch 语义应用变更，逐字段清洗与校验
+type ProfileService struct {
+	userRepo    repo.UserRepository
+	avatars     *AvatarProcessor
+	moderator   *ContentModerator
+	events      *ProfileEventEmitter
+	urlPrefixes []string
+}
+
+func NewProfileService() *ProfileService {
+	s := &ProfileService{
+		userRepo:    persistence.NewUserRepository(),
+		avatars:     NewAvatarProcessor(),
+		moderator:   NewContentModerator(),
+		events:      NewProfileEventEmitter(),
+		urlPrefixes: []string{"image/avatar/", "image/cover/"},
+	}
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		s.urlPrefixes = cfg.Profile.AllowedURLPrefixes
+	}
+	return s
+}
+
+// Patch 校验并应用资料变更，全部字段通过校验后才落库
+func (s *ProfileService) Patch(ctx context.Context, userUUID string, req *cqe.ProfilePatchReq) (*po.UserPo, error) {
+	if req == nil {
+		return nil, errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "request")
+	}
+	user, err := s.userRepo.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if req.IfMatch != nil && *req.IfMatch != user.Version {
+		return nil, errno.ErrUserVersionConflict
+	}
+	before := SnapshotProfile(user)
+	previousAvatar := user.AvatarUrl
+	var errs errno.ValidationErrors
+	if req.Nickname.Set {
+		user.Nickname = s.text(&errs, "nickname", req.Nickname, nicknameMaxLen, false)
+	}
+	if req.Description.Set {
+		user.Description = s.text(&errs, "description", req.Description, descriptionMaxLen, true)
+	}
+	if req.AvatarUrl.Set {
+		user.AvatarUrl = s.url(&errs, "avatar_url", req.AvatarUrl)
+	}
+	if req.CoverUrl.Set {
+		user.CoverUrl = s.url(&errs, "cover_url", req.CoverUrl)
+	}
+	if err := errs.OrNil(); err != nil {
+		return nil, err
+	}
+	avatarChanged := user.AvatarUrl != previousAvatar
+	if avatarChanged {
+		// 旧头像的尺寸不再适用，待新头像处理完成后重新写入
+		user.AvatarVariants = ""
+	}
+	events := s.events.Events(ctx, kafkainfra.ProfileChangeSourceProfile, before, user)
+	if err := s.userRepo.UpdateUser(ctx, user, events...); err != nil {
+		return nil, err
+	}
+	if avatarChanged && user.AvatarUrl != "" {
+		if err := s.avatars.Enqueue(ctx, userUUID, user.AvatarUrl); err != nil {
+			logger.WithContext(ctx).Errorf("enqueue avatar job failed user=%s err=%v", userUUID, err)
+		}
+	}
+	return user, nil
+}
+
+// CheckSaveFields 旧版整体保存接口（空值表示不修改）复用 Patch 的逐字段清洗与校验，返回清洗后的昵称与头像地址
+func (s *ProfileService) CheckSaveFields(nickname, avatarUrl string) (string, string, error) {
+	var errs errno.ValidationErrors
+	if nickname != "" {
+		nickname = s.text(&errs, "nickname", cqe.PatchString{Set: true, Value: nickname}, nicknameMaxLen, false)
+	}
+	if avatarUrl != "" {
+		avatarUrl = s.url(&errs, "avatar_url", cqe.PatchString{Set: true, Value: avatarUrl})
+	}
+	return nickname, avatarUrl, errs.OrNil()
+}
+
+func (s *ProfileService) text(errs *errno.ValidationErrors, field string, v cqe.PatchString, maxLen int, multiline bool) string {
+	if v.Null {
+		return ""
+	}
+	value := strings.TrimSpace(StripControlChars(v.Value, multiline))
+	if value == "" && v.Value != "" {
+		// 仅由空白/控制字符组成，视为误输入而非清空
+		errs.Add(field, reasonBlank)
+	}
+	if utf8.RuneCountInString(value) > maxLen {
+		errs.Add(field, reasonTooLong)
+	}
+	value, ok := s.moderator.Sanitize(value)
+	if !ok {
+		errs.Add(field, reasonSensitive)
+	}
+	return value
+}
+
+func (s *ProfileService) url(errs *errno.ValidationErrors, field string, v cqe.PatchString) string {
+	if v.Null {
+		return ""
+	}
+	value := strings.TrimSpace(v.Value)
+	switch {
+	case value == "":
+		return ""
+	case len(value) > profileURLMaxLen:
+		errs.Add(field, reasonTooLong)
+	case strings.ContainsAny(value, " \\") || strings.Contains(value, "..") || StripControlChars(value, false) != value:
+		errs.Add(field, reasonInvalidURL)
+	case !s.allowedURL(value):
+		errs.Add(field, reasonURLNotAllowed)
+	}
+	return value
+}
+
+func (s *ProfileService) allowedURL(value string) bool {
+	for _, prefix := range s.urlPrefixes {
+		if prefix != "" && strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// StripControlChars 去除控制字符与双向文本覆盖字符，multiline 时保留换行
+func StripControlChars(s string, multiline bool) string {
+	return strings.Map(func(r rune) rune {
+		if multiline && r == '\n' {
+			return r
+		}
+		if unicode.IsControl(r) || isBidiControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func isBidiControl(r rune) bool {
+	return (r >= 0x202A && r <= 0x202E) || (r >= 0x2066 && r <= 0x2069) || r == 0x200E || r == 0x200F
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/cache"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/internal/resource"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+)
+
+const (
+	// recommendFollowingSample 只展开用户最近关注的这些人，避免关注数很多的用户查询过重
+	recommendFollowingSample = 200
+	recommendActiveBatch     = 200
+	// recommendOverfetch 候选多取的倍数，为拉黑、已忽略、已注销等过滤留出余量
+	recommendOverfetch = 2
+	// RecommendLeaseTTL 预计算租约有效期；持有者在此时间的 1/3 内续期，停止续期后其他副本接手
+	RecommendLeaseTTL = time.Minute
+)
+
+// errRecommendLeaseLost 预计算途中租约被其他副本取得，本轮中止
+var errRecommendLeaseLost = errors.New("recommendation builder lease lost")
+
+// RecommendService 推荐关注：以“关注的人中有多少人也关注了 TA”为候选打分，候选不足时（如关注很少的新用户）
+// 用粉丝数最多的账号补位。后台组件定期为近期活跃用户预计算并缓存，读取时再按最新的关注、拉黑、忽略状态过滤。
+// 多副本部署时只有持有 Redis 租约的副本做预计算；未配置 Redis 时视为单副本部署
+type RecommendService struct {
+	recommendRepo repo.RecommendationRepository
+	followRepo    repo.FollowRepository
+	userRepo      repo.UserRepository
+	deviceRepo    repo.UserDeviceRepository
+	blocks        *BlockService
+	leader        *cache.LeaderLock
+	size          int
+	activeWithin  time.Duration
+}
+
+func NewRecommendService() *RecommendService {
+	s := &RecommendService{
+		recommendRepo: persistence.NewRecommendationRepository(),
+		followRepo:    persistence.NewFollowRepository(),
+		userRepo:      persistence.NewUserRepository(),
+		deviceRepo:    persistence.NewUserDeviceRepository(),
+		blocks:        NewBlockService(),
+		size:          50,
+		activeWithin:  7 * 24 * time.Hour,
+	}
+	if cli := resource.DefaultRedisResource().Client(); cli != nil {
+		s.leader = cache.NewLeaderLock(cli, "recommendation_builder", RecommendLeaseTTL)
+	}
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		s.size = cfg.Recommend.Size
+		s.activeWithin = cfg.Recommend.ActiveWithin
+	}
+	return s
+}
+
+// Lead 取得或续期预计算租约，返回本副本是否负责预计算；Redis 出错时不做，避免与其他副本重复计算
+func (s *RecommendService) Lead(ctx context.Context) bool {
+	if s.leader == nil {
+		return true
+	}
+	ok, err := s.leader.Acquire(ctx)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("recommendation builder acquire lease failed err=%v", err)
+	}
+	return ok && err == nil
+}
+
+// Resign 停止时主动释放租约，其他副本无需等待过期即可接手
+func (s *RecommendService) Resign(ctx context.Context) {
+	if s.leader == nil {
+		return
+	}
+	if err := s.leader.Release(ctx); err != nil {
+		logger.WithContext(ctx).Warnf("recommendation builder release lease failed err=%v", err)
+	}
+}
+
+// popular 热门账号的前 limit 个；候选池大小固定，所有调用方共用同一份缓存
+func (s *RecommendService) popular(ctx context.Context, limit int, refresh bool) ([]vo.SuggestedUser, error) {
+	list, err := s.recommendRepo.Popular(ctx, s.size*recommendOverfetch, refresh)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+// Suggestions 返回推荐列表的前 limit 个；没有预计算结果的用户（如刚注册）现算并缓存。
+// 未登录访客只返回热门账号
+func (s *RecommendService) Suggestions(ctx context.Context, userUUID string, limit int) ([]vo.SuggestedUser, error) {
+	if userUUID == "" {
+		return s.popular(ctx, limit, false)
+	}
+	list, ok, err := s.recommendRepo.GetSuggestions(ctx, userUUID)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("get cached recommendations failed user=%s err=%v", userUUID, err)
+	}
+	if !ok {
+		return s.Build(ctx, userUUID, limit)
+	}
+	// 预计算之后新关注、拉黑或忽略的账号在这里去掉
+	return s.filter(ctx, userUUID, list, limit)
+}
+
+// Build 重新计算并缓存用户的推荐列表，返回其中前 limit 个
+func (s *RecommendService) Build(ctx context.Context, userUUID string, limit int) ([]vo.SuggestedUser, error) {
+	candidates, err := s.recommendRepo.FriendsOfFriends(ctx, userUUID, recommendFollowingSample, s.size*recommendOverfetch)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.filter(ctx, userUUID, candidates, s.size)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) < s.size {
+		popular, err := s.popular(ctx, s.size*recommendOverfetch, false)
+		if err != nil {
+			return nil, err
+		}
+		picked := make(map[string]bool, len(list))
+		for _, v := range list {
+			picked[v.UserUUID] = true
+		}
+		rest := make([]vo.SuggestedUser, 0, len(popular))
+		for _, v := range popular {
+			if !picked[v.UserUUID] {
+				rest = append(rest, v)
+			}
+		}
+		more, err := s.filter(ctx, userUUID, rest, s.size-len(list))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, more...)
+	}
+	if err := s.recommendRepo.SaveSuggestions(ctx, userUUID, list); err != nil {
+		logger.WithContext(ctx).Warnf("save recommendations failed user=%s err=%v", userUUID, err)
+	}
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+// RebuildActive 刷新热门账号后，为 activeWithin 内登录过的用户逐个重建推荐列表，返回成功的用户数；
+// 单个用户失败只记录日志。调用方需先通过 Lead 取得租约，途中续期失败即中止
+func (s *RecommendService) RebuildActive(ctx context.Context) (int, error) {
+	if _, err := s.popular(ctx, s.size*recommendOverfetch, true); err != nil {
+		return 0, err
+	}
+	since := time.Now().Add(-s.activeWithin)
+	var (
+		afterUUID string
+		built     int
+	)
+	renewedAt := time.Now()
+	for {
+		users, err := s.deviceRepo.ListActiveUsers(ctx, since, afterUUID, recommendActiveBatch)
+		if err != nil {
+			return built, err
+		}
+		for _, u := range users {
+			if err := ctx.Err(); err != nil {
+				return built, err
+			}
+			if time.Since(renewedAt) > RecommendLeaseTTL/3 {
+				if !s.Lead(ctx) {
+					return built, errRecommendLeaseLost
+				}
+				renewedAt = time.Now()
+			}
+			if _, err := s.Build(ctx, u, s.size); err != nil {
+				logger.WithContext(ctx).Warnf("build recommendations failed user=%s err=%v", u, err)
+				continue
+			}
+			built++
+		}
+		if len(users) < recommendActiveBatch {
+			return built, nil
+		}
+		afterUUID = users[len(users)-1]
+	}
+}
+
+// Dismiss 用户对推荐的账号点“不感兴趣”，之后不再推荐；已缓存的列表在读取时过滤
+func (s *RecommendService) Dismiss(ctx context.Context, userUUID, targetUUID string) error {
+	return s.recommendRepo.Dismiss(ctx, userUUID, targetUUID)
+}
+
+// filter 按顺序保留最多 limit 个可推荐的账号：去掉本人、已关注、任一方向拉黑、已屏蔽、已忽略以及已注销的账号
+func (s *RecommendService) filter(ctx context.Context, userUUID string, candidates []vo.SuggestedUser, limit int) ([]vo.SuggestedUser, error) {
+	list := make([]vo.SuggestedUser, 0, len(candidates))
+	if len(candidates) == 0 || limit <= 0 {
+		return list, nil
+	}
+	uuids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		uuids = append(uuids, c.UserUUID)
+	}
+	following, _, err := s.followRepo.RelationAmong(ctx, userUUID, uuids)
+	if err != nil {
+		return nil, err
+	}
+	relations, err := s.blocks.Relations(ctx, userUUID, uuids)
+	if err != nil {
+		return nil, err
+	}
+	dismissed, err := s.recommendRepo.DismissedAmong(ctx, userUUID, uuids)
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := s.userRepo.GetUserProfiles(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range candidates {
+		rel := relations[c.UserUUID]
+		if c.UserUUID == userUUID || following[c.UserUUID] || dismissed[c.UserUUID] ||
+			rel.Blocking || rel.BlockedBy || rel.Muting {
+			continue
+		}
+		if _, ok := profiles[c.UserUUID]; !ok {
+			continue
+		}
+		list = append(list, c)
+		if len(list) == limit {
+			break
+		}
+	}
+	return list, nil
+}
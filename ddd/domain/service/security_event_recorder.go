@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/pkg/assert"
+	"user-service/pkg/grpcutil"
+	"user-service/pkg/logger"
+	"user-service/pkg/reqmeta"
+)
+
+const (
+	securityEventQueueSize     = 4096
+	securityEventBatchSize     = 100
+	securityEventFlushInterval = time.Second
+	securityEventUserAgentMax  = 255
+)
+
+var (
+	onceSecurityEventRecorder      sync.Once
+	singletonSecurityEventRecorder *SecurityEventRecorder
+)
+
+// SecurityEventRecorder 异步记录安全审计事件：调用方非阻塞入队，后台批量落库。
+// 审计写入失败不影响主流程；队列满时丢弃并告警。
+type SecurityEventRecorder struct {
+	repo   repo.SecurityEventRepository
+	queue  chan *po.SecurityEventPo
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func DefaultSecurityEventRecorder() *SecurityEventRecorder {
+	assert.NotCircular()
+	onceSecurityEventRecorder.Do(func() {
+		singletonSecurityEventRecorder = &SecurityEventRecorder{
+			repo:  persistence.NewSecurityEventRepository(),
+			queue: make(chan *po.SecurityEventPo, securityEventQueueSize),
+		}
+	})
+	assert.NotNil(singletonSecurityEventRecorder)
+	return singletonSecurityEventRecorder
+}
+
+// Record 记录一条安全事件，IP/UA/request_id 从 context 中提取
+func (r *SecurityEventRecorder) Record(ctx context.Context, userUUID, eventType, result string, metadata map[string]string) {
+	if r == nil {
+		return
+	}
+	client := reqmeta.ClientInfoFromContext(ctx)
+	meta := "{}"
+	if len(metadata) > 0 {
+		if b, err := json.Marshal(metadata); err == nil {
+			meta = string(b)
+		}
+	}
+	ev := &po.SecurityEventPo{
+		UserUUID:  userUUID,
+		EventType: eventType,
+		Result:    result,
+		IP:        client.IP,
+		UserAgent: truncate(client.UserAgent, securityEventUserAgentMax),
+		RequestID: grpcutil.RequestIDFromContext(ctx),
+		Metadata:  meta,
+		CreatedAt: time.Now(),
+	}
+	select {
+	case r.queue <- ev:
+	default:
+		logger.WithContext(ctx).Warnf("security event dropped, queue full user=%s type=%s", userUUID, eventType)
+	}
+}
+
+// Start 启动后台写入协程
+func (r *SecurityEventRecorder) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.loop(ctx)
+}
+
+// Stop 停止写入协程，并尽量落库队列中剩余的事件
+func (r *SecurityEventRecorder) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+func (r *SecurityEventRecorder) loop(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(securityEventFlushInterval)
+	defer ticker.Stop()
+	batch := make([]*po.SecurityEventPo, 0, securityEventBatchSize)
+	for {
+		select {
+		case ev := <-r.queue:
+			batch = append(batch, ev)
+			if len(batch) >= securityEventBatchSize {
+				batch = r.flush(batch)
+			}
+		case <-ticker.C:
+			batch = r.flush(batch)
+		case <-ctx.Done():
+			for {
+				select {
+				case ev := <-r.queue:
+					batch = append(batch, ev)
+				default:
+					r.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *SecurityEventRecorder) flush(batch []*po.SecurityEventPo) []*po.SecurityEventPo {
+	if len(batch) == 0 {
+		return batch
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.repo.SaveEvents(ctx, batch); err != nil {
+		logger.Errorf("save security events failed count=%d err=%v", len(batch), err)
+	}
+	return batch[:0]
+}
@@ -2,49 +2,82 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"user-service/ddd/domain/entity"
 	"user-service/ddd/domain/repo"
-	"user-service/ddd/infrastructure/cache"
+	"user-service/ddd/domain/vo"
 	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	grpcinfra "user-service/ddd/infrastructure/grpc"
 	kafkainfra "user-service/ddd/infrastructure/kafka"
-	"user-service/internal/resource"
+	"user-service/pkg/errno"
+	"user-service/pkg/grpcutil"
+	pkgkafka "user-service/pkg/kafka"
+	"user-service/pkg/logger"
+	"user-service/pkg/reqmeta"
+
+	"github.com/google/uuid"
+	notificationpb "github.com/jiangqiao2/go-video-proto/proto/notification/notification"
 )
 
 // SocialService 封装关注/取关相关的领域逻辑：
-// - 正常情况下：通过 Kafka 异步写 DB，同时立即在 Redis 中更新关注边，保证用户“是否关注”的查询是实时的；
-// - 降级路径：当 Kafka 不可用时，退化为直接写数据库 + 缓存（FollowRepository 内部已处理）。
+// 关注关系直接写库（仓储内同步更新 Redis 关注边与计数），同一事务内写入 user.follow.changed 事件到发件箱，
+// 由 OutboxRelay 投递到 Kafka，避免出现“缓存已标记关注、落库却失败”或事件丢失。
 type SocialService struct {
 	followRepo  repo.FollowRepository
-	followCache *cache.FollowCache
+	blockRepo   repo.UserBlockRepository
+	privacyRepo repo.UserPrivacyRepository
+	userRepo    repo.UserRepository
+	enabled     bool
 }
 
-// NewSocialService 使用默认的仓储和 Redis 客户端创建服务。
+const (
+	followRequestNotificationType  = "follow_request"
+	followApprovedNotificationType = "follow_approved"
+	followNotificationTimeout      = 3 * time.Second
+)
+
+// NewSocialService 使用默认的仓储创建服务。
 func NewSocialService() *SocialService {
-	var followCache *cache.FollowCache
-	if cli := resource.DefaultRedisResource().Client(); cli != nil {
-		followCache = cache.NewFollowCache(cli)
-	}
 	return &SocialService{
 		followRepo:  persistence.NewFollowRepository(),
-		followCache: followCache,
+		blockRepo:   persistence.NewUserBlockRepository(),
+		privacyRepo: persistence.NewUserPrivacyRepository(),
+		userRepo:    persistence.NewUserRepository(),
+		enabled:     outboxEnabled(),
 	}
 }
 
-func (s *SocialService) markEdge(ctx context.Context, userUUID, targetUUID string, following bool) {
-	if s == nil || s.followCache == nil {
-		return
+// followEvents 生成随关注关系写入发件箱的事件，未启用 Kafka 时为空；reason 仅在非本人操作时填写
+func (s *SocialService) followEvents(ctx context.Context, op, reason, userUUID, targetUUID string) []*po.EventOutboxPo {
+	if !s.enabled {
+		return nil
+	}
+	ev := kafkainfra.FollowEvent{
+		EventID:    uuid.NewString(),
+		UserUUID:   userUUID,
+		TargetUUID: targetUUID,
+		Op:         op,
+		Reason:     reason,
+		TS:         time.Now().UnixMilli(),
 	}
-	_ = s.followCache.SetEdge(ctx, userUUID, targetUUID, following)
+	row, err := newOutboxEvent(pkgkafka.FollowChangedTopic, targetUUID, ev.EventID, &ev)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("encode follow event failed op=%s user=%s target=%s err=%v", op, userUUID, targetUUID, err)
+		return nil
+	}
+	return []*po.EventOutboxPo{row}
 }
 
-// ToggleFollow 根据 action 统一处理关注/取关：
-// - "follow"   -> Follow
-// - "unfollow" -> Unfollow
-func (s *SocialService) ToggleFollow(ctx context.Context, followEntity *entity.FollowEntity) error {
+// ToggleFollow 根据 action 统一处理关注/取关，返回操作后的关注状态（vo.FollowState*）：
+// - "follow"   -> 对方为私密账号时发起关注申请（Pending），否则直接关注；已关注或已申请时不重复处理
+// - "unfollow" -> 取消关注，或撤回待处理的申请
+func (s *SocialService) ToggleFollow(ctx context.Context, followEntity *entity.FollowEntity) (string, error) {
 	if s == nil || followEntity == nil {
-		return nil
+		return "", nil
 	}
 	userUUID := followEntity.UserUUID()
 	targetUUID := followEntity.TargetUserUUID()
@@ -52,21 +85,164 @@ func (s *SocialService) ToggleFollow(ctx context.Context, followEntity *entity.F
 
 	switch status {
 	case kafkainfra.FollowOpFollow:
-		// 优先通过 Kafka 异步落库，失败时退化为直接写 DB。
-		if err := kafkainfra.PublishFollowEvent(ctx, kafkainfra.FollowOpFollow, userUUID, targetUUID); err != nil {
-			return s.followRepo.Follow(ctx, userUUID, targetUUID)
-		}
-		// Kafka 成功后，先在缓存里标记为已关注，提升查询体验。
-		s.markEdge(ctx, userUUID, targetUUID, true)
-		return nil
+		return s.follow(ctx, userUUID, targetUUID)
 	case kafkainfra.FollowOpUnfollow:
-		if err := kafkainfra.PublishFollowEvent(ctx, kafkainfra.FollowOpUnfollow, userUUID, targetUUID); err != nil {
-			return s.followRepo.Unfollow(ctx, userUUID, targetUUID)
-		}
-		s.markEdge(ctx, userUUID, targetUUID, false)
-		return nil
+		return vo.FollowStateUnfollowed, s.removeFollow(ctx, userUUID, targetUUID)
 	default:
 		// 理论上不会走到这里，Action 已在 CQE 层校验。
-		return fmt.Errorf("unsupported follow status: %s", status)
+		return "", fmt.Errorf("unsupported follow status: %s", status)
+	}
+}
+
+func (s *SocialService) follow(ctx context.Context, userUUID, targetUUID string) (string, error) {
+	// 任一方拉黑了另一方时不能关注
+	blocked, err := s.blockRepo.IsBlockedBetween(ctx, userUUID, targetUUID)
+	if err != nil {
+		return "", err
+	}
+	if blocked {
+		return "", errno.ErrUserBlocked
+	}
+	state, err := s.followRepo.FollowState(ctx, userUUID, targetUUID)
+	if err != nil {
+		return "", err
+	}
+	if state == vo.FollowStateFollowing || state == vo.FollowStatePending {
+		return state, nil
+	}
+	setting, err := s.privacyRepo.GetPrivacy(ctx, targetUUID)
+	if err != nil {
+		return "", err
+	}
+	if setting != nil && setting.PrivateAccount {
+		changed, err := s.followRepo.Request(ctx, userUUID, targetUUID)
+		if err != nil {
+			return "", err
+		}
+		if !changed {
+			// 并发请求已先一步写入，以库中状态为准
+			return s.followRepo.FollowState(ctx, userUUID, targetUUID)
+		}
+		// 反复申请、撤回不重复打扰对方
+		if s.followRepo.ClaimRequestNotice(ctx, userUUID, targetUUID) {
+			s.notify(ctx, targetUUID, followRequestNotificationType, "新的关注申请", "%s 请求关注你", userUUID)
+		}
+		return vo.FollowStatePending, nil
+	}
+	op := kafkainfra.FollowOpFollow
+	changed, err := s.followRepo.Follow(ctx, userUUID, targetUUID, s.followEvents(ctx, op, "", userUUID, targetUUID)...)
+	if err != nil {
+		return "", err
+	}
+	if !changed {
+		return s.followRepo.FollowState(ctx, userUUID, targetUUID)
 	}
+	return vo.FollowStateFollowing, nil
+}
+
+// ApproveRequest 私密账号 targetUUID 同意 requesterUUID 的关注申请
+func (s *SocialService) ApproveRequest(ctx context.Context, targetUUID, requesterUUID string) error {
+	op := kafkainfra.FollowOpFollow
+	ok, err := s.followRepo.ResolveRequest(ctx, requesterUUID, targetUUID, true, s.followEvents(ctx, op, "", requesterUUID, targetUUID)...)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errno.ErrFollowReqNotFound
+	}
+	s.notify(ctx, requesterUUID, followApprovedNotificationType, "关注申请已通过", "%s 同意了你的关注申请", targetUUID)
+	return nil
+}
+
+// RejectRequest 拒绝关注申请，对方之后可以重新申请
+func (s *SocialService) RejectRequest(ctx context.Context, targetUUID, requesterUUID string) error {
+	ok, err := s.followRepo.ResolveRequest(ctx, requesterUUID, targetUUID, false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errno.ErrFollowReqNotFound
+	}
+	return nil
+}
+
+// CancelRequest 申请人撤回自己的关注申请
+func (s *SocialService) CancelRequest(ctx context.Context, requesterUUID, targetUUID string) error {
+	return s.RejectRequest(ctx, targetUUID, requesterUUID)
+}
+
+// RemoveFollower 将 followerUUID 从 userUUID 的粉丝中移除，不拉黑，对方之后可以重新关注。
+// 与取关一样只在关注状态下转为取关，事件以 reason 区分，供下游不把它当作对方主动取关；
+// 审计记录写入关系审计表，与状态变更同一事务
+func (s *SocialService) RemoveFollower(ctx context.Context, userUUID, followerUUID string) error {
+	op := kafkainfra.FollowOpUnfollow
+	events := s.followEvents(ctx, op, kafkainfra.FollowReasonRemovedByTarget, followerUUID, userUUID)
+	client := reqmeta.ClientInfoFromContext(ctx)
+	audit := &po.RelationAuditPo{
+		UserUUID:   userUUID,
+		TargetUUID: followerUUID,
+		Action:     vo.RelationAuditFollowerRemove,
+		IP:         client.IP,
+		UserAgent:  truncate(client.UserAgent, securityEventUserAgentMax),
+		RequestID:  grpcutil.RequestIDFromContext(ctx),
+		CreatedAt:  time.Now(),
+	}
+	ok, err := s.followRepo.RemoveFollower(ctx, userUUID, followerUUID, audit, events...)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errno.ErrNotFollower
+	}
+	return nil
+}
+
+// removeFollow 解除 userUUID 对 targetUUID 的关注或撤回申请，没有关系时不做任何事（避免计数被错误扣减）
+func (s *SocialService) removeFollow(ctx context.Context, userUUID, targetUUID string) error {
+	state, err := s.followRepo.FollowState(ctx, userUUID, targetUUID)
+	if err != nil {
+		return err
+	}
+	switch state {
+	case vo.FollowStateFollowing:
+		// 只有确实从关注转为取关时才写事件、扣计数，并发的重复取关不会重复扣减
+		op := kafkainfra.FollowOpUnfollow
+		_, err := s.followRepo.Unfollow(ctx, userUUID, targetUUID, s.followEvents(ctx, op, "", userUUID, targetUUID)...)
+		return err
+	case vo.FollowStatePending:
+		_, err := s.followRepo.ResolveRequest(ctx, userUUID, targetUUID, false)
+		return err
+	}
+	return nil
+}
+
+// notify 异步通知 receiverUUID，content 中的 %s 替换为 actorUUID 的昵称
+func (s *SocialService) notify(ctx context.Context, receiverUUID, notificationType, title, content, actorUUID string) {
+	client := grpcinfra.DefaultNotificationServiceClient()
+	if client == nil {
+		return
+	}
+	name := "有用户"
+	if actor, err := s.userRepo.GetUserProfile(ctx, actorUUID); err == nil && actor.Nickname != "" {
+		name = actor.Nickname
+	}
+	extraJson := "{}"
+	if b, err := json.Marshal(map[string]string{"user_uuid": actorUUID}); err == nil {
+		extraJson = string(b)
+	}
+	log := logger.WithContext(ctx)
+	go func() {
+		nctx, cancel := context.WithTimeout(context.Background(), followNotificationTimeout)
+		defer cancel()
+		_, err := client.CreateNotification(nctx, &notificationpb.CreateNotificationRequest{
+			UserUuid:  receiverUUID,
+			Type:      notificationType,
+			Title:     title,
+			Content:   fmt.Sprintf(content, name),
+			ExtraJson: extraJson,
+		})
+		if err != nil {
+			log.Warnf("send %s notification failed user=%s err=%v", notificationType, receiverUUID, err)
+		}
+	}()
 }
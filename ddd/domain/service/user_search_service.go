@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/persistence"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+	"user-service/pkg/logger"
+	"user-service/pkg/usersearch"
+)
+
+const searchRebuildBatch = 500
+
+// UserSearchService 用户搜索：维护搜索索引（资料变更时增量更新、定期全量重建），按匹配程度和粉丝数排序返回用户
+type UserSearchService struct {
+	userRepo   repo.UserRepository
+	followRepo repo.FollowRepository
+	backend    usersearch.Backend
+}
+
+func NewUserSearchService() *UserSearchService {
+	return &UserSearchService{
+		userRepo:   persistence.NewUserRepository(),
+		followRepo: persistence.NewFollowRepository(),
+		backend:    resource.DefaultSearchResource().Backend(),
+	}
+}
+
+// Index 增量更新单个用户的索引，失败只记录日志，下次全量重建时修正
+func (s *UserSearchService) Index(ctx context.Context, user *po.UserPo) {
+	if s.backend == nil || user == nil {
+		return
+	}
+	if user.IsDeleted != 0 {
+		s.Remove(ctx, user.UserUUID)
+		return
+	}
+	followers, err := s.followRepo.CountFollowers(ctx, user.UserUUID)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("count followers for search index failed user=%s err=%v", user.UserUUID, err)
+	}
+	if err := s.backend.Upsert(ctx, toSearchDocument(user, followers)); err != nil {
+		logger.WithContext(ctx).Warnf("update search index failed user=%s err=%v", user.UserUUID, err)
+	}
+}
+
+// Remove 从索引中删除用户
+func (s *UserSearchService) Remove(ctx context.Context, userUUID string) {
+	if s.backend == nil {
+		return
+	}
+	if err := s.backend.Remove(ctx, userUUID); err != nil {
+		logger.WithContext(ctx).Warnf("remove from search index failed user=%s err=%v", userUUID, err)
+	}
+}
+
+// Rebuild 全量重建索引，返回索引的用户数
+func (s *UserSearchService) Rebuild(ctx context.Context) (int, error) {
+	if s.backend == nil {
+		return 0, nil
+	}
+	var (
+		docs    []usersearch.Document
+		afterID uint64
+	)
+	for {
+		users, err := s.userRepo.ScanActiveUsers(ctx, afterID, searchRebuildBatch)
+		if err != nil {
+			return 0, err
+		}
+		if len(users) == 0 {
+			break
+		}
+		uuids := make([]string, 0, len(users))
+		for _, u := range users {
+			uuids = append(uuids, u.UserUUID)
+		}
+		counts, err := s.followRepo.CountFollowersBatch(ctx, uuids)
+		if err != nil {
+			return 0, err
+		}
+		for _, u := range users {
+			docs = append(docs, toSearchDocument(u, counts[u.UserUUID]))
+		}
+		afterID = users[len(users)-1].Id
+		if len(users) < searchRebuildBatch {
+			break
+		}
+	}
+	if err := s.backend.Replace(ctx, docs); err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
+// Search 返回当前页的用户（按相关度排序）和命中总数；索引中已不存在的用户会被跳过并移出索引
+func (s *UserSearchService) Search(ctx context.Context, text string, offset, limit int) ([]*po.UserPo, int64, error) {
+	if s.backend == nil {
+		return nil, 0, nil
+	}
+	hits, total, err := s.backend.Search(ctx, usersearch.Query{Text: text, Offset: offset, Limit: limit})
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(hits) == 0 {
+		return nil, int64(total), nil
+	}
+	uuids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		uuids = append(uuids, h.UserUUID)
+	}
+	users, err := s.userRepo.GetUsersByUUIDs(ctx, uuids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byUUID := make(map[string]*po.UserPo, len(users))
+	for _, u := range users {
+		if u.IsDeleted == 0 {
+			byUUID[u.UserUUID] = u
+		}
+	}
+	result := make([]*po.UserPo, 0, len(hits))
+	for _, h := range hits {
+		u, ok := byUUID[h.UserUUID]
+		if !ok {
+			s.Remove(ctx, h.UserUUID)
+			continue
+		}
+		result = append(result, u)
+	}
+	return result, int64(total), nil
+}
+
+func toSearchDocument(user *po.UserPo, followers int64) usersearch.Document {
+	return usersearch.Document{
+		UserUUID:  user.UserUUID,
+		Nickname:  user.Nickname,
+		Handle:    user.GetHandle(),
+		Followers: followers,
+	}
+}
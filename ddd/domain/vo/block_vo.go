@@ -0,0 +1,29 @@
+package vo
+
+// 拉黑/屏蔽关系类型
+const (
+	BlockKindBlock = "block" // 拉黑：双方互不可见，解除关注且不能再关注
+	BlockKindMute  = "mute"  // 屏蔽：只影响自己看到的内容，对方无感知
+)
+
+// 拉黑/屏蔽操作
+const (
+	BlockActionBlock   = "block"
+	BlockActionUnblock = "unblock"
+	BlockActionMute    = "mute"
+	BlockActionUnmute  = "unmute"
+)
+
+// CheckBlockKind 校验关系类型取值
+func CheckBlockKind(v string) bool {
+	return v == BlockKindBlock || v == BlockKindMute
+}
+
+// CheckBlockAction 校验操作取值
+func CheckBlockAction(v string) bool {
+	switch v {
+	case BlockActionBlock, BlockActionUnblock, BlockActionMute, BlockActionUnmute:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,10 @@
+package vo
+
+// 数据导出任务状态
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusDone    = "done"
+	ExportStatusFailed  = "failed"
+	ExportStatusExpired = "expired"
+)
@@ -31,3 +31,16 @@ func GetFollowStatus(value string) FollowStatusVo {
 func (v FollowStatusVo) Value() string {
 	return v.value
 }
+
+// user_follow.status 取值，计数与关系列表只统计 Following
+const (
+	FollowStateFollowing  = "Following"
+	FollowStatePending    = "Pending" // 关注私密账号的申请，待对方同意
+	FollowStateUnfollowed = "Unfollowed"
+)
+
+// 关注申请的处理方式
+const (
+	FollowRequestApprove = "approve"
+	FollowRequestReject  = "reject"
+)
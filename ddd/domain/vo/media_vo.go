@@ -0,0 +1,22 @@
+package vo
+
+// 用户上传的媒体类型
+const (
+	MediaKindAvatar = "avatar"
+	MediaKindCover  = "cover"
+)
+
+// 头像处理任务状态
+const (
+	MediaJobStatusPending = "pending"
+	MediaJobStatusRunning = "running"
+	MediaJobStatusDone    = "done"
+	MediaJobStatusFailed  = "failed"
+)
+
+// ImageExtensions 允许上传的图片类型及其对象 key 扩展名
+var ImageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
@@ -0,0 +1,8 @@
+package vo
+
+// 事件发件箱状态
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusFailed  = "failed" // 超过最大重试次数，需人工处理
+)
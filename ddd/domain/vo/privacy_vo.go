@@ -0,0 +1,17 @@
+package vo
+
+// 个人主页可见范围
+const (
+	ProfileVisibilityPublic    = "public"    // 所有人
+	ProfileVisibilityFollowers = "followers" // 仅粉丝
+	ProfileVisibilityPrivate   = "private"   // 仅自己
+)
+
+// CheckProfileVisibility 校验可见范围取值
+func CheckProfileVisibility(v string) bool {
+	switch v {
+	case ProfileVisibilityPublic, ProfileVisibilityFollowers, ProfileVisibilityPrivate:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package vo
+
+// 推荐关注的理由
+const (
+	RecommendReasonFollowedByFollowings = "followed_by_followings" // 关注的人也关注了 TA
+	RecommendReasonPopular              = "popular"                // 粉丝数多，用于关注很少的新用户补位
+)
+
+// SuggestedUser 推荐关注的候选账号。Reason 为 followed_by_followings 时 Score 为关注了 TA 的人数（仅统计当前用户关注的人），
+// popular 时为粉丝数
+type SuggestedUser struct {
+	UserUUID string
+	Score    int64
+	Reason   string
+}
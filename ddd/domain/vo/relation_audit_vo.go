@@ -0,0 +1,6 @@
+package vo
+
+// 关系操作审计类型
+const (
+	RelationAuditFollowerRemove = "follower_removed" // 移除粉丝
+)
@@ -0,0 +1,22 @@
+package vo
+
+// 安全事件类型
+const (
+	SecurityEventLoginSuccess   = "login_success"
+	SecurityEventLoginFailure   = "login_failure"
+	SecurityEventTokenRefresh   = "token_refresh"
+	SecurityEventLogout         = "logout"
+	SecurityEventPasswordChange = "password_change"
+	SecurityEventTokenRevoked   = "token_revoked"
+	SecurityEventNewDevice      = "new_device_login"
+	SecurityEventSuspicious     = "suspicious_login"
+	// 预留：2FA 功能上线后使用
+	SecurityEventTwoFactorEnabled  = "2fa_enabled"
+	SecurityEventTwoFactorDisabled = "2fa_disabled"
+)
+
+// 安全事件结果
+const (
+	SecurityResultSuccess = "success"
+	SecurityResultFailure = "failure"
+)
@@ -11,36 +11,129 @@ import (
 )
 
 const (
-	defaultEdgeTTL   = 10 * time.Minute
-	defaultCountTTL  = 5 * time.Minute
-	defaultListTTL   = 2 * time.Minute
-	followingStatus  = "1"
-	notFollowingMark = "0"
+	defaultEdgeTTL    = 10 * time.Minute
+	defaultCountTTL   = 5 * time.Minute
+	defaultListTTL    = 10 * time.Minute
+	defaultListWindow = 1000
+	// requestNoticeWindow: one follow-request notification per requester and target
+	// in this window, so follow/unfollow toggling against a private account does not spam.
+	requestNoticeWindow = 24 * time.Hour
+	// commonFollowersTTL bounds how long a common-follower sample is served. A viewer's
+	// own follow or unfollow invalidates all of their samples at once; changes on the
+	// other side (people the viewer follows following the target) just expire.
+	commonFollowersTTL = 5 * time.Minute
+	// commonFollowersMaxTargets caps the per-viewer hash; it is reset when full.
+	commonFollowersMaxTargets = 256
+	followingStatus           = "1"
+	notFollowingMark          = "0"
+	// listCompleteMarker sits at score 0 (below every real entry) when the cached
+	// window holds the whole list. Trimming removes it first, marking the list partial.
+	listCompleteMarker = ""
 )
 
-// FollowCache caches follow relationships and relation counts in Redis.
+// Every write to a list bumps its generation key (KEYS[2] below). A reader
+// records the generation before querying DB and loads the window only if it is
+// unchanged, so a window read before a concurrent follow/unfollow committed is
+// never written over the change. Generation keys outlive the list by genTTLExtra.
+
+// addFollowScript bumps the list generation, then adds an entry to a cached list
+// and trims it to the window. Lists that are not cached are left alone so a
+// partial list is never created without TTL; they are loaded from DB on the next
+// read. An entry older than the tail of a partial window is skipped too,
+// otherwise it would hide the DB rows between the tail and itself.
+var addFollowScript = redis.NewScript(`
+redis.call('INCR', KEYS[2])
+redis.call('PEXPIRE', KEYS[2], ARGV[4])
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+if not redis.call('ZSCORE', KEYS[1], '') then
+	local tail = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	if tail[2] and tonumber(ARGV[1]) < tonumber(tail[2]) then
+		return 0
+	end
+end
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[2])
+redis.call('ZREMRANGEBYRANK', KEYS[1], 0, -(tonumber(ARGV[3]) + 1))
+return 1
+`)
+
+// removeFollowScript bumps the list generation and removes an entry.
+var removeFollowScript = redis.NewScript(`
+redis.call('INCR', KEYS[2])
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+return redis.call('ZREM', KEYS[1], ARGV[1])
+`)
+
+// loadListScript replaces a list window only if its generation still equals
+// ARGV[1] (the value read before the DB query; absent counts as 0).
+// ARGV[2] is the TTL in ms, ARGV[3] is "1" when the window is the whole list,
+// then score/member pairs follow.
+var loadListScript = redis.NewScript(`
+if (tonumber(redis.call('GET', KEYS[2])) or 0) ~= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+if ARGV[3] == '1' then
+	redis.call('ZADD', KEYS[1], 0, '')
+end
+for i = 4, #ARGV, 2 do
+	redis.call('ZADD', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// genTTLExtra keeps a generation key alive past its list, so a load racing
+// with the list expiry still sees the bumped generation.
+const genTTLExtra = time.Hour
+
+// FollowCache caches follow relationships, relation counts and the newest
+// window of each follower/following list (sorted sets scored by follow time).
 type FollowCache struct {
-	cli      redis.Cmdable
-	edgeTTL  time.Duration
-	countTTL time.Duration
-	listTTL  time.Duration
+	cli        redis.Cmdable
+	edgeTTL    time.Duration
+	countTTL   time.Duration
+	listTTL    time.Duration
+	listWindow int
+}
+
+// FollowListItem is one entry of a cached list: the other side of the edge
+// and the follow time in unix millis.
+type FollowListItem struct {
+	UUID       string
+	FollowedAt int64
 }
 
-type CachedFollowItem struct {
-	UserUUID  string `json:"user_uuid"`
-	CreatedAt int64  `json:"created_at"` // unix nano
-	ID        uint64 `json:"id"`
+// FollowListCursor points at the last item of the previous page.
+type FollowListCursor struct {
+	FollowedAt int64
+	UUID       string
 }
 
-func NewFollowCache(cli redis.Cmdable) *FollowCache {
+func NewFollowCache(cli redis.Cmdable, listWindow int, listTTL time.Duration) *FollowCache {
+	if listWindow <= 0 {
+		listWindow = defaultListWindow
+	}
+	if listTTL <= 0 {
+		listTTL = defaultListTTL
+	}
 	return &FollowCache{
-		cli:      cli,
-		edgeTTL:  defaultEdgeTTL,
-		countTTL: defaultCountTTL,
-		listTTL:  defaultListTTL,
+		cli:        cli,
+		edgeTTL:    defaultEdgeTTL,
+		countTTL:   defaultCountTTL,
+		listTTL:    listTTL,
+		listWindow: listWindow,
 	}
 }
 
+// ListWindow is the number of newest entries kept per cached list.
+func (c *FollowCache) ListWindow() int {
+	return c.listWindow
+}
+
 func (c *FollowCache) edgeKey(userUUID, targetUUID string) string {
 	return fmt.Sprintf("follow:edge:%s:%s", userUUID, targetUUID)
 }
@@ -70,10 +163,62 @@ func (c *FollowCache) SetEdge(ctx context.Context, userUUID, targetUUID string,
 	return c.cli.Set(ctx, c.edgeKey(userUUID, targetUUID), val, c.edgeTTL).Err()
 }
 
+// FollowEdge identifies a directed follow edge user -> target.
+type FollowEdge struct {
+	UserUUID   string
+	TargetUUID string
+}
+
+// GetEdges reads many edges with one MGET. Edges missing from the cache are absent from the result.
+func (c *FollowCache) GetEdges(ctx context.Context, edges []FollowEdge) (map[FollowEdge]bool, error) {
+	res := make(map[FollowEdge]bool, len(edges))
+	if len(edges) == 0 {
+		return res, nil
+	}
+	keys := make([]string, len(edges))
+	for i, e := range edges {
+		keys[i] = c.edgeKey(e.UserUUID, e.TargetUUID)
+	}
+	vals, err := c.cli.MGet(ctx, keys...).Result()
+	if err != nil {
+		return res, err
+	}
+	for i, v := range vals {
+		if s, ok := v.(string); ok {
+			res[edges[i]] = s == followingStatus
+		}
+	}
+	return res, nil
+}
+
+// SetEdges writes many edges in one pipeline.
+func (c *FollowCache) SetEdges(ctx context.Context, edges map[FollowEdge]bool) error {
+	if len(edges) == 0 {
+		return nil
+	}
+	pipe := c.cli.Pipeline()
+	for e, following := range edges {
+		val := notFollowingMark
+		if following {
+			val = followingStatus
+		}
+		pipe.Set(ctx, c.edgeKey(e.UserUUID, e.TargetUUID), val, c.edgeTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func (c *FollowCache) DeleteEdge(ctx context.Context, userUUID, targetUUID string) error {
 	return c.cli.Del(ctx, c.edgeKey(userUUID, targetUUID)).Err()
 }
 
+// ClaimRequestNotice reports whether a follow-request notification from userUUID to
+// targetUUID should be sent now; later claims in requestNoticeWindow return false.
+func (c *FollowCache) ClaimRequestNotice(ctx context.Context, userUUID, targetUUID string) (bool, error) {
+	key := fmt.Sprintf("follow:notice:request:%s:%s", userUUID, targetUUID)
+	return c.cli.SetNX(ctx, key, 1, requestNoticeWindow).Result()
+}
+
 // GetFollowerCount returns (count, found, error).
 func (c *FollowCache) GetFollowerCount(ctx context.Context, userUUID string) (int64, bool, error) {
 	return c.getCount(ctx, c.followerCountKey(userUUID))
@@ -118,42 +263,193 @@ func (c *FollowCache) InvalidateCounts(ctx context.Context, userUUIDs ...string)
 	_ = c.cli.Del(ctx, keys...).Err()
 }
 
+func (c *FollowCache) friendCountKey(userUUID string) string {
+	return fmt.Sprintf("follow:count:friend:%s", userUUID)
+}
+
+// GetFriendCount returns (count, found, error).
+func (c *FollowCache) GetFriendCount(ctx context.Context, userUUID string) (int64, bool, error) {
+	return c.getCount(ctx, c.friendCountKey(userUUID))
+}
+
+func (c *FollowCache) SetFriendCount(ctx context.Context, userUUID string, count int64) error {
+	return c.setCount(ctx, c.friendCountKey(userUUID), count)
+}
+
+// InvalidateFriendCounts deletes mutual-follow counters. A follow or unfollow may
+// create or break a mutual pair, so both sides are dropped instead of adjusted.
+func (c *FollowCache) InvalidateFriendCounts(ctx context.Context, userUUIDs ...string) {
+	keys := make([]string, 0, len(userUUIDs))
+	for _, u := range userUUIDs {
+		if u != "" {
+			keys = append(keys, c.friendCountKey(u))
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	_ = c.cli.Del(ctx, keys...).Err()
+}
+
+// CommonFollowers is the cached common-follower sample of a (viewer, target) pair.
+// Approx is true when the total was counted over a sample of the viewer's followings.
+type CommonFollowers struct {
+	Items  []FollowListItem `json:"items"`
+	Total  int64            `json:"total"`
+	Approx bool             `json:"approx"`
+	Gen    int64            `json:"gen"`
+	At     int64            `json:"at"`
+}
+
+// setCommonFollowersScript stores one target's sample in the viewer's hash, resetting
+// the hash first when it has grown to ARGV[3] fields.
+var setCommonFollowersScript = redis.NewScript(`
+if redis.call('HLEN', KEYS[1]) >= tonumber(ARGV[3]) then
+	redis.call('DEL', KEYS[1])
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+
+// commonFollowersKey is a hash of target -> sample for one viewer; the gen key next
+// to it is bumped by InvalidateCommonFollowers and samples from older gens are ignored.
+func (c *FollowCache) commonFollowersKey(viewerUUID string) string {
+	return fmt.Sprintf("follow:common:%s", viewerUUID)
+}
+
+// GetCommonFollowers returns (value, gen, found, error). gen is the viewer's current
+// generation and is passed back to SetCommonFollowers after a DB load.
+func (c *FollowCache) GetCommonFollowers(ctx context.Context, viewerUUID, targetUUID string) (*CommonFollowers, int64, bool, error) {
+	key := c.commonFollowersKey(viewerUUID)
+	pipe := c.cli.Pipeline()
+	genCmd := pipe.Get(ctx, genKey(key))
+	valCmd := pipe.HGet(ctx, key, targetUUID)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, false, err
+	}
+	gen, err := genCmd.Int64()
+	if err != nil && err != redis.Nil {
+		return nil, 0, false, err
+	}
+	b, err := valCmd.Bytes()
+	if err == redis.Nil {
+		return nil, gen, false, nil
+	}
+	if err != nil {
+		return nil, gen, false, err
+	}
+	var v CommonFollowers
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, gen, false, err
+	}
+	if v.Gen != gen || time.Since(time.UnixMilli(v.At)) > commonFollowersTTL {
+		return nil, gen, false, nil
+	}
+	return &v, gen, true, nil
+}
+
+// SetCommonFollowers stores a sample loaded while the viewer's generation was gen.
+func (c *FollowCache) SetCommonFollowers(ctx context.Context, viewerUUID, targetUUID string, gen int64, v *CommonFollowers) error {
+	v.Gen, v.At = gen, time.Now().UnixMilli()
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return setCommonFollowersScript.Run(ctx, c.cli, []string{c.commonFollowersKey(viewerUUID)},
+		targetUUID, b, commonFollowersMaxTargets, commonFollowersTTL.Milliseconds()).Err()
+}
+
+// InvalidateCommonFollowers drops every cached sample of the viewers. The gen key
+// outlives any sample it guards, so a load that raced the bump is never served.
+func (c *FollowCache) InvalidateCommonFollowers(ctx context.Context, viewerUUIDs ...string) {
+	pipe := c.cli.Pipeline()
+	for _, u := range viewerUUIDs {
+		if u == "" {
+			continue
+		}
+		key := genKey(c.commonFollowersKey(u))
+		pipe.Incr(ctx, key)
+		pipe.PExpire(ctx, key, commonFollowersTTL+genTTLExtra)
+	}
+	_, _ = pipe.Exec(ctx)
+}
+
 func (c *FollowCache) followerListKey(userUUID string) string {
-	return fmt.Sprintf("follow:list:follower:%s", userUUID)
+	return fmt.Sprintf("follow:zset:follower:%s", userUUID)
 }
 
 func (c *FollowCache) followingListKey(userUUID string) string {
-	return fmt.Sprintf("follow:list:following:%s", userUUID)
+	return fmt.Sprintf("follow:zset:following:%s", userUUID)
+}
+
+func genKey(listKey string) string {
+	return listKey + ":gen"
+}
+
+func (c *FollowCache) genTTL() time.Duration {
+	return c.listTTL + genTTLExtra
+}
+
+// FollowerListPage reads a followers page from the cached window; see listPage.
+func (c *FollowCache) FollowerListPage(ctx context.Context, targetUUID string, cursor *FollowListCursor, limit int) ([]FollowListItem, bool, error) {
+	return c.listPage(ctx, c.followerListKey(targetUUID), cursor, limit)
+}
+
+// FollowingListPage reads a followings page from the cached window; see listPage.
+func (c *FollowCache) FollowingListPage(ctx context.Context, userUUID string, cursor *FollowListCursor, limit int) ([]FollowListItem, bool, error) {
+	return c.listPage(ctx, c.followingListKey(userUUID), cursor, limit)
 }
 
-func (c *FollowCache) GetFollowerList(ctx context.Context, targetUUID string) ([]CachedFollowItem, bool, error) {
-	return c.getList(ctx, c.followerListKey(targetUUID))
+// FollowerListState reports whether the followers window of targetUUID is cached,
+// and its generation to pass to LoadFollowerList when it is not.
+func (c *FollowCache) FollowerListState(ctx context.Context, targetUUID string) (cached bool, gen int64, err error) {
+	return c.listState(ctx, c.followerListKey(targetUUID))
 }
 
-func (c *FollowCache) SetFollowerList(ctx context.Context, targetUUID string, items []CachedFollowItem) error {
-	return c.setList(ctx, c.followerListKey(targetUUID), items)
+// FollowingListState is FollowerListState for the followings window of userUUID.
+func (c *FollowCache) FollowingListState(ctx context.Context, userUUID string) (cached bool, gen int64, err error) {
+	return c.listState(ctx, c.followingListKey(userUUID))
 }
 
-func (c *FollowCache) GetFollowingList(ctx context.Context, userUUID string) ([]CachedFollowItem, bool, error) {
-	return c.getList(ctx, c.followingListKey(userUUID))
+// LoadFollowerList replaces the cached followers window with the newest items from DB.
+// complete tells whether items is the whole list (fewer than the window). gen is the
+// generation read before the DB query; loaded=false means a write happened meanwhile
+// and the window was discarded.
+func (c *FollowCache) LoadFollowerList(ctx context.Context, targetUUID string, gen int64, items []FollowListItem, complete bool) (bool, error) {
+	return c.loadList(ctx, c.followerListKey(targetUUID), gen, items, complete)
 }
 
-func (c *FollowCache) SetFollowingList(ctx context.Context, userUUID string, items []CachedFollowItem) error {
-	return c.setList(ctx, c.followingListKey(userUUID), items)
+// LoadFollowingList replaces the cached followings window with the newest items from DB.
+func (c *FollowCache) LoadFollowingList(ctx context.Context, userUUID string, gen int64, items []FollowListItem, complete bool) (bool, error) {
+	return c.loadList(ctx, c.followingListKey(userUUID), gen, items, complete)
+}
+
+// AddFollow inserts the edge into both cached lists, only where a list is already cached.
+func (c *FollowCache) AddFollow(ctx context.Context, userUUID, targetUUID string, followedAt int64) {
+	_ = c.addToList(ctx, c.followerListKey(targetUUID), userUUID, followedAt)
+	_ = c.addToList(ctx, c.followingListKey(userUUID), targetUUID, followedAt)
+}
+
+// RemoveFollow removes the edge from both cached lists.
+func (c *FollowCache) RemoveFollow(ctx context.Context, userUUID, targetUUID string) {
+	_ = c.removeFromList(ctx, c.followerListKey(targetUUID), userUUID)
+	_ = c.removeFromList(ctx, c.followingListKey(userUUID), targetUUID)
 }
 
 func (c *FollowCache) InvalidateLists(ctx context.Context, userUUIDs ...string) {
-	keys := make([]string, 0, len(userUUIDs)*2)
+	pipe := c.cli.Pipeline()
 	for _, u := range userUUIDs {
 		if u == "" {
 			continue
 		}
-		keys = append(keys, c.followerListKey(u), c.followingListKey(u))
-	}
-	if len(keys) == 0 {
-		return
+		for _, key := range []string{c.followerListKey(u), c.followingListKey(u)} {
+			pipe.Incr(ctx, genKey(key))
+			pipe.PExpire(ctx, genKey(key), c.genTTL())
+			pipe.Del(ctx, key)
+		}
 	}
-	_ = c.cli.Del(ctx, keys...).Err()
+	_, _ = pipe.Exec(ctx)
 }
 
 func (c *FollowCache) getCount(ctx context.Context, key string) (int64, bool, error) {
@@ -194,23 +490,89 @@ func (c *FollowCache) incrCount(ctx context.Context, key string, delta int64) er
 	return nil
 }
 
-func (c *FollowCache) getList(ctx context.Context, key string) ([]CachedFollowItem, bool, error) {
-	val, err := c.cli.Get(ctx, key).Result()
+// listPage serves a page strictly after cursor (nil for the first page).
+// found=false means the caller must query DB: the list is not cached, the cursor
+// item is no longer in the window, or the page runs past a partial window.
+func (c *FollowCache) listPage(ctx context.Context, key string, cursor *FollowListCursor, limit int) ([]FollowListItem, bool, error) {
+	start := int64(0)
+	if cursor != nil {
+		score, err := c.cli.ZScore(ctx, key, cursor.UUID).Result()
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if int64(score) != cursor.FollowedAt {
+			return nil, false, nil
+		}
+		rank, err := c.cli.ZRevRank(ctx, key, cursor.UUID).Result()
+		if err != nil {
+			return nil, false, err
+		}
+		start = rank + 1
+	}
+	// One extra element reveals whether the complete marker follows the page.
+	zs, err := c.cli.ZRevRangeWithScores(ctx, key, start, start+int64(limit)).Result()
 	if err != nil {
 		return nil, false, err
 	}
-	var items []CachedFollowItem
-	if err := json.Unmarshal([]byte(val), &items); err != nil {
-		_ = c.cli.Del(ctx, key).Err()
-		return nil, false, err
+	if len(zs) == 0 && cursor == nil {
+		// key missing (an empty list still holds the complete marker)
+		return nil, false, nil
+	}
+	items := make([]FollowListItem, 0, limit)
+	complete := false
+	for _, z := range zs {
+		member, _ := z.Member.(string)
+		if member == listCompleteMarker {
+			complete = true
+			break
+		}
+		if len(items) < limit {
+			items = append(items, FollowListItem{UUID: member, FollowedAt: int64(z.Score)})
+		}
+	}
+	if len(items) < limit && !complete {
+		return nil, false, nil
 	}
 	return items, true, nil
 }
 
-func (c *FollowCache) setList(ctx context.Context, key string, items []CachedFollowItem) error {
-	b, err := json.Marshal(items)
-	if err != nil {
-		return err
+func (c *FollowCache) listState(ctx context.Context, key string) (bool, int64, error) {
+	pipe := c.cli.Pipeline()
+	exists := pipe.Exists(ctx, key)
+	gen := pipe.Get(ctx, genKey(key))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return false, 0, err
+	}
+	if exists.Val() > 0 {
+		return true, 0, nil
+	}
+	n, err := gen.Int64()
+	if err == redis.Nil {
+		return false, 0, nil
+	}
+	return false, n, err
+}
+
+func (c *FollowCache) loadList(ctx context.Context, key string, gen int64, items []FollowListItem, complete bool) (bool, error) {
+	args := make([]interface{}, 0, 3+2*len(items))
+	args = append(args, gen, c.listTTL.Milliseconds(), "0")
+	if complete {
+		args[2] = "1"
 	}
-	return c.cli.Set(ctx, key, b, c.listTTL).Err()
+	for _, item := range items {
+		args = append(args, item.FollowedAt, item.UUID)
+	}
+	n, err := loadListScript.Run(ctx, c.cli, []string{key, genKey(key)}, args...).Int()
+	return n == 1, err
+}
+
+func (c *FollowCache) addToList(ctx context.Context, key, member string, followedAt int64) error {
+	return addFollowScript.Run(ctx, c.cli, []string{key, genKey(key)}, followedAt, member, c.listWindow, c.genTTL().Milliseconds()).Err()
+}
+
+func (c *FollowCache) removeFromList(ctx context.Context, key, member string) error {
+	return removeFollowScript.Run(ctx, c.cli, []string{key, genKey(key)}, member, c.genTTL().Milliseconds()).Err()
 }
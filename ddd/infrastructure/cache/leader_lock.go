@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireLeaderScript takes the lease when it is free and extends it when this
+// owner already holds it. Returns 1 when the caller is the leader.
+var acquireLeaderScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return 1
+end
+return 0
+`)
+
+// releaseLeaderScript deletes the lease only if this owner still holds it.
+var releaseLeaderScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// LeaderLock is a Redis lease held by at most one replica at a time, used by
+// background loops that must not run concurrently across replicas. The holder
+// calls Acquire before each round to extend the lease; if it stops doing so
+// the lease expires after ttl and another replica takes over.
+type LeaderLock struct {
+	cli   redis.Cmdable
+	key   string
+	owner string
+	ttl   time.Duration
+}
+
+func NewLeaderLock(cli redis.Cmdable, name string, ttl time.Duration) *LeaderLock {
+	host, _ := os.Hostname()
+	return &LeaderLock{
+		cli:   cli,
+		key:   fmt.Sprintf("leader:%s", name),
+		owner: fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.NewString()),
+		ttl:   ttl,
+	}
+}
+
+// Acquire takes or extends the lease and reports whether this replica is the leader.
+func (l *LeaderLock) Acquire(ctx context.Context) (bool, error) {
+	n, err := acquireLeaderScript.Run(ctx, l.cli, []string{l.key}, l.owner, l.ttl.Milliseconds()).Int()
+	return n == 1, err
+}
+
+// Release gives up the lease so another replica can take over without waiting for expiry.
+func (l *LeaderLock) Release(ctx context.Context) error {
+	return releaseLeaderScript.Run(ctx, l.cli, []string{l.key}, l.owner).Err()
+}
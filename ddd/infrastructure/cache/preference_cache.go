@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultPreferenceTTL = 30 * time.Minute
+
+// PreferenceCache 缓存用户显式设置过的偏好项（key -> JSON 值），无设置的用户缓存空 map 以免穿透
+type PreferenceCache struct {
+	cli redis.Cmdable
+	ttl time.Duration
+}
+
+func NewPreferenceCache(cli redis.Cmdable, ttl time.Duration) *PreferenceCache {
+	if ttl <= 0 {
+		ttl = defaultPreferenceTTL
+	}
+	return &PreferenceCache{cli: cli, ttl: ttl}
+}
+
+func (c *PreferenceCache) key(userUUID string) string {
+	return fmt.Sprintf("user:pref:%s", userUUID)
+}
+
+// MGet 批量读取，返回命中的用户偏好；未命中的用户不在结果中
+func (c *PreferenceCache) MGet(ctx context.Context, userUUIDs []string) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string, len(userUUIDs))
+	if len(userUUIDs) == 0 {
+		return result, nil
+	}
+	keys := make([]string, len(userUUIDs))
+	for i, u := range userUUIDs {
+		keys[i] = c.key(u)
+	}
+	vals, err := c.cli.MGet(ctx, keys...).Result()
+	if err != nil {
+		return result, err
+	}
+	for i, v := range vals {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var prefs map[string]string
+		if err := json.Unmarshal([]byte(raw), &prefs); err != nil {
+			_ = c.cli.Del(ctx, keys[i]).Err()
+			continue
+		}
+		result[userUUIDs[i]] = prefs
+	}
+	return result, nil
+}
+
+func (c *PreferenceCache) Set(ctx context.Context, userUUID string, prefs map[string]string) error {
+	if prefs == nil {
+		prefs = map[string]string{}
+	}
+	b, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+	return c.cli.Set(ctx, c.key(userUUID), b, c.ttl).Err()
+}
+
+func (c *PreferenceCache) Delete(ctx context.Context, userUUID string) error {
+	return c.cli.Del(ctx, c.key(userUUID)).Err()
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultRecommendTTL = 2 * time.Hour
+	popularRecommendKey = "recommend:popular"
+)
+
+// RecommendEntry is one precomputed suggestion.
+type RecommendEntry struct {
+	UUID   string `json:"uuid"`
+	Score  int64  `json:"score"`
+	Reason string `json:"reason"`
+}
+
+// RecommendCache stores the precomputed who-to-follow list of each user and the
+// shared list of popular accounts used as fallback. Entries are a candidate pool:
+// readers still filter them against the current follow and block state.
+type RecommendCache struct {
+	cli redis.Cmdable
+	ttl time.Duration
+}
+
+func NewRecommendCache(cli redis.Cmdable, ttl time.Duration) *RecommendCache {
+	if ttl <= 0 {
+		ttl = defaultRecommendTTL
+	}
+	return &RecommendCache{cli: cli, ttl: ttl}
+}
+
+func (c *RecommendCache) key(userUUID string) string {
+	return fmt.Sprintf("recommend:user:%s", userUUID)
+}
+
+// Get returns the cached list of the user; ok is false on a miss.
+func (c *RecommendCache) Get(ctx context.Context, userUUID string) ([]RecommendEntry, bool, error) {
+	return c.get(ctx, c.key(userUUID))
+}
+
+func (c *RecommendCache) Set(ctx context.Context, userUUID string, entries []RecommendEntry) error {
+	return c.set(ctx, c.key(userUUID), entries)
+}
+
+func (c *RecommendCache) GetPopular(ctx context.Context) ([]RecommendEntry, bool, error) {
+	return c.get(ctx, popularRecommendKey)
+}
+
+func (c *RecommendCache) SetPopular(ctx context.Context, entries []RecommendEntry) error {
+	return c.set(ctx, popularRecommendKey, entries)
+}
+
+func (c *RecommendCache) get(ctx context.Context, key string) ([]RecommendEntry, bool, error) {
+	raw, err := c.cli.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var entries []RecommendEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		_ = c.cli.Del(ctx, key).Err()
+		return nil, false, nil
+	}
+	return entries, true, nil
+}
+
+// set stores an empty list too, so users without any candidate are not rebuilt on every read.
+func (c *RecommendCache) set(ctx context.Context, key string, entries []RecommendEntry) error {
+	if entries == nil {
+		entries = []RecommendEntry{}
+	}
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return c.cli.Set(ctx, key, b, c.ttl).Err()
+}
@@ -51,26 +51,31 @@ func (r *RedisRevocationStore) DeleteRefreshToken(ctx context.Context, userUUID
 	return r.cli.Del(ctx, refreshKey(userUUID, tokenHash)).Err()
 }
 
-// DeleteAllRefreshTokens 删除指定用户的所有刷新令牌记录
-func (r *RedisRevocationStore) DeleteAllRefreshTokens(ctx context.Context, userUUID string) error {
+// DeleteAllRefreshTokens 删除指定用户的所有刷新令牌记录，返回实际删除的条数
+func (r *RedisRevocationStore) DeleteAllRefreshTokens(ctx context.Context, userUUID string) (int64, error) {
 	pattern := fmt.Sprintf("auth:refresh:%s:*", userUUID)
-	var cursor uint64
+	var (
+		cursor  uint64
+		deleted int64
+	)
 	for {
 		keys, nextCursor, err := r.cli.Scan(ctx, cursor, pattern, 100).Result()
 		if err != nil {
-			return err
+			return deleted, err
 		}
 		if len(keys) > 0 {
-			if err := r.cli.Del(ctx, keys...).Err(); err != nil {
-				return err
+			n, err := r.cli.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, err
 			}
+			deleted += n
 		}
 		cursor = nextCursor
 		if cursor == 0 {
 			break
 		}
 	}
-	return nil
+	return deleted, nil
 }
 
 func (r *RedisRevocationStore) ExistsRefreshToken(ctx context.Context, userUUID string, tokenHash string) (bool, error) {
@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultUserCacheTTL    = 10 * time.Minute
+	defaultUserNegativeTTL = time.Minute
+	// userNegativeMarker 不存在用户的占位值，防止不存在的 UUID 反复穿透到 MySQL
+	userNegativeMarker = "-"
+	// userTombstonePrefix 失效墓碑前缀，后接写入后的版本号；读路径视为未命中
+	userTombstonePrefix = "!"
+	// userTTLJitter TTL 随机上浮比例，避免同一批回填的 key 同时过期
+	userTTLJitter = 0.1
+)
+
+// UnknownVersion 写入后的版本未知（如删除）时使用，墓碑过期前拒绝所有回填
+const UnknownVersion uint64 = math.MaxUint64
+
+// fillUserScript 按版本条件回填：KEYS 为资料 key，ARGV 每个 key 依次为 值、版本（占位为 -1）、TTL 毫秒。
+// 已有墓碑时只接受不低于墓碑版本的值，已有资料时只接受不低于其版本的值，
+// 避免失效前读到的旧行在失效之后写回缓存
+var fillUserScript = redis.NewScript(`
+local n = 0
+for i, key in ipairs(KEYS) do
+	local value, version, ttl = ARGV[3*i-2], tonumber(ARGV[3*i-1]), ARGV[3*i]
+	local cur = redis.call('GET', key)
+	local ok = true
+	if cur and string.sub(cur, 1, 1) == '!' then
+		ok = version >= tonumber(string.sub(cur, 2))
+	elseif cur and cur ~= '-' then
+		local parsed, user = pcall(cjson.decode, cur)
+		ok = not parsed or type(user) ~= 'table' or version >= (tonumber(user['version']) or 0)
+	end
+	if ok then
+		redis.call('SET', key, value, 'PX', ttl)
+		n = n + 1
+	end
+end
+return n
+`)
+
+var userCacheHits, userCacheNegativeHits, userCacheMisses atomic.Uint64
+
+// UserCacheCounters 进程启动以来的用户资料缓存命中计数；对外展示由应用层组装为 dto.UserCacheStatsDto
+func UserCacheCounters() (hits, negativeHits, misses uint64) {
+	return userCacheHits.Load(), userCacheNegativeHits.Load(), userCacheMisses.Load()
+}
+
+// CachedUser 缓存中的用户资料快照，不含密码等敏感字段，只用于读路径
+type CachedUser struct {
+	Id             uint64    `json:"id"`
+	UserUUID       string    `json:"user_uuid"`
+	Account        string    `json:"account"`
+	Nickname       string    `json:"nickname"`
+	AvatarUrl      string    `json:"avatar_url"`
+	Description    string    `json:"description"`
+	CoverUrl       string    `json:"cover_url"`
+	Handle         string    `json:"handle,omitempty"`
+	AvatarVariants string    `json:"avatar_variants,omitempty"`
+	Version        uint64    `json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// UserCache 用户资料缓存，每个用户一个 key；不存在的用户写入短 TTL 的占位值
+type UserCache struct {
+	cli         redis.Cmdable
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+func NewUserCache(cli redis.Cmdable, ttl, negativeTTL time.Duration) *UserCache {
+	if ttl <= 0 {
+		ttl = defaultUserCacheTTL
+	}
+	if negativeTTL <= 0 {
+		negativeTTL = defaultUserNegativeTTL
+	}
+	return &UserCache{cli: cli, ttl: ttl, negativeTTL: negativeTTL}
+}
+
+func (c *UserCache) key(userUUID string) string {
+	return fmt.Sprintf("user:profile:%s", userUUID)
+}
+
+func (c *UserCache) jittered(ttl time.Duration) time.Duration {
+	return ttl + time.Duration(rand.Int63n(int64(float64(ttl)*userTTLJitter)+1))
+}
+
+// MGet 批量读取。命中的用户在 found 中；命中占位值（确认不存在）的用户在 absent 中；其余（含墓碑）为未命中
+func (c *UserCache) MGet(ctx context.Context, userUUIDs []string) (found map[string]*CachedUser, absent map[string]bool, err error) {
+	found = make(map[string]*CachedUser, len(userUUIDs))
+	absent = make(map[string]bool)
+	if len(userUUIDs) == 0 {
+		return found, absent, nil
+	}
+	keys := make([]string, len(userUUIDs))
+	for i, u := range userUUIDs {
+		keys[i] = c.key(u)
+	}
+	vals, err := c.cli.MGet(ctx, keys...).Result()
+	if err != nil {
+		userCacheMisses.Add(uint64(len(userUUIDs)))
+		return found, absent, err
+	}
+	for i, v := range vals {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if raw == userNegativeMarker {
+			absent[userUUIDs[i]] = true
+			continue
+		}
+		if strings.HasPrefix(raw, userTombstonePrefix) {
+			continue
+		}
+		var user CachedUser
+		if err := json.Unmarshal([]byte(raw), &user); err != nil {
+			_ = c.cli.Del(ctx, keys[i]).Err()
+			continue
+		}
+		found[userUUIDs[i]] = &user
+	}
+	userCacheHits.Add(uint64(len(found)))
+	userCacheNegativeHits.Add(uint64(len(absent)))
+	userCacheMisses.Add(uint64(len(userUUIDs) - len(found) - len(absent)))
+	return found, absent, nil
+}
+
+// MSet 批量回填资料与不存在占位，一次脚本调用；版本低于墓碑或现有资料的条目被跳过
+func (c *UserCache) MSet(ctx context.Context, users []*CachedUser, absent []string) error {
+	if len(users) == 0 && len(absent) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(users)+len(absent))
+	args := make([]interface{}, 0, 3*(len(users)+len(absent)))
+	for _, user := range users {
+		b, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, c.key(user.UserUUID))
+		args = append(args, b, strconv.FormatUint(user.Version, 10), c.jittered(c.ttl).Milliseconds())
+	}
+	for _, u := range absent {
+		keys = append(keys, c.key(u))
+		args = append(args, userNegativeMarker, -1, c.jittered(c.negativeTTL).Milliseconds())
+	}
+	return fillUserScript.Run(ctx, c.cli, keys, args...).Err()
+}
+
+// Invalidate 写操作提交后调用：以墓碑替换缓存值，记录写入后的版本。
+// 墓碑存活 negativeTTL，期间只有读到该版本及以上的回源结果才能回填
+func (c *UserCache) Invalidate(ctx context.Context, userUUID string, version uint64) error {
+	tombstone := userTombstonePrefix + strconv.FormatUint(version, 10)
+	return c.cli.Set(ctx, c.key(userUUID), tombstone, c.negativeTTL).Err()
+}
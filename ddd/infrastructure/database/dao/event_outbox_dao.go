@@ -0,0 +1,93 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+)
+
+type EventOutboxDao struct {
+	db *gorm.DB
+}
+
+func NewEventOutboxDao() *EventOutboxDao {
+	return &EventOutboxDao{db: resource.DefaultMysqlResource().MainDB()}
+}
+
+// insertOutbox 在调用方的事务内写入待发布事件
+func insertOutbox(tx *gorm.DB, events []*po.EventOutboxPo) error {
+	if len(events) == 0 {
+		return nil
+	}
+	for _, ev := range events {
+		ev.Status = vo.OutboxStatusPending
+	}
+	return tx.Create(events).Error
+}
+
+// withOutbox 无事件时直接执行 fn，否则将 fn 与事件写入放在同一事务中
+func withOutbox(ctx context.Context, db *gorm.DB, events []*po.EventOutboxPo, fn func(tx *gorm.DB) error) error {
+	if len(events) == 0 {
+		return fn(db.WithContext(ctx))
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return insertOutbox(tx, events)
+	})
+}
+
+// QueryPending 按写入顺序读取待发布事件
+func (d *EventOutboxDao) QueryPending(ctx context.Context, limit int) ([]*po.EventOutboxPo, error) {
+	var list []*po.EventOutboxPo
+	err := d.db.WithContext(ctx).
+		Where("status = ?", vo.OutboxStatusPending).
+		Order("id ASC").Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+func (d *EventOutboxDao) MarkSent(ctx context.Context, id uint64, now time.Time) error {
+	return d.db.WithContext(ctx).Model(&po.EventOutboxPo{}).
+		Where("id = ? AND status = ?", id, vo.OutboxStatusPending).
+		Updates(map[string]interface{}{"status": vo.OutboxStatusSent, "sent_at": now, "updated_at": now}).Error
+}
+
+// MarkAttempt 记录一次投递失败；status 为 failed 时不再重试
+func (d *EventOutboxDao) MarkAttempt(ctx context.Context, id uint64, attempts int, status, errMsg string) error {
+	return d.db.WithContext(ctx).Model(&po.EventOutboxPo{}).
+		Where("id = ? AND status = ?", id, vo.OutboxStatusPending).
+		Updates(map[string]interface{}{"attempts": attempts, "status": status, "error_msg": errMsg, "updated_at": time.Now()}).Error
+}
+
+// CountFailed 统计放弃投递的事件数
+func (d *EventOutboxDao) CountFailed(ctx context.Context) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&po.EventOutboxPo{}).
+		Where("status = ?", vo.OutboxStatusFailed).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteFailedBefore 删除最后一次尝试早于 before 的 failed 事件，单次最多 limit 条
+func (d *EventOutboxDao) DeleteFailedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	res := d.db.WithContext(ctx).
+		Where("status = ? AND updated_at < ?", vo.OutboxStatusFailed, before).
+		Limit(limit).
+		Delete(&po.EventOutboxPo{})
+	return res.RowsAffected, res.Error
+}
+
+// DeleteSentBefore 删除早于 before 已发布的事件，单次最多 limit 条
+func (d *EventOutboxDao) DeleteSentBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	res := d.db.WithContext(ctx).
+		Where("status = ? AND sent_at < ?", vo.OutboxStatusSent, before).
+		Limit(limit).
+		Delete(&po.EventOutboxPo{})
+	return res.RowsAffected, res.Error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"time"
+	"user-service/ddd/domain/vo"
 	"user-service/ddd/infrastructure/database/po"
 	"user-service/internal/resource"
 	"user-service/pkg/logger"
@@ -24,91 +25,386 @@ func (d *FollowDao) Create(ctx context.Context, follow *po.FollowPo) error {
 	return d.db.WithContext(ctx).Create(follow).Error
 }
 
-func (d *FollowDao) UpdateStatus(ctx context.Context, userUUID, targetUUID string, status string) error {
-	return d.db.WithContext(ctx).Model(&po.FollowPo{}).
-		Where("user_uuid = ? AND target_uuid = ?", userUUID, targetUUID).
-		Updates(map[string]interface{}{"status": status, "updated_at": time.Now()}).Error
-}
-
 func (d *FollowDao) Exists(ctx context.Context, userUUID, targetUUID string) (bool, error) {
 	var count int64
 	err := d.db.WithContext(ctx).Model(&po.FollowPo{}).
-		Where("user_uuid = ? AND target_uuid = ? AND status = ?", userUUID, targetUUID, "Following").
+		Where("user_uuid = ? AND target_uuid = ? AND status = ?", userUUID, targetUUID, vo.FollowStateFollowing).
 		Count(&count).Error
 	return count > 0, err
 }
 
-func (d *FollowDao) QueryFollowers(ctx context.Context, targetUUID string, cursorTime time.Time, cursorID uint64, limit int) ([]*po.FollowPo, int64, error) {
+// errFollowStateChanged rolls back TransitStatus when the row is not in the expected state.
+var errFollowStateChanged = errors.New("follow state changed")
+
+// ErrFollowBlocked rolls back a follow write because either user blocks the other.
+var ErrFollowBlocked = errors.New("follow blocked")
+
+// followWrite runs fn with the outbox insert. Writes that leave an active or pending
+// edge first re-check blocks in the same transaction; see checkNotBlocked.
+func (d *FollowDao) followWrite(ctx context.Context, userUUID, targetUUID, status string, events []*po.EventOutboxPo, fn func(tx *gorm.DB) error) error {
+	if status != vo.FollowStateFollowing && status != vo.FollowStatePending {
+		return withOutbox(ctx, d.db, events, fn)
+	}
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkNotBlocked(tx, userUUID, targetUUID); err != nil {
+			return err
+		}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return insertOutbox(tx, events)
+	})
+}
+
+// checkNotBlocked reads both block keys with a shared lock. An existing row is
+// share-locked and a missing one is gap-locked on uk_user_target_kind, so a block
+// inserted concurrently waits until this follow commits, and the unfollow that the
+// block runs afterwards sees the new edge. Relies on REPEATABLE READ (gap locks).
+func checkNotBlocked(tx *gorm.DB, userUUID, targetUUID string) error {
+	for _, pair := range [][2]string{{userUUID, targetUUID}, {targetUUID, userUUID}} {
+		var ids []uint64
+		err := tx.Model(&po.UserBlockPo{}).
+			Clauses(clause.Locking{Strength: "SHARE"}).
+			Where("user_uuid = ? AND target_uuid = ? AND kind = ?", pair[0], pair[1], vo.BlockKindBlock).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			return ErrFollowBlocked
+		}
+	}
+	return nil
+}
+
+// TransitStatus moves the edge from one status to another; ok is false (and events
+// are not written) when the edge is not currently in the from status.
+func (d *FollowDao) TransitStatus(ctx context.Context, userUUID, targetUUID, from, to string, events ...*po.EventOutboxPo) (bool, error) {
+	now := time.Now()
+	updates := map[string]interface{}{"status": to, "updated_at": now}
+	if to == vo.FollowStatePending {
+		// A new request starts over: it is listed by this request time, which also
+		// becomes the follow time once approved. A re-follow keeps the original time.
+		updates["created_at"] = now
+	}
+	err := d.followWrite(ctx, userUUID, targetUUID, to, events, func(tx *gorm.DB) error {
+		res := tx.Model(&po.FollowPo{}).
+			Where("user_uuid = ? AND target_uuid = ? AND status = ?", userUUID, targetUUID, from).
+			Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errFollowStateChanged
+		}
+		return nil
+	})
+	if errors.Is(err, errFollowStateChanged) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// RemoveFollower unfollows followerUUID from userUUID on userUUID's behalf and writes
+// the audit row with the events in the same transaction; ok is false (nothing is
+// written) when followerUUID is not currently following userUUID.
+func (d *FollowDao) RemoveFollower(ctx context.Context, followerUUID, userUUID string, audit *po.RelationAuditPo, events ...*po.EventOutboxPo) (bool, error) {
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&po.FollowPo{}).
+			Where("user_uuid = ? AND target_uuid = ? AND status = ?", followerUUID, userUUID, vo.FollowStateFollowing).
+			Updates(map[string]interface{}{"status": vo.FollowStateUnfollowed, "updated_at": time.Now()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errFollowStateChanged
+		}
+		if err := tx.Create(audit).Error; err != nil {
+			return err
+		}
+		return insertOutbox(tx, events)
+	})
+	if errors.Is(err, errFollowStateChanged) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// QueryFollowRequests pages pending follow requests to the target, newest request first.
+func (d *FollowDao) QueryFollowRequests(ctx context.Context, targetUUID string, cursorTime time.Time, cursorUUID string, limit int) ([]*po.FollowPo, int64, error) {
 	var list []*po.FollowPo
-	q := d.db.WithContext(ctx).Model(&po.FollowPo{}).Where("target_uuid = ? AND status = ?", targetUUID, "Following")
+	q := d.db.WithContext(ctx).Model(&po.FollowPo{}).Where("target_uuid = ? AND status = ?", targetUUID, vo.FollowStatePending)
 	var total int64
 	if err := q.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 	if !cursorTime.IsZero() {
-		q = q.Where("(created_at < ?) OR (created_at = ? AND id < ?)", cursorTime, cursorTime, cursorID)
+		q = q.Where("(created_at < ?) OR (created_at = ? AND user_uuid < ?)", cursorTime, cursorTime, cursorUUID)
 	}
 	if limit > 0 {
 		q = q.Limit(limit)
 	}
-	if err := q.Order("created_at DESC, id DESC").Find(&list).Error; err != nil {
+	if err := q.Order("created_at DESC, user_uuid DESC").Find(&list).Error; err != nil {
 		return nil, 0, err
 	}
 	return list, total, nil
 }
 
-func (d *FollowDao) QueryFollowings(ctx context.Context, userUUID string, cursorTime time.Time, cursorID uint64, limit int) ([]*po.FollowPo, int64, error) {
+// QueryFollowers pages active followers newest first, ties broken by user_uuid DESC
+// (the same order as the cached follower list).
+func (d *FollowDao) QueryFollowers(ctx context.Context, targetUUID string, cursorTime time.Time, cursorUUID string, limit int) ([]*po.FollowPo, int64, error) {
 	var list []*po.FollowPo
-	q := d.db.WithContext(ctx).Model(&po.FollowPo{}).Where("user_uuid = ? AND status = ?", userUUID, "Following")
+	q := d.db.WithContext(ctx).Model(&po.FollowPo{}).Where("target_uuid = ? AND status = ?", targetUUID, vo.FollowStateFollowing)
 	var total int64
 	if err := q.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 	if !cursorTime.IsZero() {
-		q = q.Where("(created_at < ?) OR (created_at = ? AND id < ?)", cursorTime, cursorTime, cursorID)
+		q = q.Where("(created_at < ?) OR (created_at = ? AND user_uuid < ?)", cursorTime, cursorTime, cursorUUID)
 	}
 	if limit > 0 {
 		q = q.Limit(limit)
 	}
-	if err := q.Order("created_at DESC, id DESC").Find(&list).Error; err != nil {
+	if err := q.Order("created_at DESC, user_uuid DESC").Find(&list).Error; err != nil {
 		return nil, 0, err
 	}
 	return list, total, nil
 }
 
-func (d *FollowDao) Upsert(ctx context.Context, follow *po.FollowPo) error {
+// QueryFollowings pages active followings newest first, ties broken by target_uuid DESC.
+func (d *FollowDao) QueryFollowings(ctx context.Context, userUUID string, cursorTime time.Time, cursorUUID string, limit int) ([]*po.FollowPo, int64, error) {
+	var list []*po.FollowPo
+	q := d.db.WithContext(ctx).Model(&po.FollowPo{}).Where("user_uuid = ? AND status = ?", userUUID, vo.FollowStateFollowing)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if !cursorTime.IsZero() {
+		q = q.Where("(created_at < ?) OR (created_at = ? AND target_uuid < ?)", cursorTime, cursorTime, cursorUUID)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Order("created_at DESC, target_uuid DESC").Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// friendQuery selects the user's outgoing follows (alias a) whose reverse edge is also active.
+func (d *FollowDao) friendQuery(ctx context.Context, userUUID string) *gorm.DB {
+	return d.db.WithContext(ctx).Table("user_follow AS a").
+		Joins("JOIN user_follow AS b ON b.user_uuid = a.target_uuid AND b.target_uuid = a.user_uuid AND b.status = ?", vo.FollowStateFollowing).
+		Where("a.user_uuid = ? AND a.status = ?", userUUID, vo.FollowStateFollowing)
+}
+
+// QueryFriends pages mutual follows of the user by the user's own follow time, newest
+// first, ties broken by target_uuid DESC. Rows are the user's outgoing edges.
+// The total is not counted per page; callers use CountFriends, which is cached.
+func (d *FollowDao) QueryFriends(ctx context.Context, userUUID string, cursorTime time.Time, cursorUUID string, limit int) ([]*po.FollowPo, error) {
+	var list []*po.FollowPo
+	q := d.friendQuery(ctx, userUUID)
+	if !cursorTime.IsZero() {
+		q = q.Where("(a.created_at < ?) OR (a.created_at = ? AND a.target_uuid < ?)", cursorTime, cursorTime, cursorUUID)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	err := q.Select("a.*").Order("a.created_at DESC, a.target_uuid DESC").Find(&list).Error
+	return list, err
+}
+
+// CountFriends returns the number of mutual follows of the user.
+func (d *FollowDao) CountFriends(ctx context.Context, userUUID string) (int64, error) {
+	var count int64
+	err := d.friendQuery(ctx, userUUID).Count(&count).Error
+	return count, err
+}
+
+// QueryCommonFollowers returns followers of target that the viewer follows, most recent
+// follower of target first, capped at limit, plus their total. Only the viewer's
+// sampleSize most recent followings drive the join and each probe hits the
+// (user_uuid, target_uuid) unique key, so the cost is bounded by sampleSize whatever
+// the size of either account; the total only counts matches within that sample.
+func (d *FollowDao) QueryCommonFollowers(ctx context.Context, viewerUUID, targetUUID string, sampleSize, limit int) ([]*po.FollowPo, int64, error) {
+	var list []*po.FollowPo
+	sample := d.db.WithContext(ctx).Model(&po.FollowPo{}).Select("target_uuid").
+		Where("user_uuid = ? AND status = ?", viewerUUID, vo.FollowStateFollowing).
+		Order("created_at DESC").Limit(sampleSize)
+	q := d.db.WithContext(ctx).Table("(?) AS a", sample).
+		Joins("JOIN user_follow AS b ON b.user_uuid = a.target_uuid AND b.target_uuid = ? AND b.status = ?", targetUUID, vo.FollowStateFollowing)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return list, 0, nil
+	}
+	if err := q.Select("b.*").Order("b.created_at DESC, b.user_uuid DESC").Limit(limit).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// ScoredUser is a recommendation candidate with its score.
+type ScoredUser struct {
+	UserUUID string
+	Score    int64
+}
+
+// QueryFollowedByFollowings scores accounts followed by the user's followings by how
+// many of them follow each account, highest first. Only the user's sampleSize most
+// recent followings are expanded so heavy followers stay bounded. Followings who hide
+// their following list are not expanded, so neither the accounts nor the scores reveal
+// whom they follow. Accounts the user already follows or has requested to follow, and
+// the user itself, are left out.
+func (d *FollowDao) QueryFollowedByFollowings(ctx context.Context, userUUID string, sampleSize, limit int) ([]ScoredUser, error) {
+	var rows []ScoredUser
+	sample := d.db.WithContext(ctx).Table("user_follow AS f").Select("f.target_uuid").
+		Joins("LEFT JOIN user_privacy_setting AS p ON p.user_uuid = f.target_uuid AND p.is_deleted = 0").
+		Where("f.user_uuid = ? AND f.status = ?", userUUID, vo.FollowStateFollowing).
+		Where("p.id IS NULL OR p.hide_following_list = 0").
+		Order("f.created_at DESC").Limit(sampleSize)
+	err := d.db.WithContext(ctx).Table("(?) AS a", sample).
+		Joins("JOIN user_follow AS b ON b.user_uuid = a.target_uuid AND b.status = ?", vo.FollowStateFollowing).
+		Joins("LEFT JOIN user_follow AS c ON c.user_uuid = ? AND c.target_uuid = b.target_uuid AND c.status IN ?",
+			userUUID, []string{vo.FollowStateFollowing, vo.FollowStatePending}).
+		Where("b.target_uuid <> ? AND c.id IS NULL", userUUID).
+		Select("b.target_uuid AS user_uuid, COUNT(*) AS score").
+		Group("b.target_uuid").
+		Order("score DESC, MAX(b.created_at) DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// QueryMostFollowed returns the accounts with the most active followers, highest first.
+// It scans the whole table and is meant for periodic background jobs only.
+func (d *FollowDao) QueryMostFollowed(ctx context.Context, limit int) ([]ScoredUser, error) {
+	var rows []ScoredUser
+	err := d.db.WithContext(ctx).Model(&po.FollowPo{}).
+		Select("target_uuid AS user_uuid, COUNT(*) AS score").
+		Where("status = ?", vo.FollowStateFollowing).
+		Group("target_uuid").
+		Order("score DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// QueryEdge returns the follow row of user -> target in any status, or nil if none.
+func (d *FollowDao) QueryEdge(ctx context.Context, userUUID, targetUUID string) (*po.FollowPo, error) {
+	var follow po.FollowPo
+	err := d.db.WithContext(ctx).
+		Where("user_uuid = ? AND target_uuid = ?", userUUID, targetUUID).
+		Take(&follow).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &follow, nil
+}
+
+// Insert creates the edge when the pair has no row yet; ok is false (and events are
+// not written) when a row already exists, whatever its status.
+func (d *FollowDao) Insert(ctx context.Context, follow *po.FollowPo, events ...*po.EventOutboxPo) (bool, error) {
 	now := time.Now()
 	follow.CreatedAt = now
 	follow.UpdatedAt = now
-	err := d.db.WithContext(ctx).
-		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "user_uuid"}, {Name: "target_uuid"}},
-			DoUpdates: clause.Assignments(map[string]interface{}{"status": follow.Status, "updated_at": now}),
-		}).
-		Create(follow).Error
-	if err != nil {
-		if !errors.Is(err, gorm.ErrDuplicatedKey) {
-			logger.WithContext(ctx).Errorf("follow upsert user_uuid: %v, target_uuid: %v error: %v", follow.UserUUID, follow.TargetUUID, err)
-		}
-		return err
+	err := d.followWrite(ctx, follow.UserUUID, follow.TargetUUID, follow.Status, events, func(tx *gorm.DB) error {
+		return tx.Create(follow).Error
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return false, nil
 	}
-	return nil
+	if err != nil && !errors.Is(err, ErrFollowBlocked) {
+		logger.WithContext(ctx).Errorf("follow insert user_uuid: %v, target_uuid: %v error: %v", follow.UserUUID, follow.TargetUUID, err)
+	}
+	return err == nil, err
 }
 
 // CountFollowers returns the count of active followers for a target user.
 func (d *FollowDao) CountFollowers(ctx context.Context, targetUUID string) (int64, error) {
 	var total int64
 	err := d.db.WithContext(ctx).Model(&po.FollowPo{}).
-		Where("target_uuid = ? AND status = ?", targetUUID, "Following").
+		Where("target_uuid = ? AND status = ?", targetUUID, vo.FollowStateFollowing).
 		Count(&total).Error
 	return total, err
 }
 
+// CountFollowersBatch returns active follower counts keyed by target user; users without followers are omitted.
+func (d *FollowDao) CountFollowersBatch(ctx context.Context, targetUUIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(targetUUIDs))
+	if len(targetUUIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		TargetUUID string
+		Total      int64
+	}
+	err := d.db.WithContext(ctx).Model(&po.FollowPo{}).
+		Select("target_uuid, COUNT(*) AS total").
+		Where("target_uuid IN ? AND status = ?", targetUUIDs, vo.FollowStateFollowing).
+		Group("target_uuid").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.TargetUUID] = row.Total
+	}
+	return counts, nil
+}
+
+// QueryFollowedTargets returns which of targetUUIDs the user actively follows.
+func (d *FollowDao) QueryFollowedTargets(ctx context.Context, userUUID string, targetUUIDs []string) ([]string, error) {
+	var targets []string
+	if len(targetUUIDs) == 0 {
+		return targets, nil
+	}
+	err := d.db.WithContext(ctx).Model(&po.FollowPo{}).
+		Where("user_uuid = ? AND target_uuid IN ? AND status = ?", userUUID, targetUUIDs, vo.FollowStateFollowing).
+		Pluck("target_uuid", &targets).Error
+	return targets, err
+}
+
+// QueryEdgesAround returns active edges user -> targets and sources -> user in one query.
+func (d *FollowDao) QueryEdgesAround(ctx context.Context, userUUID string, targetUUIDs, sourceUUIDs []string) ([]*po.FollowPo, error) {
+	var list []*po.FollowPo
+	q := d.db.WithContext(ctx).Model(&po.FollowPo{}).Select("user_uuid", "target_uuid")
+	switch {
+	case len(targetUUIDs) > 0 && len(sourceUUIDs) > 0:
+		q = q.Where("(user_uuid = ? AND target_uuid IN ?) OR (target_uuid = ? AND user_uuid IN ?)", userUUID, targetUUIDs, userUUID, sourceUUIDs)
+	case len(targetUUIDs) > 0:
+		q = q.Where("user_uuid = ? AND target_uuid IN ?", userUUID, targetUUIDs)
+	case len(sourceUUIDs) > 0:
+		q = q.Where("target_uuid = ? AND user_uuid IN ?", userUUID, sourceUUIDs)
+	default:
+		return list, nil
+	}
+	err := q.Where("status = ?", vo.FollowStateFollowing).Find(&list).Error
+	return list, err
+}
+
+// QueryFollowerSources returns which of userUUIDs actively follow the target.
+func (d *FollowDao) QueryFollowerSources(ctx context.Context, targetUUID string, userUUIDs []string) ([]string, error) {
+	var users []string
+	if len(userUUIDs) == 0 {
+		return users, nil
+	}
+	err := d.db.WithContext(ctx).Model(&po.FollowPo{}).
+		Where("target_uuid = ? AND user_uuid IN ? AND status = ?", targetUUID, userUUIDs, vo.FollowStateFollowing).
+		Pluck("user_uuid", &users).Error
+	return users, err
+}
+
 // CountFollowings returns the count of active followings for a user.
 func (d *FollowDao) CountFollowings(ctx context.Context, userUUID string) (int64, error) {
 	var total int64
 	err := d.db.WithContext(ctx).Model(&po.FollowPo{}).
-		Where("user_uuid = ? AND status = ?", userUUID, "Following").
+		Where("user_uuid = ? AND status = ?", userUUID, vo.FollowStateFollowing).
 		Count(&total).Error
 	return total, err
 }
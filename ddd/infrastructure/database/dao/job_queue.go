@@ -0,0 +1,37 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// jobQueue 后台任务表（status + attempts）共用的抢占操作：条件更新状态，以及把实例崩溃后
+// 停留在 running 的任务放回 pending
+type jobQueue struct {
+	db      *gorm.DB
+	model   interface{}
+	pending string
+	running string
+}
+
+// transitStatus 条件更新状态（from -> to），返回是否更新成功，用于多实例下抢占任务
+func (q jobQueue) transitStatus(ctx context.Context, id uint64, from, to string, fields map[string]interface{}) (bool, error) {
+	updates := map[string]interface{}{"status": to, "updated_at": time.Now()}
+	for k, v := range fields {
+		updates[k] = v
+	}
+	res := q.db.WithContext(ctx).Model(q.model).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	return res.RowsAffected > 0, res.Error
+}
+
+// requeueStale 将 before 之前就进入 running 且未再更新的任务放回 pending
+func (q jobQueue) requeueStale(ctx context.Context, before time.Time) (int64, error) {
+	res := q.db.WithContext(ctx).Model(q.model).
+		Where("status = ? AND updated_at < ?", q.running, before).
+		Updates(map[string]interface{}{"status": q.pending, "updated_at": time.Now()})
+	return res.RowsAffected, res.Error
+}
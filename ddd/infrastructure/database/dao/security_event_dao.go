@@ -0,0 +1,53 @@
+package dao
+
+import (
+	"context"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+)
+
+// SecurityEventFilter 安全事件查询条件，空字段不参与过滤
+type SecurityEventFilter struct {
+	UserUUID  string
+	EventType string
+	IP        string
+}
+
+type SecurityEventDao struct {
+	db *gorm.DB
+}
+
+func NewSecurityEventDao() *SecurityEventDao {
+	return &SecurityEventDao{db: resource.DefaultMysqlResource().MainDB()}
+}
+
+func (d *SecurityEventDao) BatchCreate(ctx context.Context, events []*po.SecurityEventPo) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).Create(&events).Error
+}
+
+func (d *SecurityEventDao) Query(ctx context.Context, filter SecurityEventFilter, offset, limit int) ([]*po.SecurityEventPo, int64, error) {
+	var list []*po.SecurityEventPo
+	q := d.db.WithContext(ctx).Model(&po.SecurityEventPo{})
+	if filter.UserUUID != "" {
+		q = q.Where("user_uuid = ?", filter.UserUUID)
+	}
+	if filter.EventType != "" {
+		q = q.Where("event_type = ?", filter.EventType)
+	}
+	if filter.IP != "" {
+		q = q.Where("ip = ?", filter.IP)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := q.Order("id DESC").Offset(offset).Limit(limit).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
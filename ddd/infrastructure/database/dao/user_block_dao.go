@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserBlockDao struct {
+	db *gorm.DB
+}
+
+func NewUserBlockDao() *UserBlockDao {
+	return &UserBlockDao{db: resource.DefaultMysqlResource().MainDB()}
+}
+
+// Upsert 重复拉黑/屏蔽时保留原记录
+func (d *UserBlockDao) Upsert(ctx context.Context, block *po.UserBlockPo) error {
+	return d.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_uuid"}, {Name: "target_uuid"}, {Name: "kind"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"updated_at": time.Now()}),
+		}).
+		Create(block).Error
+}
+
+func (d *UserBlockDao) Delete(ctx context.Context, userUUID, targetUUID, kind string) error {
+	return d.db.WithContext(ctx).
+		Where("user_uuid = ? AND target_uuid = ? AND kind = ?", userUUID, targetUUID, kind).
+		Delete(&po.UserBlockPo{}).Error
+}
+
+// CountBetween 统计两个用户之间任一方向的指定关系
+func (d *UserBlockDao) CountBetween(ctx context.Context, userUUID, otherUUID, kind string) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&po.UserBlockPo{}).
+		Where("kind = ? AND ((user_uuid = ? AND target_uuid = ?) OR (user_uuid = ? AND target_uuid = ?))",
+			kind, userUUID, otherUUID, otherUUID, userUUID).
+		Count(&count).Error
+	return count, err
+}
+
+// QueryRelations 查询 userUUID 与 targetUUIDs 之间两个方向的全部关系
+func (d *UserBlockDao) QueryRelations(ctx context.Context, userUUID string, targetUUIDs []string) ([]*po.UserBlockPo, error) {
+	var list []*po.UserBlockPo
+	if len(targetUUIDs) == 0 {
+		return list, nil
+	}
+	err := d.db.WithContext(ctx).
+		Where("(user_uuid = ? AND target_uuid IN ?) OR (target_uuid = ? AND user_uuid IN ?)",
+			userUUID, targetUUIDs, userUUID, targetUUIDs).
+		Find(&list).Error
+	return list, err
+}
+
+// QueryByUser 按时间倒序分页查询用户拉黑/屏蔽的人，同一时间按 target_uuid 倒序
+func (d *UserBlockDao) QueryByUser(ctx context.Context, userUUID, kind string, cursorTime time.Time, cursorUUID string, limit int) ([]*po.UserBlockPo, int64, error) {
+	var list []*po.UserBlockPo
+	q := d.db.WithContext(ctx).Model(&po.UserBlockPo{}).Where("user_uuid = ? AND kind = ?", userUUID, kind)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if !cursorTime.IsZero() {
+		q = q.Where("(created_at < ?) OR (created_at = ? AND target_uuid < ?)", cursorTime, cursorTime, cursorUUID)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Order("created_at DESC, target_uuid DESC").Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
@@ -58,8 +58,50 @@ func (d *UserDao) QueryByID(ctx context.Context, id uint64) (*po.UserPo, error)
 	return &user, nil
 }
 
-func (d *UserDao) Update(ctx context.Context, userPo *po.UserPo) error {
-	return d.db.WithContext(ctx).Save(userPo).Error
+// ErrVersionConflict 条件更新未命中：记录已被其他请求修改
+var ErrVersionConflict = errors.New("user version conflict")
+
+// Update 以读取时的 version 为条件整行更新，成功后 version 递增
+func (d *UserDao) Update(ctx context.Context, userPo *po.UserPo, events ...*po.EventOutboxPo) error {
+	expected := userPo.Version
+	userPo.Version = expected + 1
+	err := withOutbox(ctx, d.db, events, func(tx *gorm.DB) error {
+		res := tx.Model(userPo).
+			Where("version = ?", expected).
+			Select("*").Omit("id", "created_at").
+			Updates(userPo)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+	if err != nil {
+		userPo.Version = expected
+	}
+	return err
+}
+
+// QueryByUUIDs 批量查询用户，结果顺序不保证与入参一致
+func (d *UserDao) QueryByUUIDs(ctx context.Context, userUUIDs []string) ([]*po.UserPo, error) {
+	var users []*po.UserPo
+	if len(userUUIDs) == 0 {
+		return users, nil
+	}
+	err := d.db.WithContext(ctx).Where("user_uuid IN ?", userUUIDs).Find(&users).Error
+	return users, err
+}
+
+// ScanActive 按 id 升序分批读取未删除的用户，用于全量重建搜索索引
+func (d *UserDao) ScanActive(ctx context.Context, afterID uint64, limit int) ([]*po.UserPo, error) {
+	var users []*po.UserPo
+	err := d.db.WithContext(ctx).
+		Where("id > ? AND is_deleted = 0", afterID).
+		Order("id ASC").Limit(limit).
+		Find(&users).Error
+	return users, err
 }
 
 func (d *UserDao) DeleteByUUID(ctx context.Context, userUUID string) error {
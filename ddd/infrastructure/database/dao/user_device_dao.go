@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserDeviceDao struct {
+	db *gorm.DB
+}
+
+func NewUserDeviceDao() *UserDeviceDao {
+	return &UserDeviceDao{db: resource.DefaultMysqlResource().MainDB()}
+}
+
+func (d *UserDeviceDao) QueryByDevice(ctx context.Context, userUUID, deviceID string) (*po.UserDevicePo, error) {
+	var device po.UserDevicePo
+	err := d.db.WithContext(ctx).Where("user_uuid = ? AND device_id = ?", userUUID, deviceID).First(&device).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
+// QueryLatest 查询用户最近一次使用的设备
+func (d *UserDeviceDao) QueryLatest(ctx context.Context, userUUID string) (*po.UserDevicePo, error) {
+	var device po.UserDevicePo
+	err := d.db.WithContext(ctx).Where("user_uuid = ?", userUUID).Order("last_seen_at DESC").First(&device).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
+// QueryActiveUsers 按 user_uuid 升序分批查询 since 之后登录过的用户
+func (d *UserDeviceDao) QueryActiveUsers(ctx context.Context, since time.Time, afterUUID string, limit int) ([]string, error) {
+	var users []string
+	err := d.db.WithContext(ctx).Model(&po.UserDevicePo{}).
+		Distinct("user_uuid").
+		Where("user_uuid > ? AND last_seen_at >= ?", afterUUID, since).
+		Order("user_uuid ASC").Limit(limit).
+		Pluck("user_uuid", &users).Error
+	return users, err
+}
+
+func (d *UserDeviceDao) Upsert(ctx context.Context, device *po.UserDevicePo) error {
+	return d.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_uuid"}, {Name: "device_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"user_agent":   device.UserAgent,
+				"last_ip":      device.LastIP,
+				"last_region":  device.LastRegion,
+				"last_seen_at": device.LastSeenAt,
+				"updated_at":   device.LastSeenAt,
+			}),
+		}).
+		Create(device).Error
+}
@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+)
+
+type UserExportDao struct {
+	db   *gorm.DB
+	jobs jobQueue
+}
+
+func NewUserExportDao() *UserExportDao {
+	db := resource.DefaultMysqlResource().MainDB()
+	return &UserExportDao{
+		db:   db,
+		jobs: jobQueue{db: db, model: &po.UserExportPo{}, pending: vo.ExportStatusPending, running: vo.ExportStatusRunning},
+	}
+}
+
+func (d *UserExportDao) Create(ctx context.Context, export *po.UserExportPo) error {
+	return d.db.WithContext(ctx).Create(export).Error
+}
+
+func (d *UserExportDao) QueryByExportUUID(ctx context.Context, exportUUID string) (*po.UserExportPo, error) {
+	var export po.UserExportPo
+	err := d.db.WithContext(ctx).Where("export_uuid = ?", exportUUID).First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// QueryActiveByUser 查询用户尚未结束的导出任务（pending/running）
+func (d *UserExportDao) QueryActiveByUser(ctx context.Context, userUUID string) (*po.UserExportPo, error) {
+	var export po.UserExportPo
+	err := d.db.WithContext(ctx).
+		Where("user_uuid = ? AND status IN ?", userUUID, []string{vo.ExportStatusPending, vo.ExportStatusRunning}).
+		Order("id DESC").
+		First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (d *UserExportDao) QueryPending(ctx context.Context, limit int) ([]*po.UserExportPo, error) {
+	var list []*po.UserExportPo
+	err := d.db.WithContext(ctx).
+		Where("status = ?", vo.ExportStatusPending).
+		Order("id ASC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// QueryExpired 查询已过保留期、文件仍存在的导出任务
+func (d *UserExportDao) QueryExpired(ctx context.Context, now time.Time, limit int) ([]*po.UserExportPo, error) {
+	var list []*po.UserExportPo
+	err := d.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", vo.ExportStatusDone, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// TransitStatus 条件更新状态（from -> to），返回是否更新成功，用于多实例下抢占任务
+func (d *UserExportDao) TransitStatus(ctx context.Context, id uint64, from, to string, fields map[string]interface{}) (bool, error) {
+	return d.jobs.transitStatus(ctx, id, from, to, fields)
+}
+
+// RequeueStale 将长时间停留在 running 的任务（实例崩溃）放回 pending
+func (d *UserExportDao) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	return d.jobs.requeueStale(ctx, before)
+}
@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+)
+
+type UserHandleDao struct {
+	db *gorm.DB
+}
+
+func NewUserHandleDao() *UserHandleDao {
+	return &UserHandleDao{db: resource.DefaultMysqlResource().MainDB()}
+}
+
+// QueryUserByHandle 按当前用户名查询用户，依赖列排序规则做大小写不敏感匹配
+func (d *UserHandleDao) QueryUserByHandle(ctx context.Context, handle string) (*po.UserPo, error) {
+	var user po.UserPo
+	err := d.db.WithContext(ctx).Where("handle = ?", handle).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// QueryActiveHistory 查询仍在跳转保留期内的旧用户名记录
+func (d *UserHandleDao) QueryActiveHistory(ctx context.Context, handle string, now time.Time) (*po.UserHandleHistoryPo, error) {
+	var history po.UserHandleHistoryPo
+	err := d.db.WithContext(ctx).Where("handle = ? AND redirect_until > ?", handle, now).First(&history).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &history, nil
+}
+
+// ChangeHandle 在同一事务内以 version 为条件更新用户名、清理新用户名的历史占用并记录旧用户名
+func (d *UserHandleDao) ChangeHandle(ctx context.Context, userUUID string, version uint64, oldHandle, newHandle string, now, redirectUntil time.Time, events ...*po.EventOutboxPo) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&po.UserPo{}).Where("user_uuid = ? AND version = ?", userUUID, version).
+			Updates(map[string]interface{}{"handle": newHandle, "handle_changed_at": now, "version": gorm.Expr("version + 1")})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		// 过期记录或本人曾用的同名记录，均可释放
+		if err := tx.Where("handle = ?", newHandle).Delete(&po.UserHandleHistoryPo{}).Error; err != nil {
+			return err
+		}
+		if oldHandle != "" {
+			err := tx.Create(&po.UserHandleHistoryPo{
+				UserUUID:      userUUID,
+				Handle:        oldHandle,
+				RedirectUntil: redirectUntil,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return insertOutbox(tx, events)
+	})
+}
@@ -0,0 +1,49 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+)
+
+type UserMediaJobDao struct {
+	db   *gorm.DB
+	jobs jobQueue
+}
+
+func NewUserMediaJobDao() *UserMediaJobDao {
+	db := resource.DefaultMysqlResource().MainDB()
+	return &UserMediaJobDao{
+		db:   db,
+		jobs: jobQueue{db: db, model: &po.UserMediaJobPo{}, pending: vo.MediaJobStatusPending, running: vo.MediaJobStatusRunning},
+	}
+}
+
+func (d *UserMediaJobDao) Create(ctx context.Context, job *po.UserMediaJobPo) error {
+	return d.db.WithContext(ctx).Create(job).Error
+}
+
+// QueryDue 查询已到执行时间的待处理任务
+func (d *UserMediaJobDao) QueryDue(ctx context.Context, now time.Time, limit int) ([]*po.UserMediaJobPo, error) {
+	var list []*po.UserMediaJobPo
+	err := d.db.WithContext(ctx).
+		Where("status = ? AND next_run_at <= ?", vo.MediaJobStatusPending, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// TransitStatus 条件更新状态（from -> to），返回是否更新成功，用于多实例下抢占任务
+func (d *UserMediaJobDao) TransitStatus(ctx context.Context, id uint64, from, to string, fields map[string]interface{}) (bool, error) {
+	return d.jobs.transitStatus(ctx, id, from, to, fields)
+}
+
+// RequeueStale 将长时间停留在 running 的任务（实例崩溃）放回 pending
+func (d *UserMediaJobDao) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	return d.jobs.requeueStale(ctx, before)
+}
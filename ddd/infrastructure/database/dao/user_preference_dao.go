@@ -0,0 +1,59 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserPreferenceDao struct {
+	db *gorm.DB
+}
+
+func NewUserPreferenceDao() *UserPreferenceDao {
+	return &UserPreferenceDao{db: resource.DefaultMysqlResource().MainDB()}
+}
+
+func (d *UserPreferenceDao) QueryByUsers(ctx context.Context, userUUIDs []string) ([]*po.UserPreferencePo, error) {
+	var list []*po.UserPreferencePo
+	if len(userUUIDs) == 0 {
+		return list, nil
+	}
+	err := d.db.WithContext(ctx).Where("user_uuid IN ?", userUUIDs).Find(&list).Error
+	return list, err
+}
+
+// Save 在一个事务内写入 set 中的偏好项并删除 deleteKeys；replace 为 true 时先清空该用户全部偏好
+func (d *UserPreferenceDao) Save(ctx context.Context, userUUID string, set map[string]string, deleteKeys []string, replace bool) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		del := tx.Where("user_uuid = ?", userUUID)
+		switch {
+		case replace:
+			if err := del.Delete(&po.UserPreferencePo{}).Error; err != nil {
+				return err
+			}
+		case len(deleteKeys) > 0:
+			if err := del.Where("pref_key IN ?", deleteKeys).Delete(&po.UserPreferencePo{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(set) == 0 {
+			return nil
+		}
+		rows := make([]*po.UserPreferencePo, 0, len(set))
+		for k, v := range set {
+			rows = append(rows, &po.UserPreferencePo{UserUUID: userUUID, PrefKey: k, PrefValue: v})
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_uuid"}, {Name: "pref_key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"pref_value": gorm.Expr("VALUES(pref_value)"),
+				"updated_at": time.Now(),
+			}),
+		}).Create(&rows).Error
+	})
+}
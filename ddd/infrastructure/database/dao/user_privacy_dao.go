@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserPrivacyDao struct {
+	db *gorm.DB
+}
+
+func NewUserPrivacyDao() *UserPrivacyDao {
+	return &UserPrivacyDao{db: resource.DefaultMysqlResource().MainDB()}
+}
+
+func (d *UserPrivacyDao) QueryByUserUUID(ctx context.Context, userUUID string) (*po.UserPrivacyPo, error) {
+	var setting po.UserPrivacyPo
+	err := d.db.WithContext(ctx).Where("user_uuid = ?", userUUID).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// QueryByUserUUIDs loads the settings of several users; users without a row are absent.
+func (d *UserPrivacyDao) QueryByUserUUIDs(ctx context.Context, userUUIDs []string) ([]*po.UserPrivacyPo, error) {
+	var list []*po.UserPrivacyPo
+	if len(userUUIDs) == 0 {
+		return list, nil
+	}
+	err := d.db.WithContext(ctx).Where("user_uuid IN ?", userUUIDs).Find(&list).Error
+	return list, err
+}
+
+func (d *UserPrivacyDao) Upsert(ctx context.Context, setting *po.UserPrivacyPo) error {
+	return d.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_uuid"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"private_account":     setting.PrivateAccount,
+				"hide_follower_list":  setting.HideFollowerList,
+				"hide_following_list": setting.HideFollowingList,
+				"hide_counts":         setting.HideCounts,
+				"profile_visibility":  setting.ProfileVisibility,
+				"updated_at":          time.Now(),
+			}),
+		}).
+		Create(setting).Error
+}
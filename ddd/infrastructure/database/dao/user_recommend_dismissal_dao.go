@@ -0,0 +1,41 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserRecommendDismissalDao struct {
+	db *gorm.DB
+}
+
+func NewUserRecommendDismissalDao() *UserRecommendDismissalDao {
+	return &UserRecommendDismissalDao{db: resource.DefaultMysqlResource().MainDB()}
+}
+
+// Upsert 重复忽略时保留原记录
+func (d *UserRecommendDismissalDao) Upsert(ctx context.Context, dismissal *po.UserRecommendDismissalPo) error {
+	return d.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_uuid"}, {Name: "target_uuid"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"updated_at": time.Now()}),
+		}).
+		Create(dismissal).Error
+}
+
+// QueryTargetsAmong 返回 targetUUIDs 中被用户忽略过的用户
+func (d *UserRecommendDismissalDao) QueryTargetsAmong(ctx context.Context, userUUID string, targetUUIDs []string) ([]string, error) {
+	var targets []string
+	if len(targetUUIDs) == 0 {
+		return targets, nil
+	}
+	err := d.db.WithContext(ctx).Model(&po.UserRecommendDismissalPo{}).
+		Where("user_uuid = ? AND target_uuid IN ?", userUUID, targetUUIDs).
+		Pluck("target_uuid", &targets).Error
+	return targets, err
+}
@@ -0,0 +1,41 @@
+package persistence
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+type eventOutboxRepositoryImpl struct {
+	dao *dao.EventOutboxDao
+}
+
+func NewEventOutboxRepository() repo.EventOutboxRepository {
+	return &eventOutboxRepositoryImpl{dao: dao.NewEventOutboxDao()}
+}
+
+func (r *eventOutboxRepositoryImpl) ListPending(ctx context.Context, limit int) ([]*po.EventOutboxPo, error) {
+	return r.dao.QueryPending(ctx, limit)
+}
+
+func (r *eventOutboxRepositoryImpl) MarkSent(ctx context.Context, id uint64, now time.Time) error {
+	return r.dao.MarkSent(ctx, id, now)
+}
+
+func (r *eventOutboxRepositoryImpl) MarkAttempt(ctx context.Context, id uint64, attempts int, status, errMsg string) error {
+	return r.dao.MarkAttempt(ctx, id, attempts, status, errMsg)
+}
+
+func (r *eventOutboxRepositoryImpl) DeleteSentBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.dao.DeleteSentBefore(ctx, before, limit)
+}
+
+func (r *eventOutboxRepositoryImpl) CountFailed(ctx context.Context) (int64, error) {
+	return r.dao.CountFailed(ctx)
+}
+
+func (r *eventOutboxRepositoryImpl) DeleteFailedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.dao.DeleteFailedBefore(ctx, before, limit)
+}
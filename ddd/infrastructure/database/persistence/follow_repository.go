@@ -2,17 +2,25 @@ package persistence
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"strconv"
 	"strings"
 	"time"
 	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
 	"user-service/ddd/infrastructure/cache"
 	"user-service/ddd/infrastructure/database/dao"
 	"user-service/ddd/infrastructure/database/po"
 	"user-service/internal/resource"
+	"user-service/pkg/config"
+	"user-service/pkg/errno"
+
+	"github.com/google/uuid"
 )
 
+// maxCursorMillis 光标时间的上限（9999 年末）；旧版以纳秒表示的光标远大于此值
+const maxCursorMillis = 253402300799999
+
 type followRepositoryImpl struct {
 	dao   *dao.FollowDao
 	cache *cache.FollowCache
@@ -21,7 +29,11 @@ type followRepositoryImpl struct {
 func NewFollowRepository() repo.FollowRepository {
 	var followCache *cache.FollowCache
 	if cli := resource.DefaultRedisResource().Client(); cli != nil {
-		followCache = cache.NewFollowCache(cli)
+		listWindow, listTTL := 0, time.Duration(0)
+		if cfg := config.GetGlobalConfig(); cfg != nil {
+			listWindow, listTTL = cfg.FollowCache.ListWindow, cfg.FollowCache.ListTTL
+		}
+		followCache = cache.NewFollowCache(cli, listWindow, listTTL)
 	}
 	return &followRepositoryImpl{
 		dao:   dao.NewFollowDao(),
@@ -29,22 +41,97 @@ func NewFollowRepository() repo.FollowRepository {
 	}
 }
 
-func (r *followRepositoryImpl) Follow(ctx context.Context, userUUID, targetUUID string) error {
-	if err := r.dao.Upsert(ctx, &po.FollowPo{UserUUID: userUUID, TargetUUID: targetUUID, Status: "Following"}); err != nil {
-		return err
+// followWriteErr 关注写入事务内复查到拉黑关系时转为业务错误
+func followWriteErr(err error) error {
+	if errors.Is(err, dao.ErrFollowBlocked) {
+		return errno.ErrUserBlocked
+	}
+	return err
+}
+
+// open 将关系从已取关转为 to，没有记录时插入；两步都带状态条件，并发写入只有一个成功
+func (r *followRepositoryImpl) open(ctx context.Context, userUUID, targetUUID, to string, events []*po.EventOutboxPo) (bool, error) {
+	ok, err := r.dao.TransitStatus(ctx, userUUID, targetUUID, vo.FollowStateUnfollowed, to, events...)
+	if err != nil || ok {
+		return ok, followWriteErr(err)
+	}
+	ok, err = r.dao.Insert(ctx, &po.FollowPo{UserUUID: userUUID, TargetUUID: targetUUID, Status: to}, events...)
+	return ok, followWriteErr(err)
+}
+
+func (r *followRepositoryImpl) Follow(ctx context.Context, userUUID, targetUUID string, events ...*po.EventOutboxPo) (bool, error) {
+	ok, err := r.open(ctx, userUUID, targetUUID, vo.FollowStateFollowing, events)
+	if err != nil || !ok {
+		return false, err
 	}
 	r.afterFollow(ctx, userUUID, targetUUID)
 	r.setEdge(ctx, userUUID, targetUUID, true)
-	return nil
+	return true, nil
 }
 
-func (r *followRepositoryImpl) Unfollow(ctx context.Context, userUUID, targetUUID string) error {
-	if err := r.dao.UpdateStatus(ctx, userUUID, targetUUID, "Unfollowed"); err != nil {
-		return err
+func (r *followRepositoryImpl) Unfollow(ctx context.Context, userUUID, targetUUID string, events ...*po.EventOutboxPo) (bool, error) {
+	ok, err := r.dao.TransitStatus(ctx, userUUID, targetUUID, vo.FollowStateFollowing, vo.FollowStateUnfollowed, events...)
+	if err != nil || !ok {
+		return false, err
 	}
 	r.afterUnfollow(ctx, userUUID, targetUUID)
 	r.deleteEdge(ctx, userUUID, targetUUID)
-	return nil
+	return true, nil
+}
+
+func (r *followRepositoryImpl) FollowState(ctx context.Context, userUUID, targetUUID string) (string, error) {
+	edge, err := r.dao.QueryEdge(ctx, userUUID, targetUUID)
+	if err != nil || edge == nil {
+		return "", err
+	}
+	return edge.Status, nil
+}
+
+func (r *followRepositoryImpl) RemoveFollower(ctx context.Context, userUUID, followerUUID string, audit *po.RelationAuditPo, events ...*po.EventOutboxPo) (bool, error) {
+	ok, err := r.dao.RemoveFollower(ctx, followerUUID, userUUID, audit, events...)
+	if err != nil || !ok {
+		return false, err
+	}
+	r.afterUnfollow(ctx, followerUUID, userUUID)
+	r.deleteEdge(ctx, followerUUID, userUUID)
+	return true, nil
+}
+
+func (r *followRepositoryImpl) Request(ctx context.Context, userUUID, targetUUID string) (bool, error) {
+	return r.open(ctx, userUUID, targetUUID, vo.FollowStatePending, nil)
+}
+
+func (r *followRepositoryImpl) ClaimRequestNotice(ctx context.Context, userUUID, targetUUID string) bool {
+	if r.cache == nil {
+		return true
+	}
+	ok, err := r.cache.ClaimRequestNotice(ctx, userUUID, targetUUID)
+	return ok || err != nil
+}
+
+func (r *followRepositoryImpl) ResolveRequest(ctx context.Context, userUUID, targetUUID string, approve bool, events ...*po.EventOutboxPo) (bool, error) {
+	to := vo.FollowStateUnfollowed
+	if approve {
+		to = vo.FollowStateFollowing
+	}
+	ok, err := r.dao.TransitStatus(ctx, userUUID, targetUUID, vo.FollowStatePending, to, events...)
+	if err != nil || !ok {
+		return false, followWriteErr(err)
+	}
+	if approve {
+		r.afterFollow(ctx, userUUID, targetUUID)
+		r.setEdge(ctx, userUUID, targetUUID, true)
+	}
+	return true, nil
+}
+
+func (r *followRepositoryImpl) ListFollowRequests(ctx context.Context, targetUUID string, cursor string, limit int) ([]*po.FollowPo, int64, error) {
+	c, err := parseCursor(cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	cursorTime, cursorUUID := cursorArgs(c)
+	return r.dao.QueryFollowRequests(ctx, targetUUID, cursorTime, cursorUUID, limit)
 }
 
 func (r *followRepositoryImpl) IsFollowing(ctx context.Context, userUUID, targetUUID string) (bool, error) {
@@ -62,45 +149,120 @@ func (r *followRepositoryImpl) IsFollowing(ctx context.Context, userUUID, target
 }
 
 func (r *followRepositoryImpl) ListFollowers(ctx context.Context, targetUUID string, cursor string, limit int) ([]*po.FollowPo, int64, error) {
-	if r.cache != nil && cursor == "" {
-		if cached, ok, err := r.cache.GetFollowerList(ctx, targetUUID); err == nil && ok {
-			if len(cached) == 0 {
-				return []*po.FollowPo{}, 0, nil
-			}
-			list := toFollowPoList(cached, limit, func(item cache.CachedFollowItem) *po.FollowPo {
-				return &po.FollowPo{BaseModel: po.BaseModel{Id: item.ID, CreatedAt: time.Unix(0, item.CreatedAt)}, UserUUID: item.UserUUID, TargetUUID: targetUUID, Status: "Following"}
-			})
-			total, _ := r.CountFollowers(ctx, targetUUID)
-			return list, total, nil
-		}
+	c, err := parseCursor(cursor)
+	if err != nil {
+		return nil, 0, err
 	}
-	cursorTime, cursorID := parseCursor(cursor)
-	list, total, err := r.dao.QueryFollowers(ctx, targetUUID, cursorTime, cursorID, limit)
-	if err == nil && r.cache != nil && cursor == "" {
-		_ = r.cache.SetFollowerList(ctx, targetUUID, toCachedItems(list))
+	if items, ok := r.cachedPage(ctx, followerList(r.cache, r.dao, targetUUID), c, limit); ok {
+		list := make([]*po.FollowPo, 0, len(items))
+		for _, item := range items {
+			list = append(list, &po.FollowPo{UserUUID: item.UUID, TargetUUID: targetUUID, Status: vo.FollowStateFollowing, CreatedAt: time.UnixMilli(item.FollowedAt)})
+		}
+		total, err := r.CountFollowers(ctx, targetUUID)
+		return list, total, err
 	}
-	return list, total, err
+	cursorTime, cursorUUID := cursorArgs(c)
+	return r.dao.QueryFollowers(ctx, targetUUID, cursorTime, cursorUUID, limit)
 }
 
 func (r *followRepositoryImpl) ListFollowings(ctx context.Context, userUUID string, cursor string, limit int) ([]*po.FollowPo, int64, error) {
-	if r.cache != nil && cursor == "" {
-		if cached, ok, err := r.cache.GetFollowingList(ctx, userUUID); err == nil && ok {
-			if len(cached) == 0 {
-				return []*po.FollowPo{}, 0, nil
-			}
-			list := toFollowPoList(cached, limit, func(item cache.CachedFollowItem) *po.FollowPo {
-				return &po.FollowPo{BaseModel: po.BaseModel{Id: item.ID, CreatedAt: time.Unix(0, item.CreatedAt)}, UserUUID: userUUID, TargetUUID: item.UserUUID, Status: "Following"}
-			})
-			total, _ := r.CountFollowings(ctx, userUUID)
-			return list, total, nil
+	c, err := parseCursor(cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	if items, ok := r.cachedPage(ctx, followingList(r.cache, r.dao, userUUID), c, limit); ok {
+		list := make([]*po.FollowPo, 0, len(items))
+		for _, item := range items {
+			list = append(list, &po.FollowPo{UserUUID: userUUID, TargetUUID: item.UUID, Status: vo.FollowStateFollowing, CreatedAt: time.UnixMilli(item.FollowedAt)})
 		}
+		total, err := r.CountFollowings(ctx, userUUID)
+		return list, total, err
 	}
-	cursorTime, cursorID := parseCursor(cursor)
-	list, total, err := r.dao.QueryFollowings(ctx, userUUID, cursorTime, cursorID, limit)
-	if err == nil && r.cache != nil && cursor == "" {
-		_ = r.cache.SetFollowingList(ctx, userUUID, toCachedItems(list))
+	cursorTime, cursorUUID := cursorArgs(c)
+	return r.dao.QueryFollowings(ctx, userUUID, cursorTime, cursorUUID, limit)
+}
+
+// cachedList 粉丝列表与关注列表在缓存读写上的差异
+type cachedList struct {
+	page  func(ctx context.Context, cursor *cache.FollowListCursor, limit int) ([]cache.FollowListItem, bool, error)
+	state func(ctx context.Context) (bool, int64, error)
+	query func(ctx context.Context, limit int) ([]cache.FollowListItem, int64, error)
+	load  func(ctx context.Context, gen int64, items []cache.FollowListItem, complete bool) (bool, error)
+}
+
+func followerList(c *cache.FollowCache, d *dao.FollowDao, targetUUID string) *cachedList {
+	if c == nil {
+		return nil
 	}
-	return list, total, err
+	return &cachedList{
+		page: func(ctx context.Context, cursor *cache.FollowListCursor, limit int) ([]cache.FollowListItem, bool, error) {
+			return c.FollowerListPage(ctx, targetUUID, cursor, limit)
+		},
+		state: func(ctx context.Context) (bool, int64, error) { return c.FollowerListState(ctx, targetUUID) },
+		query: func(ctx context.Context, limit int) ([]cache.FollowListItem, int64, error) {
+			list, total, err := d.QueryFollowers(ctx, targetUUID, time.Time{}, "", limit)
+			return toListItems(list, func(p *po.FollowPo) string { return p.UserUUID }), total, err
+		},
+		load: func(ctx context.Context, gen int64, items []cache.FollowListItem, complete bool) (bool, error) {
+			return c.LoadFollowerList(ctx, targetUUID, gen, items, complete)
+		},
+	}
+}
+
+func followingList(c *cache.FollowCache, d *dao.FollowDao, userUUID string) *cachedList {
+	if c == nil {
+		return nil
+	}
+	return &cachedList{
+		page: func(ctx context.Context, cursor *cache.FollowListCursor, limit int) ([]cache.FollowListItem, bool, error) {
+			return c.FollowingListPage(ctx, userUUID, cursor, limit)
+		},
+		state: func(ctx context.Context) (bool, int64, error) { return c.FollowingListState(ctx, userUUID) },
+		query: func(ctx context.Context, limit int) ([]cache.FollowListItem, int64, error) {
+			list, total, err := d.QueryFollowings(ctx, userUUID, time.Time{}, "", limit)
+			return toListItems(list, func(p *po.FollowPo) string { return p.TargetUUID }), total, err
+		},
+		load: func(ctx context.Context, gen int64, items []cache.FollowListItem, complete bool) (bool, error) {
+			return c.LoadFollowingList(ctx, userUUID, gen, items, complete)
+		},
+	}
+}
+
+// cachedPage 从列表缓存读取一页；列表未缓存时先从数据库加载最新窗口再读。
+// 加载前记下列表的写入代数，期间有关注/取关写入时放弃加载，避免旧窗口覆盖新写入。
+// 返回 false 表示需要回源数据库（游标超出窗口、加载被放弃、缓存异常等）
+func (r *followRepositoryImpl) cachedPage(ctx context.Context, l *cachedList, cursor *cache.FollowListCursor, limit int) ([]cache.FollowListItem, bool) {
+	if l == nil {
+		return nil, false
+	}
+	items, ok, err := l.page(ctx, cursor, limit)
+	if err != nil {
+		return nil, false
+	}
+	if ok {
+		return items, true
+	}
+	cached, gen, err := l.state(ctx)
+	if err != nil || cached {
+		return nil, false
+	}
+	window, total, err := l.query(ctx, r.cache.ListWindow())
+	if err != nil {
+		return nil, false
+	}
+	if loaded, err := l.load(ctx, gen, window, int64(len(window)) >= total); err != nil || !loaded {
+		return nil, false
+	}
+	items, ok, err = l.page(ctx, cursor, limit)
+	return items, ok && err == nil
+}
+
+func toListItems(list []*po.FollowPo, pick func(*po.FollowPo) string) []cache.FollowListItem {
+	items := make([]cache.FollowListItem, 0, len(list))
+	for _, v := range list {
+		items = append(items, cache.FollowListItem{UUID: pick(v), FollowedAt: v.CreatedAt.UnixMilli()})
+	}
+	return items
 }
 
 func (r *followRepositoryImpl) CountFollowers(ctx context.Context, targetUUID string) (int64, error) {
@@ -129,12 +291,181 @@ func (r *followRepositoryImpl) CountFollowings(ctx context.Context, userUUID str
 	return count, err
 }
 
-func (r *followRepositoryImpl) invalidateCounts(ctx context.Context, userUUID, targetUUID string) {
-	if r.cache == nil {
-		return
+// ListFriends 好友列表直接查询数据库（自关联），总数走缓存
+func (r *followRepositoryImpl) ListFriends(ctx context.Context, userUUID string, cursor string, limit int) ([]*po.FollowPo, int64, error) {
+	c, err := parseCursor(cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	cursorTime, cursorUUID := cursorArgs(c)
+	list, err := r.dao.QueryFriends(ctx, userUUID, cursorTime, cursorUUID, limit)
+	if err != nil {
+		return nil, 0, err
 	}
-	r.cache.InvalidateCounts(ctx, userUUID, targetUUID)
-	r.cache.InvalidateLists(ctx, userUUID, targetUUID)
+	total, err := r.CountFriends(ctx, userUUID)
+	return list, total, err
+}
+
+func (r *followRepositoryImpl) CountFriends(ctx context.Context, userUUID string) (int64, error) {
+	if r.cache != nil {
+		if v, ok, err := r.cache.GetFriendCount(ctx, userUUID); err == nil && ok {
+			return v, nil
+		}
+	}
+	count, err := r.dao.CountFriends(ctx, userUUID)
+	if err == nil && r.cache != nil {
+		_ = r.cache.SetFriendCount(ctx, userUUID, count)
+	}
+	return count, err
+}
+
+const (
+	// commonFollowersSample 只用访问者最近关注的这些人去求交集，关注数很多的访问者查询也有上界
+	commonFollowersSample = 200
+	// commonFollowersMax 缓存的示例用户数，与 CommonFollowersQuery 的 size 上限一致，按调用方的 limit 截取
+	commonFollowersMax = 20
+)
+
+// CommonFollowers 按 (viewer, target) 缓存最多 commonFollowersMax 条示例与总数；
+// 访问者本人关注或取关时其全部缓存失效，其余变化在缓存过期后体现
+func (r *followRepositoryImpl) CommonFollowers(ctx context.Context, viewerUUID, targetUUID string, limit int) ([]*po.FollowPo, int64, bool, error) {
+	var (
+		res    *cache.CommonFollowers
+		gen    int64
+		cached bool
+	)
+	if r.cache != nil {
+		v, g, ok, err := r.cache.GetCommonFollowers(ctx, viewerUUID, targetUUID)
+		cached = err == nil
+		if cached && ok {
+			res = v
+		}
+		gen = g
+	}
+	if res == nil {
+		list, total, err := r.dao.QueryCommonFollowers(ctx, viewerUUID, targetUUID, commonFollowersSample, commonFollowersMax)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		res = &cache.CommonFollowers{Items: make([]cache.FollowListItem, 0, len(list)), Total: total}
+		for _, p := range list {
+			res.Items = append(res.Items, cache.FollowListItem{UUID: p.UserUUID, FollowedAt: p.CreatedAt.UnixMilli()})
+		}
+		// 访问者关注的人超过抽样数时，总数只统计了最近关注的那部分
+		followings, err := r.CountFollowings(ctx, viewerUUID)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		res.Approx = followings > commonFollowersSample
+		if cached {
+			_ = r.cache.SetCommonFollowers(ctx, viewerUUID, targetUUID, gen, res)
+		}
+	}
+	if limit > 0 && len(res.Items) > limit {
+		res.Items = res.Items[:limit]
+	}
+	list := make([]*po.FollowPo, 0, len(res.Items))
+	for _, it := range res.Items {
+		list = append(list, &po.FollowPo{UserUUID: it.UUID, TargetUUID: targetUUID, Status: vo.FollowStateFollowing, CreatedAt: time.UnixMilli(it.FollowedAt)})
+	}
+	return list, res.Total, res.Approx, nil
+}
+
+// IsFriend 复用两个方向的关注关系缓存，关注/取关时关系缓存同步更新
+func (r *followRepositoryImpl) IsFriend(ctx context.Context, userUUID, otherUUID string) (bool, error) {
+	following, err := r.IsFollowing(ctx, userUUID, otherUUID)
+	if err != nil || !following {
+		return false, err
+	}
+	return r.IsFollowing(ctx, otherUUID, userUUID)
+}
+
+// CountFollowersBatch 直接查询数据库，用于搜索索引重建等批量场景
+func (r *followRepositoryImpl) CountFollowersBatch(ctx context.Context, targetUUIDs []string) (map[string]int64, error) {
+	return r.dao.CountFollowersBatch(ctx, targetUUIDs)
+}
+
+// FollowedAmong 单次 IN 查询批量判断关注关系
+func (r *followRepositoryImpl) FollowedAmong(ctx context.Context, userUUID string, targetUUIDs []string) (map[string]bool, error) {
+	targets, err := r.dao.QueryFollowedTargets(ctx, userUUID, targetUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	return toSet(targets), nil
+}
+
+// FollowersAmong 单次 IN 查询批量判断是否为粉丝
+func (r *followRepositoryImpl) FollowersAmong(ctx context.Context, targetUUID string, userUUIDs []string) (map[string]bool, error) {
+	users, err := r.dao.QueryFollowerSources(ctx, targetUUID, userUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	return toSet(users), nil
+}
+
+func (r *followRepositoryImpl) RelationAmong(ctx context.Context, userUUID string, targetUUIDs []string) (map[string]bool, map[string]bool, error) {
+	following := make(map[string]bool, len(targetUUIDs))
+	followedBy := make(map[string]bool, len(targetUUIDs))
+	edges := make([]cache.FollowEdge, 0, len(targetUUIDs)*2)
+	for _, t := range targetUUIDs {
+		edges = append(edges, cache.FollowEdge{UserUUID: userUUID, TargetUUID: t}, cache.FollowEdge{UserUUID: t, TargetUUID: userUUID})
+	}
+	cached := map[cache.FollowEdge]bool{}
+	if r.cache != nil {
+		// 缓存异常时全部回源
+		cached, _ = r.cache.GetEdges(ctx, edges)
+	}
+	var missTargets, missSources []string
+	for _, e := range edges {
+		v, ok := cached[e]
+		switch {
+		case e.UserUUID == userUUID && ok:
+			following[e.TargetUUID] = v
+		case e.UserUUID == userUUID:
+			missTargets = append(missTargets, e.TargetUUID)
+		case ok:
+			followedBy[e.UserUUID] = v
+		default:
+			missSources = append(missSources, e.UserUUID)
+		}
+	}
+	if len(missTargets) == 0 && len(missSources) == 0 {
+		return following, followedBy, nil
+	}
+	rows, err := r.dao.QueryEdgesAround(ctx, userUUID, missTargets, missSources)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, t := range missTargets {
+		following[t] = false
+	}
+	for _, s := range missSources {
+		followedBy[s] = false
+	}
+	// 只回写已存在的关注边。未命中的边不写否定缓存：读库与回写之间若有关注提交，
+	// 否定值会覆盖 Follow 刚写入的缓存，在 TTL 内一直返回未关注
+	found := make(map[cache.FollowEdge]bool, len(rows))
+	for _, row := range rows {
+		e := cache.FollowEdge{UserUUID: row.UserUUID, TargetUUID: row.TargetUUID}
+		found[e] = true
+		if e.UserUUID == userUUID {
+			following[e.TargetUUID] = true
+		} else {
+			followedBy[e.UserUUID] = true
+		}
+	}
+	if r.cache != nil {
+		_ = r.cache.SetEdges(ctx, found)
+	}
+	return following, followedBy, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
 }
 
 // afterFollow updates cached counters and lists for a successful follow.
@@ -146,8 +477,15 @@ func (r *followRepositoryImpl) afterFollow(ctx context.Context, userUUID, target
 	// Increment counts; occasional drift is corrected by Count* when cache miss happens.
 	_ = r.cache.IncrFollowingCount(ctx, userUUID, 1)
 	_ = r.cache.IncrFollowerCount(ctx, targetUUID, 1)
-	// Lists are still invalidated and rebuilt lazily on read.
-	r.cache.InvalidateLists(ctx, userUUID, targetUUID)
+	r.cache.InvalidateFriendCounts(ctx, userUUID, targetUUID)
+	r.cache.InvalidateCommonFollowers(ctx, userUUID)
+	// Re-follow keeps the original created_at, so read the row back for the list score.
+	edge, err := r.dao.QueryEdge(ctx, userUUID, targetUUID)
+	if err != nil || edge == nil {
+		r.cache.InvalidateLists(ctx, userUUID, targetUUID)
+		return
+	}
+	r.cache.AddFollow(ctx, userUUID, targetUUID, edge.CreatedAt.UnixMilli())
 }
 
 // afterUnfollow updates cached counters and lists for a successful unfollow.
@@ -157,7 +495,9 @@ func (r *followRepositoryImpl) afterUnfollow(ctx context.Context, userUUID, targ
 	}
 	_ = r.cache.IncrFollowingCount(ctx, userUUID, -1)
 	_ = r.cache.IncrFollowerCount(ctx, targetUUID, -1)
-	r.cache.InvalidateLists(ctx, userUUID, targetUUID)
+	r.cache.InvalidateFriendCounts(ctx, userUUID, targetUUID)
+	r.cache.InvalidateCommonFollowers(ctx, userUUID)
+	r.cache.RemoveFollow(ctx, userUUID, targetUUID)
 }
 
 func (r *followRepositoryImpl) setEdge(ctx context.Context, userUUID, targetUUID string, following bool) {
@@ -188,52 +528,23 @@ func (r *followRepositoryImpl) setFollowingCount(ctx context.Context, userUUID s
 	_ = r.cache.SetFollowingCount(ctx, userUUID, count)
 }
 
-func toCachedItems(list []*po.FollowPo) []cache.CachedFollowItem {
-	items := make([]cache.CachedFollowItem, 0, len(list))
-	for _, v := range list {
-		if v == nil {
-			continue
-		}
-		items = append(items, cache.CachedFollowItem{
-			UserUUID:  v.UserUUID,
-			CreatedAt: v.CreatedAt.UnixNano(),
-			ID:        v.Id,
-		})
-	}
-	return items
-}
-
-func toFollowPoList(items []cache.CachedFollowItem, limit int, builder func(item cache.CachedFollowItem) *po.FollowPo) []*po.FollowPo {
-	if limit > 0 && len(items) > limit {
-		items = items[:limit]
-	}
-	res := make([]*po.FollowPo, 0, len(items))
-	for _, item := range items {
-		res = append(res, builder(item))
-	}
-	return res
-}
-
-// cursor format: unixnano:id (both int). Empty means no cursor.
-func parseCursor(cursor string) (time.Time, uint64) {
+// parseCursor 解析 "unixmilli:uuid" 光标（上一页最后一条的关注时间与对方 UUID），空光标返回 nil。
+// 无法解析或旧版 "unixnano:id" 格式的光标返回参数错误，不再静默回到第一页
+func parseCursor(cursor string) (*cache.FollowListCursor, error) {
 	if cursor == "" {
-		return time.Time{}, 0
-	}
-	parts := strings.Split(cursor, ":")
-	if len(parts) != 2 {
-		return time.Time{}, 0
+		return nil, nil
 	}
-	ns, err1 := strconv.ParseInt(parts[0], 10, 64)
-	id, err2 := strconv.ParseUint(parts[1], 10, 64)
-	if err1 != nil || err2 != nil {
-		return time.Time{}, 0
+	ms, peer, ok := strings.Cut(cursor, ":")
+	v, err := strconv.ParseInt(ms, 10, 64)
+	if !ok || err != nil || v <= 0 || v > maxCursorMillis || uuid.Validate(peer) != nil {
+		return nil, errno.NewSimpleBizError(errno.ErrParameterInvalid, nil, "cursor")
 	}
-	return time.Unix(0, ns), id
+	return &cache.FollowListCursor{FollowedAt: v, UUID: peer}, nil
 }
 
-func makeCursor(p *po.FollowPo) string {
-	if p == nil {
-		return ""
+func cursorArgs(c *cache.FollowListCursor) (time.Time, string) {
+	if c == nil {
+		return time.Time{}, ""
 	}
-	return fmt.Sprintf("%d:%d", p.CreatedAt.UnixNano(), p.Id)
+	return time.UnixMilli(c.FollowedAt), c.UUID
 }
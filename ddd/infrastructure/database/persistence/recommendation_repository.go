@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/cache"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+	"user-service/pkg/config"
+)
+
+type recommendationRepositoryImpl struct {
+	followDao  *dao.FollowDao
+	dismissDao *dao.UserRecommendDismissalDao
+	cache      *cache.RecommendCache
+}
+
+func NewRecommendationRepository() repo.RecommendationRepository {
+	var recommendCache *cache.RecommendCache
+	if cli := resource.DefaultRedisResource().Client(); cli != nil {
+		var ttl time.Duration
+		if cfg := config.GetGlobalConfig(); cfg != nil {
+			ttl = cfg.Recommend.CacheTTL
+		}
+		recommendCache = cache.NewRecommendCache(cli, ttl)
+	}
+	return &recommendationRepositoryImpl{
+		followDao:  dao.NewFollowDao(),
+		dismissDao: dao.NewUserRecommendDismissalDao(),
+		cache:      recommendCache,
+	}
+}
+
+func (r *recommendationRepositoryImpl) FriendsOfFriends(ctx context.Context, userUUID string, sampleSize, limit int) ([]vo.SuggestedUser, error) {
+	rows, err := r.followDao.QueryFollowedByFollowings(ctx, userUUID, sampleSize, limit)
+	if err != nil {
+		return nil, err
+	}
+	return fromScoredUsers(rows, vo.RecommendReasonFollowedByFollowings), nil
+}
+
+func (r *recommendationRepositoryImpl) Popular(ctx context.Context, poolSize int, refresh bool) ([]vo.SuggestedUser, error) {
+	if !refresh && r.cache != nil {
+		if entries, ok, err := r.cache.GetPopular(ctx); err == nil && ok {
+			return fromRecommendEntries(entries), nil
+		}
+	}
+	rows, err := r.followDao.QueryMostFollowed(ctx, poolSize)
+	if err != nil {
+		return nil, err
+	}
+	list := fromScoredUsers(rows, vo.RecommendReasonPopular)
+	if r.cache != nil {
+		_ = r.cache.SetPopular(ctx, toRecommendEntries(list))
+	}
+	return list, nil
+}
+
+func (r *recommendationRepositoryImpl) GetSuggestions(ctx context.Context, userUUID string) ([]vo.SuggestedUser, bool, error) {
+	if r.cache == nil {
+		return nil, false, nil
+	}
+	entries, ok, err := r.cache.Get(ctx, userUUID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return fromRecommendEntries(entries), true, nil
+}
+
+func (r *recommendationRepositoryImpl) SaveSuggestions(ctx context.Context, userUUID string, list []vo.SuggestedUser) error {
+	if r.cache == nil {
+		return nil
+	}
+	return r.cache.Set(ctx, userUUID, toRecommendEntries(list))
+}
+
+func (r *recommendationRepositoryImpl) Dismiss(ctx context.Context, userUUID, targetUUID string) error {
+	return r.dismissDao.Upsert(ctx, &po.UserRecommendDismissalPo{UserUUID: userUUID, TargetUUID: targetUUID})
+}
+
+func (r *recommendationRepositoryImpl) DismissedAmong(ctx context.Context, userUUID string, targetUUIDs []string) (map[string]bool, error) {
+	targets, err := r.dismissDao.QueryTargetsAmong(ctx, userUUID, targetUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	return toSet(targets), nil
+}
+
+func fromScoredUsers(rows []dao.ScoredUser, reason string) []vo.SuggestedUser {
+	list := make([]vo.SuggestedUser, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, vo.SuggestedUser{UserUUID: row.UserUUID, Score: row.Score, Reason: reason})
+	}
+	return list
+}
+
+func fromRecommendEntries(entries []cache.RecommendEntry) []vo.SuggestedUser {
+	list := make([]vo.SuggestedUser, 0, len(entries))
+	for _, e := range entries {
+		list = append(list, vo.SuggestedUser{UserUUID: e.UUID, Score: e.Score, Reason: e.Reason})
+	}
+	return list
+}
+
+func toRecommendEntries(list []vo.SuggestedUser) []cache.RecommendEntry {
+	entries := make([]cache.RecommendEntry, 0, len(list))
+	for _, s := range list {
+		entries = append(entries, cache.RecommendEntry{UUID: s.UserUUID, Score: s.Score, Reason: s.Reason})
+	}
+	return entries
+}
@@ -0,0 +1,29 @@
+package persistence
+
+import (
+	"context"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+type securityEventRepositoryImpl struct {
+	dao *dao.SecurityEventDao
+}
+
+func NewSecurityEventRepository() repo.SecurityEventRepository {
+	return &securityEventRepositoryImpl{dao: dao.NewSecurityEventDao()}
+}
+
+func (r *securityEventRepositoryImpl) SaveEvents(ctx context.Context, events []*po.SecurityEventPo) error {
+	return r.dao.BatchCreate(ctx, events)
+}
+
+func (r *securityEventRepositoryImpl) ListEvents(ctx context.Context, query repo.SecurityEventQuery) ([]*po.SecurityEventPo, int64, error) {
+	filter := dao.SecurityEventFilter{
+		UserUUID:  query.UserUUID,
+		EventType: query.EventType,
+		IP:        query.IP,
+	}
+	return r.dao.Query(ctx, filter, query.Offset, query.Limit)
+}
@@ -0,0 +1,43 @@
+package persistence
+
+import (
+	"context"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/domain/vo"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+type userBlockRepositoryImpl struct {
+	dao *dao.UserBlockDao
+}
+
+func NewUserBlockRepository() repo.UserBlockRepository {
+	return &userBlockRepositoryImpl{dao: dao.NewUserBlockDao()}
+}
+
+func (r *userBlockRepositoryImpl) Add(ctx context.Context, userUUID, targetUUID, kind string) error {
+	return r.dao.Upsert(ctx, &po.UserBlockPo{UserUUID: userUUID, TargetUUID: targetUUID, Kind: kind})
+}
+
+func (r *userBlockRepositoryImpl) Remove(ctx context.Context, userUUID, targetUUID, kind string) error {
+	return r.dao.Delete(ctx, userUUID, targetUUID, kind)
+}
+
+func (r *userBlockRepositoryImpl) IsBlockedBetween(ctx context.Context, userUUID, otherUUID string) (bool, error) {
+	count, err := r.dao.CountBetween(ctx, userUUID, otherUUID, vo.BlockKindBlock)
+	return count > 0, err
+}
+
+func (r *userBlockRepositoryImpl) Relations(ctx context.Context, userUUID string, targetUUIDs []string) ([]*po.UserBlockPo, error) {
+	return r.dao.QueryRelations(ctx, userUUID, targetUUIDs)
+}
+
+func (r *userBlockRepositoryImpl) List(ctx context.Context, userUUID, kind string, cursor string, limit int) ([]*po.UserBlockPo, int64, error) {
+	c, err := parseCursor(cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	cursorTime, cursorUUID := cursorArgs(c)
+	return r.dao.QueryByUser(ctx, userUUID, kind, cursorTime, cursorUUID, limit)
+}
@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+type userDeviceRepositoryImpl struct {
+	dao *dao.UserDeviceDao
+}
+
+func NewUserDeviceRepository() repo.UserDeviceRepository {
+	return &userDeviceRepositoryImpl{dao: dao.NewUserDeviceDao()}
+}
+
+func (r *userDeviceRepositoryImpl) GetDevice(ctx context.Context, userUUID, deviceID string) (*po.UserDevicePo, error) {
+	return r.dao.QueryByDevice(ctx, userUUID, deviceID)
+}
+
+func (r *userDeviceRepositoryImpl) GetLatestDevice(ctx context.Context, userUUID string) (*po.UserDevicePo, error) {
+	return r.dao.QueryLatest(ctx, userUUID)
+}
+
+func (r *userDeviceRepositoryImpl) SaveDevice(ctx context.Context, device *po.UserDevicePo) error {
+	return r.dao.Upsert(ctx, device)
+}
+
+func (r *userDeviceRepositoryImpl) ListActiveUsers(ctx context.Context, since time.Time, afterUUID string, limit int) ([]string, error) {
+	return r.dao.QueryActiveUsers(ctx, since, afterUUID, limit)
+}
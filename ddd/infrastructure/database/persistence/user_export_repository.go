@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/pkg/errno"
+)
+
+type userExportRepositoryImpl struct {
+	dao *dao.UserExportDao
+}
+
+func NewUserExportRepository() repo.UserExportRepository {
+	return &userExportRepositoryImpl{dao: dao.NewUserExportDao()}
+}
+
+func (r *userExportRepositoryImpl) CreateExport(ctx context.Context, export *po.UserExportPo) error {
+	return r.dao.Create(ctx, export)
+}
+
+func (r *userExportRepositoryImpl) GetExportByUUID(ctx context.Context, exportUUID string) (*po.UserExportPo, error) {
+	export, err := r.dao.QueryByExportUUID(ctx, exportUUID)
+	if err != nil {
+		return nil, err
+	}
+	if export == nil {
+		return nil, errno.ErrExportNotFound
+	}
+	return export, nil
+}
+
+// GetActiveExport 返回进行中的导出任务，不存在时返回 nil
+func (r *userExportRepositoryImpl) GetActiveExport(ctx context.Context, userUUID string) (*po.UserExportPo, error) {
+	return r.dao.QueryActiveByUser(ctx, userUUID)
+}
+
+func (r *userExportRepositoryImpl) ListPending(ctx context.Context, limit int) ([]*po.UserExportPo, error) {
+	return r.dao.QueryPending(ctx, limit)
+}
+
+func (r *userExportRepositoryImpl) ListExpired(ctx context.Context, now time.Time, limit int) ([]*po.UserExportPo, error) {
+	return r.dao.QueryExpired(ctx, now, limit)
+}
+
+func (r *userExportRepositoryImpl) TransitStatus(ctx context.Context, id uint64, from, to string, fields map[string]interface{}) (bool, error) {
+	return r.dao.TransitStatus(ctx, id, from, to, fields)
+}
+
+func (r *userExportRepositoryImpl) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	return r.dao.RequeueStale(ctx, before)
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/cache"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/ddd/infrastructure/database/po"
+	"user-service/pkg/errno"
+
+	"gorm.io/gorm"
+)
+
+type userHandleRepositoryImpl struct {
+	dao   *dao.UserHandleDao
+	cache *cache.UserCache
+}
+
+func NewUserHandleRepository() repo.UserHandleRepository {
+	return &userHandleRepositoryImpl{dao: dao.NewUserHandleDao(), cache: newUserCache()}
+}
+
+func (r *userHandleRepositoryImpl) GetUserByHandle(ctx context.Context, handle string) (*po.UserPo, error) {
+	return r.dao.QueryUserByHandle(ctx, handle)
+}
+
+func (r *userHandleRepositoryImpl) GetActiveHistory(ctx context.Context, handle string, now time.Time) (*po.UserHandleHistoryPo, error) {
+	return r.dao.QueryActiveHistory(ctx, handle, now)
+}
+
+func (r *userHandleRepositoryImpl) ChangeHandle(ctx context.Context, userUUID string, version uint64, oldHandle, newHandle string, now, redirectUntil time.Time, events ...*po.EventOutboxPo) error {
+	err := r.dao.ChangeHandle(ctx, userUUID, version, oldHandle, newHandle, now, redirectUntil, events...)
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		// 并发抢占同一用户名
+		return errno.ErrHandleTaken
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return errno.ErrUserNotFound
+	case errors.Is(err, dao.ErrVersionConflict):
+		return errno.ErrUserVersionConflict
+	}
+	if err != nil {
+		return err
+	}
+	invalidateUserCache(ctx, r.cache, userUUID, version+1)
+	return nil
+}
@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+type userMediaJobRepositoryImpl struct {
+	dao *dao.UserMediaJobDao
+}
+
+func NewUserMediaJobRepository() repo.UserMediaJobRepository {
+	return &userMediaJobRepositoryImpl{dao: dao.NewUserMediaJobDao()}
+}
+
+func (r *userMediaJobRepositoryImpl) CreateJob(ctx context.Context, job *po.UserMediaJobPo) error {
+	return r.dao.Create(ctx, job)
+}
+
+func (r *userMediaJobRepositoryImpl) ListDue(ctx context.Context, now time.Time, limit int) ([]*po.UserMediaJobPo, error) {
+	return r.dao.QueryDue(ctx, now, limit)
+}
+
+func (r *userMediaJobRepositoryImpl) TransitStatus(ctx context.Context, id uint64, from, to string, fields map[string]interface{}) (bool, error) {
+	return r.dao.TransitStatus(ctx, id, from, to, fields)
+}
+
+func (r *userMediaJobRepositoryImpl) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	return r.dao.RequeueStale(ctx, before)
+}
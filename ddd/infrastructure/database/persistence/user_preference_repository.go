@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"time"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/cache"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/internal/resource"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+)
+
+type userPreferenceRepositoryImpl struct {
+	dao   *dao.UserPreferenceDao
+	cache *cache.PreferenceCache
+}
+
+func NewUserPreferenceRepository() repo.UserPreferenceRepository {
+	var prefCache *cache.PreferenceCache
+	if cli := resource.DefaultRedisResource().Client(); cli != nil {
+		var ttl time.Duration
+		if cfg := config.GetGlobalConfig(); cfg != nil {
+			ttl = cfg.Preferences.CacheTTL
+		}
+		prefCache = cache.NewPreferenceCache(cli, ttl)
+	}
+	return &userPreferenceRepositoryImpl{
+		dao:   dao.NewUserPreferenceDao(),
+		cache: prefCache,
+	}
+}
+
+// GetPreferences 先读缓存，未命中的用户回源 MySQL 并回填
+func (r *userPreferenceRepositoryImpl) GetPreferences(ctx context.Context, userUUIDs []string) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string, len(userUUIDs))
+	missing := userUUIDs
+	if r.cache != nil {
+		cached, err := r.cache.MGet(ctx, userUUIDs)
+		if err != nil {
+			logger.WithContext(ctx).Warnf("get preference cache failed err=%v", err)
+		}
+		missing = missing[:0:0]
+		for _, u := range userUUIDs {
+			if prefs, ok := cached[u]; ok {
+				result[u] = prefs
+				continue
+			}
+			missing = append(missing, u)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	rows, err := r.dao.QueryByUsers(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range missing {
+		result[u] = map[string]string{}
+	}
+	for _, row := range rows {
+		result[row.UserUUID][row.PrefKey] = row.PrefValue
+	}
+	if r.cache != nil {
+		for _, u := range missing {
+			if err := r.cache.Set(ctx, u, result[u]); err != nil {
+				logger.WithContext(ctx).Warnf("set preference cache failed user=%s err=%v", u, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *userPreferenceRepositoryImpl) SavePreferences(ctx context.Context, userUUID string, set map[string]string, deleteKeys []string, replace bool) error {
+	if err := r.dao.Save(ctx, userUUID, set, deleteKeys, replace); err != nil {
+		return err
+	}
+	if r.cache != nil {
+		if err := r.cache.Delete(ctx, userUUID); err != nil {
+			logger.WithContext(ctx).Warnf("delete preference cache failed user=%s err=%v", userUUID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package persistence
+
+import (
+	"context"
+	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/database/dao"
+	"user-service/ddd/infrastructure/database/po"
+)
+
+type userPrivacyRepositoryImpl struct {
+	dao *dao.UserPrivacyDao
+}
+
+func NewUserPrivacyRepository() repo.UserPrivacyRepository {
+	return &userPrivacyRepositoryImpl{dao: dao.NewUserPrivacyDao()}
+}
+
+func (r *userPrivacyRepositoryImpl) GetPrivacy(ctx context.Context, userUUID string) (*po.UserPrivacyPo, error) {
+	return r.dao.QueryByUserUUID(ctx, userUUID)
+}
+
+func (r *userPrivacyRepositoryImpl) GetPrivacyBatch(ctx context.Context, userUUIDs []string) (map[string]*po.UserPrivacyPo, error) {
+	list, err := r.dao.QueryByUserUUIDs(ctx, userUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*po.UserPrivacyPo, len(list))
+	for _, p := range list {
+		res[p.UserUUID] = p
+	}
+	return res, nil
+}
+
+func (r *userPrivacyRepositoryImpl) SavePrivacy(ctx context.Context, setting *po.UserPrivacyPo) error {
+	return r.dao.Upsert(ctx, setting)
+}
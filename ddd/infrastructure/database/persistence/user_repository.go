@@ -2,27 +2,75 @@ package persistence
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
 	"user-service/ddd/domain/repo"
+	"user-service/ddd/infrastructure/cache"
 	"user-service/ddd/infrastructure/database/dao"
 	"user-service/ddd/infrastructure/database/po"
+	"user-service/internal/resource"
+	"user-service/pkg/config"
 	"user-service/pkg/errno"
+	"user-service/pkg/logger"
+
+	"golang.org/x/sync/singleflight"
 )
 
+// userProfileLoads 合并并发的同批次回源查询，进程内共享
+var userProfileLoads singleflight.Group
+
+// userProfileLoadTimeout 共享回源的超时。回源不继承任何一个调用方的取消，
+// 否则首个调用方断开会让同批等待的请求一起失败
+const userProfileLoadTimeout = 3 * time.Second
+
 // userRepositoryImpl 用户仓储实现
 type userRepositoryImpl struct {
 	userDao *dao.UserDao
+	cache   *cache.UserCache
 }
 
 // NewUserRepository 创建用户仓储
 func NewUserRepository() repo.UserRepository {
 	return &userRepositoryImpl{
 		userDao: dao.NewUserDao(),
+		cache:   newUserCache(),
+	}
+}
+
+// newUserCache Redis 未配置时返回 nil，读写直接走 MySQL
+func newUserCache() *cache.UserCache {
+	cli := resource.DefaultRedisResource().Client()
+	if cli == nil {
+		return nil
+	}
+	var cfg config.UserCacheConfig
+	if c := config.GetGlobalConfig(); c != nil {
+		cfg = c.UserCache
+	}
+	return cache.NewUserCache(cli, cfg.TTL, cfg.NegativeTTL)
+}
+
+// invalidateUserCache 写操作提交后以墓碑替换资料缓存，version 为写入后的行版本，
+// 并发回源读到的旧版本不会再写回；失败只记录日志，由 TTL 兜底
+func invalidateUserCache(ctx context.Context, userCache *cache.UserCache, userUUID string, version uint64) {
+	if userCache == nil {
+		return
+	}
+	if err := userCache.Invalidate(ctx, userUUID, version); err != nil {
+		logger.WithContext(ctx).Warnf("invalidate user cache failed user=%s err=%v", userUUID, err)
 	}
 }
 
 // CreateUser 创建用户
 func (r *userRepositoryImpl) CreateUser(ctx context.Context, userPo *po.UserPo) error {
-	return r.userDao.Create(ctx, userPo)
+	if err := r.userDao.Create(ctx, userPo); err != nil {
+		return err
+	}
+	// 清掉可能存在的不存在占位
+	invalidateUserCache(ctx, r.cache, userPo.UserUUID, userPo.Version)
+	return nil
 }
 
 // GetUserByAccount 根据账号获取用户
@@ -62,13 +110,26 @@ func (r *userRepositoryImpl) GetUserByID(ctx context.Context, id uint64) (*po.Us
 }
 
 // UpdateUser 更新用户
-func (r *userRepositoryImpl) UpdateUser(ctx context.Context, userPo *po.UserPo) error {
-	return r.userDao.Update(ctx, userPo)
+func (r *userRepositoryImpl) UpdateUser(ctx context.Context, userPo *po.UserPo, events ...*po.EventOutboxPo) error {
+	err := r.userDao.Update(ctx, userPo, events...)
+	if errors.Is(err, dao.ErrVersionConflict) {
+		return errno.ErrUserVersionConflict
+	}
+	if err != nil {
+		return err
+	}
+	invalidateUserCache(ctx, r.cache, userPo.UserUUID, userPo.Version)
+	return nil
 }
 
 // DeleteUser 删除用户
 func (r *userRepositoryImpl) DeleteUser(ctx context.Context, userUUID string) error {
-	return r.userDao.DeleteByUUID(ctx, userUUID)
+	if err := r.userDao.DeleteByUUID(ctx, userUUID); err != nil {
+		return err
+	}
+	// 行已不存在，墓碑过期前不接受任何回填
+	invalidateUserCache(ctx, r.cache, userUUID, cache.UnknownVersion)
+	return nil
 }
 
 // ExistsByAccount 检查账号是否存在
@@ -80,3 +141,149 @@ func (r *userRepositoryImpl) ExistsByAccount(ctx context.Context, account string
 func (r *userRepositoryImpl) ExistsByUUID(ctx context.Context, userUUID string) (bool, error) {
 	return r.userDao.ExistsByUUID(ctx, userUUID)
 }
+
+// GetUsersByUUIDs 批量查询用户
+func (r *userRepositoryImpl) GetUsersByUUIDs(ctx context.Context, userUUIDs []string) ([]*po.UserPo, error) {
+	return r.userDao.QueryByUUIDs(ctx, userUUIDs)
+}
+
+// ScanActiveUsers 分批读取未删除的用户
+func (r *userRepositoryImpl) ScanActiveUsers(ctx context.Context, afterID uint64, limit int) ([]*po.UserPo, error) {
+	return r.userDao.ScanActive(ctx, afterID, limit)
+}
+
+// GetUserProfile 读穿缓存获取单个用户资料，并发未命中经 singleflight 合并为一次查询
+func (r *userRepositoryImpl) GetUserProfile(ctx context.Context, userUUID string) (*po.UserPo, error) {
+	users, err := r.GetUserProfiles(ctx, []string{userUUID})
+	if err != nil {
+		return nil, err
+	}
+	user, ok := users[userUUID]
+	if !ok {
+		return nil, errno.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// GetUserProfiles 先批量读缓存，未命中的用户合并为一次 IN 查询回源并回填；
+// 回源结果中缺失的 UUID 写入不存在占位。返回的 PO 不含密码，只能用于展示，不能用于更新
+func (r *userRepositoryImpl) GetUserProfiles(ctx context.Context, userUUIDs []string) (map[string]*po.UserPo, error) {
+	result := make(map[string]*po.UserPo, len(userUUIDs))
+	missing := userUUIDs
+	if r.cache != nil {
+		found, absent, err := r.cache.MGet(ctx, userUUIDs)
+		if err != nil {
+			logger.WithContext(ctx).Warnf("get user cache failed err=%v", err)
+		}
+		missing = missing[:0:0]
+		for _, u := range userUUIDs {
+			if cached, ok := found[u]; ok {
+				result[u] = fromCachedUser(cached)
+				continue
+			}
+			if !absent[u] {
+				missing = append(missing, u)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	loaded, err := r.loadUserProfiles(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for u, user := range loaded {
+		result[u] = user
+	}
+	return result, nil
+}
+
+// loadUserProfiles 回源 MySQL；相同的未命中集合并发到达时只查询一次。
+// 共享回源使用独立超时，调用方取消时只放弃等待
+func (r *userRepositoryImpl) loadUserProfiles(ctx context.Context, userUUIDs []string) (map[string]*po.UserPo, error) {
+	sorted := append([]string(nil), userUUIDs...)
+	sort.Strings(sorted)
+	ch := userProfileLoads.DoChan(strings.Join(sorted, ","), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), userProfileLoadTimeout)
+		defer cancel()
+		users, err := r.userDao.QueryByUUIDs(ctx, sorted)
+		if err != nil {
+			return nil, err
+		}
+		loaded := make(map[string]*po.UserPo, len(users))
+		cached := make([]*cache.CachedUser, 0, len(users))
+		for _, user := range users {
+			item := toCachedUser(user)
+			cached = append(cached, item)
+			loaded[user.UserUUID] = fromCachedUser(item)
+		}
+		if r.cache != nil {
+			var absent []string
+			for _, u := range sorted {
+				if _, ok := loaded[u]; !ok {
+					absent = append(absent, u)
+				}
+			}
+			if err := r.cache.MSet(ctx, cached, absent); err != nil {
+				logger.WithContext(ctx).Warnf("set user cache failed err=%v", err)
+			}
+		}
+		return loaded, nil
+	})
+	var res singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-ch:
+	}
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	// 共享结果只读，按调用方拷贝一份 map
+	shared := res.Val.(map[string]*po.UserPo)
+	loaded := make(map[string]*po.UserPo, len(shared))
+	for u, user := range shared {
+		copied := *user
+		loaded[u] = &copied
+	}
+	return loaded, nil
+}
+
+func toCachedUser(user *po.UserPo) *cache.CachedUser {
+	return &cache.CachedUser{
+		Id:             user.Id,
+		UserUUID:       user.UserUUID,
+		Account:        user.Account,
+		Nickname:       user.Nickname,
+		AvatarUrl:      user.AvatarUrl,
+		Description:    user.Description,
+		CoverUrl:       user.CoverUrl,
+		Handle:         user.GetHandle(),
+		AvatarVariants: user.AvatarVariants,
+		Version:        user.Version,
+		CreatedAt:      user.CreatedAt,
+		UpdatedAt:      user.UpdatedAt,
+	}
+}
+
+func fromCachedUser(item *cache.CachedUser) *po.UserPo {
+	user := &po.UserPo{
+		UserUUID:       item.UserUUID,
+		Account:        item.Account,
+		Nickname:       item.Nickname,
+		AvatarUrl:      item.AvatarUrl,
+		Description:    item.Description,
+		CoverUrl:       item.CoverUrl,
+		AvatarVariants: item.AvatarVariants,
+		Version:        item.Version,
+	}
+	user.Id = item.Id
+	user.CreatedAt = item.CreatedAt
+	user.UpdatedAt = item.UpdatedAt
+	if item.Handle != "" {
+		handle := item.Handle
+		user.Handle = &handle
+	}
+	return user
+}
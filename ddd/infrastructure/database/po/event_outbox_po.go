@@ -0,0 +1,20 @@
+package po
+
+import "time"
+
+// EventOutboxPo 待发布的领域事件，与业务数据在同一事务内写入，由中继组件投递到 Kafka
+type EventOutboxPo struct {
+	BaseModel
+	EventID  string     `gorm:"column:event_id"`
+	Topic    string     `gorm:"column:topic"`
+	MsgKey   string     `gorm:"column:msg_key"`
+	Payload  string     `gorm:"column:payload"`
+	Status   string     `gorm:"column:status"`
+	Attempts int        `gorm:"column:attempts"`
+	ErrorMsg string     `gorm:"column:error_msg"`
+	SentAt   *time.Time `gorm:"column:sent_at"`
+}
+
+func (EventOutboxPo) TableName() string {
+	return "event_outbox"
+}
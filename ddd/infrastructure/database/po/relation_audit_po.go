@@ -0,0 +1,20 @@
+package po
+
+import "time"
+
+// RelationAuditPo 关系操作审计（如移除粉丝），与安全事件分开存放，只追加不修改。
+// UserUUID 为操作人，TargetUUID 为被操作的用户
+type RelationAuditPo struct {
+	Id         uint64    `gorm:"primary_key;AUTO_INCREMENT;column:id"`
+	UserUUID   string    `gorm:"column:user_uuid"`
+	TargetUUID string    `gorm:"column:target_uuid"`
+	Action     string    `gorm:"column:action"`
+	IP         string    `gorm:"column:ip"`
+	UserAgent  string    `gorm:"column:user_agent"`
+	RequestID  string    `gorm:"column:request_id"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+func (RelationAuditPo) TableName() string {
+	return "user_relation_audit"
+}
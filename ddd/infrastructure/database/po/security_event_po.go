@@ -0,0 +1,20 @@
+package po
+
+import "time"
+
+// SecurityEventPo 安全审计事件（登录、刷新、登出、改密等），只追加不修改
+type SecurityEventPo struct {
+	Id        uint64    `gorm:"primary_key;AUTO_INCREMENT;column:id"`
+	UserUUID  string    `gorm:"column:user_uuid"`
+	EventType string    `gorm:"column:event_type"`
+	Result    string    `gorm:"column:result"`
+	IP        string    `gorm:"column:ip"`
+	UserAgent string    `gorm:"column:user_agent"`
+	RequestID string    `gorm:"column:request_id"`
+	Metadata  string    `gorm:"column:metadata;type:json"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (SecurityEventPo) TableName() string {
+	return "user_security_event"
+}
@@ -0,0 +1,13 @@
+package po
+
+// UserBlockPo 用户拉黑/屏蔽关系，UserUUID 为操作人，TargetUUID 为被拉黑/屏蔽的用户
+type UserBlockPo struct {
+	BaseModel
+	UserUUID   string `gorm:"column:user_uuid"`
+	TargetUUID string `gorm:"column:target_uuid"`
+	Kind       string `gorm:"column:kind"` // block / mute
+}
+
+func (UserBlockPo) TableName() string {
+	return "user_block"
+}
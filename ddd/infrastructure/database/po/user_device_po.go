@@ -0,0 +1,18 @@
+package po
+
+import "time"
+
+// UserDevicePo 用户登录过的设备
+type UserDevicePo struct {
+	BaseModel
+	UserUUID   string    `gorm:"column:user_uuid"`
+	DeviceID   string    `gorm:"column:device_id"`
+	UserAgent  string    `gorm:"column:user_agent"`
+	LastIP     string    `gorm:"column:last_ip"`
+	LastRegion string    `gorm:"column:last_region"`
+	LastSeenAt time.Time `gorm:"column:last_seen_at"`
+}
+
+func (UserDevicePo) TableName() string {
+	return "user_device"
+}
@@ -0,0 +1,21 @@
+package po
+
+import "time"
+
+// UserExportPo 个人数据导出任务
+type UserExportPo struct {
+	BaseModel
+	ExportUUID string     `gorm:"column:export_uuid"`
+	UserUUID   string     `gorm:"column:user_uuid"`
+	Status     string     `gorm:"column:status"`
+	ObjectKey  string     `gorm:"column:object_key"`
+	FileSize   int64      `gorm:"column:file_size"`
+	Attempts   int        `gorm:"column:attempts"`
+	ErrorMsg   string     `gorm:"column:error_msg"`
+	FinishedAt *time.Time `gorm:"column:finished_at"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at"`
+}
+
+func (UserExportPo) TableName() string {
+	return "user_export"
+}
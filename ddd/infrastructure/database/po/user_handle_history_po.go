@@ -0,0 +1,15 @@
+package po
+
+import "time"
+
+// UserHandleHistoryPo 用户曾用的公开用户名，保留期内旧用户名跳转到当前用户且不可被他人占用
+type UserHandleHistoryPo struct {
+	BaseModel
+	UserUUID      string    `gorm:"column:user_uuid"`
+	Handle        string    `gorm:"column:handle"`
+	RedirectUntil time.Time `gorm:"column:redirect_until"`
+}
+
+func (UserHandleHistoryPo) TableName() string {
+	return "user_handle_history"
+}
@@ -0,0 +1,18 @@
+package po
+
+import "time"
+
+// UserMediaJobPo 头像处理任务
+type UserMediaJobPo struct {
+	BaseModel
+	UserUUID  string    `gorm:"column:user_uuid"`
+	ObjectKey string    `gorm:"column:object_key"`
+	Status    string    `gorm:"column:status"`
+	Attempts  int       `gorm:"column:attempts"`
+	ErrorMsg  string    `gorm:"column:error_msg"`
+	NextRunAt time.Time `gorm:"column:next_run_at"`
+}
+
+func (UserMediaJobPo) TableName() string {
+	return "user_media_job"
+}
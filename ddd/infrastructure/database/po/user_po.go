@@ -1,5 +1,7 @@
 package po
 
+import "time"
+
 type UserPo struct {
 	BaseModel
 	UserUUID    string `gorm:"column:user_uuid" json:"user_uuid"`
@@ -9,6 +11,21 @@ type UserPo struct {
 	AvatarUrl   string `gorm:"column:avatar_url;type:varchar(512)" json:"avatar_url"`
 	Description string `gorm:"column:description;type:varchar(512)" json:"description"`
 	CoverUrl    string `gorm:"column:cover_url;type:varchar(512)" json:"cover_url"`
+	// Handle 公开用户名（@handle），大小写不敏感唯一；未设置时为 NULL
+	Handle          *string    `gorm:"column:handle;type:varchar(32)" json:"handle"`
+	HandleChangedAt *time.Time `gorm:"column:handle_changed_at" json:"handle_changed_at"`
+	// AvatarVariants 已生成的头像尺寸，逗号分隔（如 "64,256,512"），头像变更时清空
+	AvatarVariants string `gorm:"column:avatar_variants;type:varchar(64)" json:"avatar_variants"`
+	// Version 乐观锁版本号，每次更新递增
+	Version uint64 `gorm:"column:version" json:"version"`
+}
+
+// GetHandle 返回公开用户名，未设置时为空串
+func (u *UserPo) GetHandle() string {
+	if u == nil || u.Handle == nil {
+		return ""
+	}
+	return *u.Handle
 }
 
 func (UserPo) TableName() string {
@@ -0,0 +1,13 @@
+package po
+
+// UserPreferencePo 用户偏好设置，每个偏好项一行，仅保存用户显式设置过的值
+type UserPreferencePo struct {
+	BaseModel
+	UserUUID  string `gorm:"column:user_uuid"`
+	PrefKey   string `gorm:"column:pref_key"`
+	PrefValue string `gorm:"column:pref_value"` // JSON 编码的值
+}
+
+func (UserPreferencePo) TableName() string {
+	return "user_preference"
+}
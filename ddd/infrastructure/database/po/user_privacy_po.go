@@ -0,0 +1,16 @@
+package po
+
+// UserPrivacyPo 用户隐私设置，未设置过的用户没有记录，按默认值（全部公开）处理
+type UserPrivacyPo struct {
+	BaseModel
+	UserUUID          string `gorm:"column:user_uuid"`
+	PrivateAccount    bool   `gorm:"column:private_account"`
+	HideFollowerList  bool   `gorm:"column:hide_follower_list"`
+	HideFollowingList bool   `gorm:"column:hide_following_list"`
+	HideCounts        bool   `gorm:"column:hide_counts"`
+	ProfileVisibility string `gorm:"column:profile_visibility"`
+}
+
+func (UserPrivacyPo) TableName() string {
+	return "user_privacy_setting"
+}
@@ -0,0 +1,12 @@
+package po
+
+// UserRecommendDismissalPo 用户在推荐关注中忽略的账号，UserUUID 为操作人，TargetUUID 为被忽略的用户
+type UserRecommendDismissalPo struct {
+	BaseModel
+	UserUUID   string `gorm:"column:user_uuid"`
+	TargetUUID string `gorm:"column:target_uuid"`
+}
+
+func (UserRecommendDismissalPo) TableName() string {
+	return "user_recommend_dismissal"
+}
@@ -1,51 +1,21 @@
 package kafka
 
-import (
-	"context"
-	"encoding/json"
-	"time"
-
-	pkgkafka "user-service/pkg/kafka"
-	"user-service/pkg/logger"
-)
-
 const (
 	// FollowOpFollow represents a follow operation.
 	FollowOpFollow = "follow"
 	// FollowOpUnfollow represents an unfollow operation.
 	FollowOpUnfollow = "unfollow"
+	// FollowReasonRemovedByTarget marks an unfollow done by the target removing the follower.
+	FollowReasonRemovedByTarget = "removed_by_target"
 )
 
-// FollowEvent is the payload sent to Kafka for follow/unfollow commands.
+// FollowEvent is the payload of follow/unfollow commands (user.follow.events)
+// and of persisted follow facts (user.follow.changed, which also sets EventID).
 type FollowEvent struct {
+	EventID    string `json:"event_id,omitempty"`
 	UserUUID   string `json:"user_uuid"`
 	TargetUUID string `json:"target_uuid"`
 	Op         string `json:"op"`
-	TS         int64  `json:"ts"` // unix millis
-}
-
-// PublishFollowEvent sends a follow/unfollow command to Kafka.
-// If Kafka is disabled or not configured, it returns a non-nil error so callers can fall back.
-func PublishFollowEvent(ctx context.Context, op, userUUID, targetUUID string) error {
-	ev := FollowEvent{
-		UserUUID:   userUUID,
-		TargetUUID: targetUUID,
-		Op:         op,
-		TS:         time.Now().UnixMilli(),
-	}
-	data, err := json.Marshal(&ev)
-	if err != nil {
-		logger.WithContext(ctx).Errorf("PublishFollowEvent marshal failed op=%s user=%s target=%s err=%v", op, userUUID, targetUUID, err)
-		return err
-	}
-	key := []byte(targetUUID)
-	if len(key) == 0 {
-		key = []byte(userUUID)
-	}
-	if err := pkgkafka.DefaultClient().Produce(ctx, pkgkafka.FollowEventsTopic, key, data); err != nil {
-		logger.WithContext(ctx).Warnf("PublishFollowEvent produce failed op=%s user=%s target=%s err=%v", op, userUUID, targetUUID, err)
-		return err
-	}
-	logger.WithContext(ctx).Infof("PublishFollowEvent success op=%s user=%s target=%s", op, userUUID, targetUUID)
-	return nil
+	Reason     string `json:"reason,omitempty"` // empty when the follower acted themselves
+	TS         int64  `json:"ts"`               // unix millis
 }
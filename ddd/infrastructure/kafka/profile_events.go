@@ -0,0 +1,35 @@
+package kafka
+
+// ProfileChangedSchemaVersion is bumped on incompatible payload changes.
+// Consumers should ignore events with a version they do not understand.
+const ProfileChangedSchemaVersion = 1
+
+const (
+	// ProfileChangeSourceProfile marks edits made by the user (nickname, avatar, bio ...).
+	ProfileChangeSourceProfile = "profile"
+	// ProfileChangeSourceHandle marks a @handle change.
+	ProfileChangeSourceHandle = "handle"
+	// ProfileChangeSourceAccount marks account level changes (account name, status).
+	ProfileChangeSourceAccount = "account"
+	// ProfileChangeSourceAvatar marks avatar variants written by the background processor.
+	ProfileChangeSourceAvatar = "avatar"
+)
+
+// FieldChange holds the value of a field before and after the change.
+type FieldChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// ProfileChangedEvent is the payload of user.profile.changed. Only changed fields
+// appear in Changes. Version is the profile version after the change; consumers
+// can drop events whose version is not newer than what they already hold.
+type ProfileChangedEvent struct {
+	SchemaVersion int                    `json:"schema_version"`
+	EventID       string                 `json:"event_id"`
+	UserUUID      string                 `json:"user_uuid"`
+	Version       uint64                 `json:"version"`
+	Source        string                 `json:"source"`
+	Changes       map[string]FieldChange `json:"changes"`
+	TS            int64                  `json:"ts"` // unix millis
+}
@@ -0,0 +1,12 @@
+package kafka
+
+import (
+	"context"
+
+	pkgkafka "user-service/pkg/kafka"
+)
+
+// Publish sends an already encoded payload, used by the outbox relay.
+func Publish(ctx context.Context, topic, key string, payload []byte) error {
+	return pkgkafka.DefaultClient().Produce(ctx, topic, []byte(key), payload)
+}
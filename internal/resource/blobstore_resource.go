@@ -0,0 +1,37 @@
+package resource
+
+import (
+	"user-service/pkg/blobstore"
+	"user-service/pkg/config"
+	"user-service/pkg/manager"
+)
+
+// BlobStoreResource 初始化全局对象存储（导出包等内部文件）
+type BlobStoreResource struct{}
+
+// BlobStoreResourcePlugin 对象存储资源插件
+type BlobStoreResourcePlugin struct{}
+
+func (p *BlobStoreResourcePlugin) Name() string { return "blobStore" }
+
+func (p *BlobStoreResourcePlugin) MustCreateResource() manager.Resource { return &BlobStoreResource{} }
+
+// MustOpen 根据配置的 driver 创建存储实现
+func (r *BlobStoreResource) MustOpen() {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		panic("global config not initialized")
+	}
+	switch cfg.BlobStore.Driver {
+	case "local":
+		store, err := blobstore.NewLocalStore(cfg.BlobStore.LocalDir)
+		if err != nil {
+			panic("failed to open blob store: " + err.Error())
+		}
+		blobstore.Init(store)
+	default:
+		panic("unsupported blob store driver: " + cfg.BlobStore.Driver)
+	}
+}
+
+func (r *BlobStoreResource) Close() {}
@@ -0,0 +1,34 @@
+package resource
+
+import (
+	"user-service/pkg/config"
+	"user-service/pkg/georegion"
+	"user-service/pkg/logger"
+	"user-service/pkg/manager"
+)
+
+// GeoRegionResource 加载 IP 地区库，供登录风险检测使用
+type GeoRegionResource struct{}
+
+// GeoRegionResourcePlugin IP 地区库资源插件
+type GeoRegionResourcePlugin struct{}
+
+func (p *GeoRegionResourcePlugin) Name() string { return "geoRegion" }
+
+func (p *GeoRegionResourcePlugin) MustCreateResource() manager.Resource { return &GeoRegionResource{} }
+
+// MustOpen 地区库缺失或损坏时仅告警，不影响启动（退化为不做地区判断）
+func (r *GeoRegionResource) MustOpen() {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil || !cfg.LoginRisk.Enabled || cfg.LoginRisk.IPRegionDBPath == "" {
+		return
+	}
+	resolver, err := georegion.LoadCSV(cfg.LoginRisk.IPRegionDBPath)
+	if err != nil {
+		logger.Warnf("load ip region db failed path=%s err=%v", cfg.LoginRisk.IPRegionDBPath, err)
+		return
+	}
+	georegion.Init(resolver)
+}
+
+func (r *GeoRegionResource) Close() {}
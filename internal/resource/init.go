@@ -17,4 +17,19 @@ func init() {
 
 	// 注册Kafka资源插件（用于关注事件异步化等场景）
 	manager.RegisterResourcePlugin(&KafkaResourcePlugin{})
+
+	// 注册对象存储资源插件（数据导出包等）
+	manager.RegisterResourcePlugin(&BlobStoreResourcePlugin{})
+
+	// 注册MinIO资源插件（头像、封面上传）
+	manager.RegisterResourcePlugin(&MinioResourcePlugin{})
+
+	// 注册IP地区库资源插件（登录风险检测）
+	manager.RegisterResourcePlugin(&GeoRegionResourcePlugin{})
+
+	// 注册敏感词资源插件（昵称、简介内容过滤）
+	manager.RegisterResourcePlugin(&ModerationResourcePlugin{})
+
+	// 注册用户搜索资源插件
+	manager.RegisterResourcePlugin(&SearchResourcePlugin{})
 }
@@ -22,8 +22,10 @@ func (r *KafkaResource) MustOpen() {
 		return
 	}
 	kafka.DefaultClient().MustOpen()
-	// Ensure follow events topic exists; ignore error in dev environments.
+	// Ensure topics exist; ignore error in dev environments.
 	_ = kafka.DefaultClient().EnsureTopic(kafka.FollowEventsTopic, 3, 1)
+	_ = kafka.DefaultClient().EnsureTopic(kafka.ProfileChangedTopic, 3, 1)
+	_ = kafka.DefaultClient().EnsureTopic(kafka.FollowChangedTopic, 3, 1)
 }
 
 func (r *KafkaResource) Close() {
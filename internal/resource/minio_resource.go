@@ -3,7 +3,10 @@ package resource
 import (
 	"sync"
 	"user-service/pkg/assert"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
 	"user-service/pkg/manager"
+	"user-service/pkg/s3"
 )
 
 var (
@@ -11,9 +14,9 @@ var (
 	singletonMinioResource *MinioResource
 )
 
-// MinioResource MinIO资源管理器（简化版）
+// MinioResource S3 兼容对象存储资源（MinIO / AWS S3），用于头像、封面等用户上传文件
 type MinioResource struct {
-	// TODO: 实现MinIO客户端
+	client *s3.Client
 }
 
 // DefaultMinioResource 获取MinIO资源单例
@@ -26,14 +29,33 @@ func DefaultMinioResource() *MinioResource {
 	return singletonMinioResource
 }
 
-// MustOpen 打开MinIO连接
+// MustOpen 根据 minio 配置创建客户端；未配置 endpoint 时跳过，上传相关接口返回错误
 func (r *MinioResource) MustOpen() {
-	// TODO: 实现MinIO连接逻辑
+	cfg := config.GetGlobalConfig()
+	if cfg == nil || cfg.Minio.Endpoint == "" {
+		logger.Warn("minio endpoint not configured, object storage disabled")
+		return
+	}
+	client, err := s3.NewClient(s3.Config{
+		Endpoint:  cfg.Minio.Endpoint,
+		AccessKey: cfg.Minio.AccessKeyID,
+		SecretKey: cfg.Minio.SecretAccessKey,
+		UseSSL:    cfg.Minio.UseSSL,
+		Bucket:    cfg.Minio.BucketName,
+		Region:    cfg.Minio.Region,
+	})
+	if err != nil {
+		panic("failed to create minio client: " + err.Error())
+	}
+	r.client = client
 }
 
-// Close 关闭MinIO连接
-func (r *MinioResource) Close() {
-	// TODO: 实现MinIO关闭逻辑
+// Close 关闭MinIO连接（HTTP 客户端无需显式关闭）
+func (r *MinioResource) Close() {}
+
+// Client 返回 S3 客户端，未配置时为 nil
+func (r *MinioResource) Client() *s3.Client {
+	return r.client
 }
 
 // MinioResourcePlugin MinIO资源插件
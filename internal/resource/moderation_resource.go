@@ -0,0 +1,72 @@
+package resource
+
+import (
+	"errors"
+	"sync"
+	"user-service/pkg/assert"
+	"user-service/pkg/config"
+	"user-service/pkg/logger"
+	"user-service/pkg/manager"
+	"user-service/pkg/moderation"
+)
+
+var (
+	moderationOnce              sync.Once
+	singletonModerationResource *ModerationResource
+)
+
+// ModerationResource 敏感词词表资源，启动时加载并按配置间隔热加载
+type ModerationResource struct {
+	reloader *moderation.Reloader
+}
+
+// DefaultModerationResource 获取敏感词资源单例
+func DefaultModerationResource() *ModerationResource {
+	assert.NotCircular()
+	moderationOnce.Do(func() {
+		singletonModerationResource = &ModerationResource{}
+	})
+	assert.NotNil(singletonModerationResource)
+	return singletonModerationResource
+}
+
+// MustOpen 词表缺失或读取失败时仅告警，不影响启动（退化为不过滤）
+func (r *ModerationResource) MustOpen() {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil || !cfg.Moderation.Enabled || cfg.Moderation.WordListPath == "" {
+		return
+	}
+	r.reloader = moderation.NewReloader(cfg.Moderation.WordListPath, cfg.Moderation.ReloadInterval)
+	if err := r.reloader.Load(); err != nil {
+		logger.Warnf("load moderation word list failed path=%s err=%v", cfg.Moderation.WordListPath, err)
+	}
+	r.reloader.Start()
+}
+
+// Close 停止热加载
+func (r *ModerationResource) Close() {
+	if r.reloader != nil {
+		r.reloader.Stop()
+	}
+}
+
+// Reload 立即重新加载词表
+func (r *ModerationResource) Reload() error {
+	if r.reloader == nil {
+		return errors.New("moderation not enabled")
+	}
+	return r.reloader.Load()
+}
+
+// ModerationResourcePlugin 敏感词资源插件
+type ModerationResourcePlugin struct{}
+
+// Name 返回插件名称
+func (p *ModerationResourcePlugin) Name() string {
+	return "moderation"
+}
+
+// MustCreateResource 创建敏感词资源
+func (p *ModerationResourcePlugin) MustCreateResource() manager.Resource {
+	return DefaultModerationResource()
+}
@@ -0,0 +1,62 @@
+package resource
+
+import (
+	"sync"
+	"user-service/pkg/assert"
+	"user-service/pkg/config"
+	"user-service/pkg/manager"
+	"user-service/pkg/usersearch"
+)
+
+var (
+	searchOnce              sync.Once
+	singletonSearchResource *SearchResource
+)
+
+// SearchResource 用户搜索后端
+type SearchResource struct {
+	backend usersearch.Backend
+}
+
+// DefaultSearchResource 获取搜索资源单例
+func DefaultSearchResource() *SearchResource {
+	assert.NotCircular()
+	searchOnce.Do(func() {
+		singletonSearchResource = &SearchResource{}
+	})
+	assert.NotNil(singletonSearchResource)
+	return singletonSearchResource
+}
+
+// MustOpen 按配置创建搜索后端，后端名称未注册时 panic
+func (r *SearchResource) MustOpen() {
+	name := "memory"
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Search.Backend != "" {
+		name = cfg.Search.Backend
+	}
+	backend, err := usersearch.Open(name)
+	if err != nil {
+		panic("failed to open search backend: " + err.Error())
+	}
+	r.backend = backend
+}
+
+func (r *SearchResource) Close() {}
+
+// Backend 返回搜索后端，未初始化时为 nil
+func (r *SearchResource) Backend() usersearch.Backend {
+	return r.backend
+}
+
+// SearchResourcePlugin 搜索资源插件
+type SearchResourcePlugin struct{}
+
+// Name 返回插件名称
+func (p *SearchResourcePlugin) Name() string {
+	return "search"
+}
+
+// MustCreateResource 创建搜索资源
+func (p *SearchResourcePlugin) MustCreateResource() manager.Resource {
+	return DefaultSearchResource()
+}
@@ -0,0 +1,33 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = errors.New("blob not found")
+
+// Store 可插拔的对象存储，key 使用 "/" 分隔的相对路径（如 exports/<uuid>.zip）
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+var (
+	once     sync.Once
+	instance Store
+)
+
+func DefaultStore() Store {
+	return instance
+}
+
+func Init(store Store) {
+	once.Do(func() {
+		instance = store
+	})
+}
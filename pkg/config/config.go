@@ -18,6 +18,19 @@ type Config struct {
 	Minio           MinioConfig           `mapstructure:"minio"`
 	GRPC            GRPCConfig            `mapstructure:"grpc"`
 	ServiceRegistry ServiceRegistryConfig `mapstructure:"service_registry"`
+	BlobStore       BlobStoreConfig       `mapstructure:"blob_store"`
+	Export          ExportConfig          `mapstructure:"export"`
+	LoginRisk       LoginRiskConfig       `mapstructure:"login_risk"`
+	Handle          HandleConfig          `mapstructure:"handle"`
+	Profile         ProfileConfig         `mapstructure:"profile"`
+	Moderation      ModerationConfig      `mapstructure:"moderation"`
+	Preferences     PreferencesConfig     `mapstructure:"preferences"`
+	Search          SearchConfig          `mapstructure:"search"`
+	UserCache       UserCacheConfig       `mapstructure:"user_cache"`
+	FollowCache     FollowCacheConfig     `mapstructure:"follow_cache"`
+	Outbox          OutboxConfig          `mapstructure:"outbox"`
+	Recommend       RecommendConfig       `mapstructure:"recommend"`
+	ServiceAuth     ServiceAuthConfig     `mapstructure:"service_auth"`
 }
 
 // ServerConfig 服务器配置
@@ -27,6 +40,9 @@ type ServerConfig struct {
 	Mode         string        `mapstructure:"mode"`
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	// TrustedProxies 可信代理（入口网关）的 IP 或 CIDR，只有来自这些地址的 X-Forwarded-For / X-Real-IP 才被采信；
+	// 为空时不信任任何代理，客户端 IP 取 TCP 对端地址
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 // DatabaseConfig 数据库配置
@@ -65,6 +81,7 @@ type MinioConfig struct {
 	SecretAccessKey string `mapstructure:"secret_access_key"`
 	UseSSL          bool   `mapstructure:"use_ssl"`
 	BucketName      string `mapstructure:"bucket_name"`
+	Region          string `mapstructure:"region"`
 }
 
 // JWTConfig JWT配置
@@ -109,6 +126,129 @@ type ServiceRegistryConfig struct {
 	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
 }
 
+// BlobStoreConfig 文件存储配置（导出包等内部文件）
+type BlobStoreConfig struct {
+	Driver   string `mapstructure:"driver"` // local
+	LocalDir string `mapstructure:"local_dir"`
+}
+
+// ExportConfig 个人数据导出配置
+type ExportConfig struct {
+	DownloadSecret string        `mapstructure:"download_secret"` // 下载链接签名密钥，必填，未配置时导出功能不可用
+	LinkTTL        time.Duration `mapstructure:"link_ttl"`
+	Retention      time.Duration `mapstructure:"retention"`
+	PollInterval   time.Duration `mapstructure:"poll_interval"`
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+}
+
+// LoginRiskConfig 登录风险检测配置（新设备、异地登录）
+type LoginRiskConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	IPRegionDBPath string        `mapstructure:"ip_region_db_path"` // 本地 CSV 地区库，为空则不做地区判断
+	TravelWindow   time.Duration `mapstructure:"travel_window"`     // 该时间窗内地区变化视为可疑
+	// DeviceCookieSecure 设备标识 Cookie 是否只在 HTTPS 下发送，未配置时仅 debug 模式为 false
+	DeviceCookieSecure *bool `mapstructure:"device_cookie_secure"`
+}
+
+// HandleConfig 公开用户名（@handle）配置
+type HandleConfig struct {
+	ChangeCooldown time.Duration `mapstructure:"change_cooldown"`
+	RedirectPeriod time.Duration `mapstructure:"redirect_period"`
+	Reserved       []string      `mapstructure:"reserved"`
+}
+
+// ProfileConfig 个人资料配置
+type ProfileConfig struct {
+	// AllowedURLPrefixes 头像、封面 URL 允许的前缀（对象存储 key 前缀或公开访问域名）
+	AllowedURLPrefixes []string `mapstructure:"allowed_url_prefixes"`
+	// UploadURLTTL 头像、封面预签名上传 URL 有效期
+	UploadURLTTL  time.Duration `mapstructure:"upload_url_ttl"`
+	AvatarMaxSize int64         `mapstructure:"avatar_max_size"`
+	CoverMaxSize  int64         `mapstructure:"cover_max_size"`
+	// AvatarSizes 头像处理生成的正方形尺寸
+	AvatarSizes           []int         `mapstructure:"avatar_sizes"`
+	AvatarJobPollInterval time.Duration `mapstructure:"avatar_job_poll_interval"`
+	AvatarJobMaxAttempts  int           `mapstructure:"avatar_job_max_attempts"`
+}
+
+// ModerationConfig 昵称、简介等用户文本的敏感词过滤配置
+type ModerationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// WordListPath 敏感词词表文件，每行一个词，# 开头为注释
+	WordListPath string `mapstructure:"word_list_path"`
+	// Mode 命中后的处理方式：reject 拒绝保存；mask 替换为 *（账号、用户名始终拒绝）
+	Mode string `mapstructure:"mode"`
+	// ReloadInterval 词表文件变更检查间隔，0 表示不热加载
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+// PreferencesConfig 用户偏好设置（界面语言、主题、自动播放、通知开关等）
+type PreferencesConfig struct {
+	// Schema 允许保存的偏好项，key 需为小写（配置加载时 key 会被统一转为小写）
+	Schema   map[string]PreferenceDef `mapstructure:"schema"`
+	CacheTTL time.Duration            `mapstructure:"cache_ttl"`
+}
+
+// SearchConfig 用户搜索配置
+type SearchConfig struct {
+	// Backend 搜索后端，默认 memory（进程内索引）
+	Backend string `mapstructure:"backend"`
+	// RebuildInterval 全量重建索引的间隔（刷新粉丝数排序、同步其他实例的资料变更）
+	RebuildInterval time.Duration `mapstructure:"rebuild_interval"`
+}
+
+// UserCacheConfig 用户资料缓存配置
+type UserCacheConfig struct {
+	// TTL 资料缓存有效期
+	TTL time.Duration `mapstructure:"ttl"`
+	// NegativeTTL 不存在用户的占位缓存有效期
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"`
+	// MaxBatch 单次批量查询的用户数上限
+	MaxBatch int `mapstructure:"max_batch"`
+}
+
+// FollowCacheConfig 粉丝/关注列表缓存配置
+type FollowCacheConfig struct {
+	// ListWindow 每个列表缓存最新的条数，翻页超出窗口时回源数据库
+	ListWindow int `mapstructure:"list_window"`
+	// ListTTL 列表缓存有效期
+	ListTTL time.Duration `mapstructure:"list_ttl"`
+}
+
+// OutboxConfig 事件发件箱中继配置
+type OutboxConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"` // 轮询待发布事件的间隔
+	BatchSize    int           `mapstructure:"batch_size"`    // 每轮最多投递条数
+	MaxAttempts  int           `mapstructure:"max_attempts"`  // 超过后标记为 failed，不再重试
+	Retention    time.Duration `mapstructure:"retention"`     // 已发布事件的保留时长
+	// FailedRetention failed 事件的保留时长，保留期内每小时告警一次，便于排查后手动重放
+	FailedRetention time.Duration `mapstructure:"failed_retention"`
+}
+
+// RecommendConfig 推荐关注配置
+type RecommendConfig struct {
+	RebuildInterval time.Duration `mapstructure:"rebuild_interval"` // 后台预计算推荐列表的间隔
+	ActiveWithin    time.Duration `mapstructure:"active_within"`    // 只为该时长内登录过的用户预计算
+	Size            int           `mapstructure:"size"`             // 每个用户缓存的推荐人数
+	CacheTTL        time.Duration `mapstructure:"cache_ttl"`        // 推荐列表缓存有效期，应大于预计算间隔
+}
+
+// ServiceAuthConfig 服务间 HTTP 接口鉴权：调用方用共享密钥对请求做 HMAC-SHA256 签名
+type ServiceAuthConfig struct {
+	Keys    map[string]string `mapstructure:"keys"`     // 调用方服务名（小写）-> 共享密钥，为空时服务间接口一律拒绝
+	MaxSkew time.Duration     `mapstructure:"max_skew"` // 请求时间戳允许的偏差，超出视为重放
+}
+
+// PreferenceDef 单个偏好项的类型、默认值与取值约束
+type PreferenceDef struct {
+	Type      string      `mapstructure:"type"` // bool / int / string / enum
+	Default   interface{} `mapstructure:"default"`
+	Enum      []string    `mapstructure:"enum"`       // type=enum 时的可选值
+	Min       *int64      `mapstructure:"min"`        // type=int 时的下限
+	Max       *int64      `mapstructure:"max"`        // type=int 时的上限
+	MaxLength int         `mapstructure:"max_length"` // type=string 时的最大字符数
+}
+
 // Load 加载配置
 func Load(configPath string) (*Config, error) {
 	viper.SetConfigFile(configPath)
@@ -121,6 +261,8 @@ func Load(configPath string) (*Config, error) {
 	viper.SetDefault("kafka.client_id", "user-service")
 	viper.SetDefault("kafka.group_id", "user-service-group")
 	viper.SetDefault("kafka.bootstrap_servers", []string{"localhost:29092"})
+	// 登录风险检测默认开启
+	viper.SetDefault("login_risk.enabled", true)
 
 	// 设置环境变量前缀
 	viper.SetEnvPrefix("GO_VIDEO")
@@ -150,6 +292,118 @@ func normalize(c *Config) {
 	if c.ServiceRegistry.RefreshInterval == 0 {
 		c.ServiceRegistry.RefreshInterval = 10 * time.Second
 	}
+	if c.BlobStore.Driver == "" {
+		c.BlobStore.Driver = "local"
+	}
+	if c.BlobStore.LocalDir == "" {
+		c.BlobStore.LocalDir = "data/blobs"
+	}
+	if c.Export.LinkTTL == 0 {
+		c.Export.LinkTTL = 15 * time.Minute
+	}
+	if c.Export.Retention == 0 {
+		c.Export.Retention = 72 * time.Hour
+	}
+	if c.Export.PollInterval == 0 {
+		c.Export.PollInterval = 5 * time.Second
+	}
+	if c.Export.MaxAttempts == 0 {
+		c.Export.MaxAttempts = 3
+	}
+	if c.LoginRisk.TravelWindow == 0 {
+		c.LoginRisk.TravelWindow = 2 * time.Hour
+	}
+	if c.Handle.ChangeCooldown == 0 {
+		c.Handle.ChangeCooldown = 30 * 24 * time.Hour
+	}
+	if c.Handle.RedirectPeriod == 0 {
+		c.Handle.RedirectPeriod = 90 * 24 * time.Hour
+	}
+	if len(c.Profile.AllowedURLPrefixes) == 0 {
+		c.Profile.AllowedURLPrefixes = []string{"image/avatar/", "image/cover/"}
+	}
+	if c.Profile.UploadURLTTL == 0 {
+		c.Profile.UploadURLTTL = 10 * time.Minute
+	}
+	if c.Profile.AvatarMaxSize == 0 {
+		c.Profile.AvatarMaxSize = 5 << 20
+	}
+	if c.Profile.CoverMaxSize == 0 {
+		c.Profile.CoverMaxSize = 10 << 20
+	}
+	if len(c.Profile.AvatarSizes) == 0 {
+		c.Profile.AvatarSizes = []int{64, 256, 512}
+	}
+	if c.Profile.AvatarJobPollInterval == 0 {
+		c.Profile.AvatarJobPollInterval = 3 * time.Second
+	}
+	if c.Profile.AvatarJobMaxAttempts == 0 {
+		c.Profile.AvatarJobMaxAttempts = 5
+	}
+	if c.LoginRisk.DeviceCookieSecure == nil {
+		secure := c.Server.Mode != "debug"
+		c.LoginRisk.DeviceCookieSecure = &secure
+	}
+	if c.Moderation.Mode == "" {
+		c.Moderation.Mode = "reject"
+	}
+	if c.Preferences.CacheTTL == 0 {
+		c.Preferences.CacheTTL = 30 * time.Minute
+	}
+	if c.Search.Backend == "" {
+		c.Search.Backend = "memory"
+	}
+	if c.Search.RebuildInterval == 0 {
+		c.Search.RebuildInterval = 10 * time.Minute
+	}
+	if c.UserCache.TTL == 0 {
+		c.UserCache.TTL = 10 * time.Minute
+	}
+	if c.UserCache.NegativeTTL == 0 {
+		c.UserCache.NegativeTTL = time.Minute
+	}
+	if c.UserCache.MaxBatch <= 0 {
+		c.UserCache.MaxBatch = 100
+	}
+	if c.FollowCache.ListWindow <= 0 {
+		c.FollowCache.ListWindow = 1000
+	}
+	if c.FollowCache.ListTTL == 0 {
+		c.FollowCache.ListTTL = 10 * time.Minute
+	}
+	if c.Outbox.PollInterval == 0 {
+		c.Outbox.PollInterval = time.Second
+	}
+	if c.Outbox.BatchSize <= 0 {
+		c.Outbox.BatchSize = 100
+	}
+	if c.Outbox.MaxAttempts <= 0 {
+		c.Outbox.MaxAttempts = 10
+	}
+	if c.Outbox.Retention == 0 {
+		c.Outbox.Retention = 72 * time.Hour
+	}
+	if c.Outbox.FailedRetention == 0 {
+		c.Outbox.FailedRetention = 7 * 24 * time.Hour
+	}
+	if c.Recommend.RebuildInterval == 0 {
+		c.Recommend.RebuildInterval = 30 * time.Minute
+	}
+	if c.Recommend.ActiveWithin == 0 {
+		c.Recommend.ActiveWithin = 7 * 24 * time.Hour
+	}
+	if c.Recommend.Size <= 0 {
+		c.Recommend.Size = 50
+	}
+	if c.Recommend.CacheTTL == 0 {
+		c.Recommend.CacheTTL = 2 * time.Hour
+	}
+	if len(c.Preferences.Schema) == 0 {
+		c.Preferences.Schema = defaultPreferenceSchema()
+	}
+	if c.ServiceAuth.MaxSkew <= 0 {
+		c.ServiceAuth.MaxSkew = 5 * time.Minute
+	}
 }
 
 // GetDSN 获取数据库连接字符串
@@ -175,3 +429,15 @@ type KafkaConfig struct {
 	GroupID          string   `mapstructure:"group_id"`
 	Enabled          bool     `mapstructure:"enabled"`
 }
+
+// defaultPreferenceSchema 未配置 preferences.schema 时使用的偏好项
+func defaultPreferenceSchema() map[string]PreferenceDef {
+	return map[string]PreferenceDef{
+		"language":       {Type: "enum", Default: "zh-CN", Enum: []string{"zh-CN", "en-US"}},
+		"theme":          {Type: "enum", Default: "system", Enum: []string{"system", "light", "dark"}},
+		"autoplay":       {Type: "bool", Default: true},
+		"notify_follow":  {Type: "bool", Default: true},
+		"notify_comment": {Type: "bool", Default: true},
+		"notify_like":    {Type: "bool", Default: true},
+	}
+}
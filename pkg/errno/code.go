@@ -47,4 +47,22 @@ var (
 	ErrTokenGenerate        = &Errno{Code: 30008, Message: "令牌生成失败"}
 	ErrRefreshTokenGenerate = &Errno{Code: 30009, Message: "刷新令牌生成失败"}
 	ErrFollowSelf           = &Errno{Code: 30010, Message: "不能关注自己"}
+	ErrExportNotFound       = &Errno{Code: 30011, Message: "导出任务不存在"}
+	ErrExportNotReady       = &Errno{Code: 30012, Message: "导出文件尚未生成"}
+	ErrExportLinkInvalid    = &Errno{Code: 30013, Message: "下载链接无效或已过期"}
+	ErrHandleInvalid        = &Errno{Code: 30014, Message: "用户名格式不合法"}
+	ErrHandleReserved       = &Errno{Code: 30015, Message: "用户名为保留字"}
+	ErrHandleTaken          = &Errno{Code: 30016, Message: "用户名已被占用"}
+	ErrHandleChangeTooSoon  = &Errno{Code: 30017, Message: "用户名修改过于频繁"}
+	ErrUserVersionConflict  = &Errno{Code: 30018, Message: "资料已被修改，请刷新后重试"}
+	ErrMediaTypeInvalid     = &Errno{Code: 30019, Message: "不支持的图片类型"}
+	ErrMediaTooLarge        = &Errno{Code: 30020, Message: "图片大小超出限制"}
+	ErrMediaNotUploaded     = &Errno{Code: 30021, Message: "文件尚未上传或已失效"}
+	ErrContentSensitive     = &Errno{Code: 30022, Message: "内容包含敏感词"}
+	ErrPrivacyRestricted    = &Errno{Code: 30023, Message: "对方已设置隐私保护，无权查看"}
+	ErrUserBlocked          = &Errno{Code: 30024, Message: "你与对方存在拉黑关系，无法操作"}
+	ErrBlockSelf            = &Errno{Code: 30025, Message: "不能拉黑或屏蔽自己"}
+	ErrFollowReqNotFound    = &Errno{Code: 30026, Message: "关注申请不存在或已处理"}
+	ErrNotFollower          = &Errno{Code: 30027, Message: "对方不是你的粉丝"}
+	ErrExportDisabled       = &Errno{Code: 30028, Message: "数据导出功能未启用"}
 )
@@ -77,6 +77,14 @@ func AssertBizError(err error) BizError {
 	if e, ok := err.(*BizError); ok {
 		return *e
 	}
+	if e, ok := err.(ValidationErrors); ok {
+		return BizError{
+			code:        ErrParameterInvalid.Code,
+			message:     ErrParameterInvalid.Message,
+			args:        []interface{}{strings.Join(e.Fields(), ",")},
+			originError: err,
+		}
+	}
 	return BizError{
 		originError: err,
 		code:        ErrUnknown.Code,
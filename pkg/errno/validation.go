@@ -0,0 +1,37 @@
+package errno
+
+import "strings"
+
+// FieldError 单个字段的校验失败原因
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// ValidationErrors 字段级校验错误，按 ErrParameterInvalid 返回，明细放在响应 data.field_errors 中
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	return "validation failed: " + strings.Join(v.Fields(), ",")
+}
+
+// Fields 返回校验失败的字段名
+func (v ValidationErrors) Fields() []string {
+	fields := make([]string, 0, len(v))
+	for _, fe := range v {
+		fields = append(fields, fe.Field)
+	}
+	return fields
+}
+
+func (v *ValidationErrors) Add(field, reason string) {
+	*v = append(*v, FieldError{Field: field, Reason: reason})
+}
+
+// OrNil 无错误时返回 nil，避免返回非 nil 的空切片 error
+func (v ValidationErrors) OrNil() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
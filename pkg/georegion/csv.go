@@ -0,0 +1,80 @@
+package georegion
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+)
+
+type ipRange struct {
+	start  uint32
+	end    uint32
+	region string
+}
+
+// CSVResolver 基于本地 CSV 的 IPv4 地区库，每行格式：start_ip,end_ip,region，# 开头为注释
+type CSVResolver struct {
+	ranges []ipRange
+}
+
+// LoadCSV 从文件加载地区库
+func LoadCSV(path string) (*CSVResolver, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseCSV(f)
+}
+
+// ParseCSV 解析地区库内容，区间按起始地址排序
+func ParseCSV(r io.Reader) (*CSVResolver, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+	var ranges []ipRange
+	for line := 1; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok1 := ipv4ToUint(rec[0])
+		end, ok2 := ipv4ToUint(rec[1])
+		if !ok1 || !ok2 || end < start {
+			return nil, fmt.Errorf("invalid ip range at record %d: %s-%s", line, rec[0], rec[1])
+		}
+		ranges = append(ranges, ipRange{start: start, end: end, region: strings.TrimSpace(rec[2])})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+	return &CSVResolver{ranges: ranges}, nil
+}
+
+func (r *CSVResolver) Lookup(ip string) (string, bool) {
+	v, ok := ipv4ToUint(ip)
+	if !ok {
+		return "", false
+	}
+	// 找到最后一个 start <= v 的区间
+	i := sort.Search(len(r.ranges), func(i int) bool { return r.ranges[i].start > v }) - 1
+	if i < 0 || v > r.ranges[i].end {
+		return "", false
+	}
+	return r.ranges[i].region, true
+}
+
+func ipv4ToUint(s string) (uint32, bool) {
+	ip := net.ParseIP(strings.TrimSpace(s)).To4()
+	if ip == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(ip), true
+}
@@ -0,0 +1,33 @@
+package georegion
+
+import "sync"
+
+// Resolver IP 到地区的查询接口，地区为不透明字符串（如 "CN-ZJ"），查不到时返回 false
+type Resolver interface {
+	Lookup(ip string) (string, bool)
+}
+
+// noopResolver 未配置地区库时使用，所有 IP 均视为未知
+type noopResolver struct{}
+
+func (noopResolver) Lookup(string) (string, bool) { return "", false }
+
+var (
+	mu       sync.RWMutex
+	instance Resolver = noopResolver{}
+)
+
+func DefaultResolver() Resolver {
+	mu.RLock()
+	defer mu.RUnlock()
+	return instance
+}
+
+func Init(r Resolver) {
+	if r == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	instance = r
+}
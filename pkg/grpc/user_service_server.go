@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	pb "github.com/jiangqiao2/go-video-proto/proto/user/user"
 	"user-service/ddd/application/app"
@@ -86,40 +87,39 @@ func (s *UserServiceServer) ValidateUser(ctx context.Context, req *pb.ValidateUs
 	}, nil
 }
 
-// GetUsersByUUIDs 批量获取用户信息
+// GetUsersByUUIDs 批量获取用户信息：一次读缓存/回源。
+//
+// 响应暂无结构化的缺失字段，调用方按以下约定解析 Message，proto 增加 missing_user_uuids 后改为读取该字段：
+//
+//	Found <n> users                          全部存在
+//	Found <n> users, missing <m> users: <uuid>,<uuid>,...
+//
+// 前缀与分隔符保持稳定；也可直接用请求 UUID 与 Users 做差集，两者结果一致
 func (s *UserServiceServer) GetUsersByUUIDs(ctx context.Context, req *pb.GetUsersByUUIDsRequest) (*pb.GetUsersByUUIDsResponse, error) {
 	logger.WithContext(ctx).Infof("gRPC GetUsersByUUIDs called with %d UUIDs", len(req.UserUuids))
 
-	var users []*pb.UserInfo
-	var failedUUIDs []string
-
-	// 批量查询用户信息
-	for _, uuid := range req.UserUuids {
-		userInfo, err := s.userApp.GetUserInfo(ctx, uuid)
-		if err != nil {
-			logger.WithContext(ctx).Warnf("Failed to get user info for UUID %s: %v", uuid, err)
-			failedUUIDs = append(failedUUIDs, uuid)
-			continue
-		}
+	userInfos, missing, err := s.userApp.GetUsersByUUIDs(ctx, req.UserUuids)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get users: %v", err)
+		return &pb.GetUsersByUUIDsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get users: %v", err),
+		}, nil
+	}
 
-		// 转换为gRPC响应
-		pbUser := &pb.UserInfo{
+	users := make([]*pb.UserInfo, 0, len(userInfos))
+	for _, userInfo := range userInfos {
+		users = append(users, &pb.UserInfo{
 			UserUuid:  userInfo.UserUUID,
-			UserId:    0,
-			Account:   "",
-			Email:     "",
 			Nickname:  userInfo.Nickname,
 			AvatarUrl: userInfo.AvatarUrl,
-			CreatedAt: 0,
-			UpdatedAt: 0,
-		}
-		users = append(users, pbUser)
+		})
 	}
 
-	// 构建响应消息
+	// 构建响应消息，格式见方法注释中的约定
 	message := fmt.Sprintf("Found %d users", len(users))
-	if len(failedUUIDs) > 0 {
-		message += fmt.Sprintf(", failed to find %d users", len(failedUUIDs))
+	if len(missing) > 0 {
+		message += fmt.Sprintf(", missing %d users: %s", len(missing), strings.Join(missing, ","))
 	}
 
 	return &pb.GetUsersByUUIDsResponse{
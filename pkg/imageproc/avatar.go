@@ -0,0 +1,83 @@
+package imageproc
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+
+	// 注册解码器
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	jpegQuality = 85
+	// maxPixels 解码前按头信息限制像素数，防止解压炸弹
+	maxPixels = 40_000_000
+)
+
+// SquareVariants 解码图片（JPEG/PNG/WebP），按 EXIF 方向校正后居中裁剪为正方形，
+// 生成各尺寸的 JPEG。重新编码不保留任何元数据，EXIF/GPS 等信息随之剥离。
+func SquareVariants(data []byte, sizes []int) (map[int][]byte, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("image dimensions %dx%d not allowed", cfg.Width, cfg.Height)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", format, err)
+	}
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	square := centerSquare(img)
+
+	res := make(map[int][]byte, len(sizes))
+	for _, size := range sizes {
+		if size <= 0 {
+			continue
+		}
+		dst := image.NewRGBA(image.Rect(0, 0, size, size))
+		// 透明区域铺白底，JPEG 不支持 alpha
+		draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, square, draw.Over, nil)
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, fmt.Errorf("encode %d: %w", size, err)
+		}
+		res[size] = buf.Bytes()
+	}
+	return res, nil
+}
+
+// ReadLimited 读取不超过 limit 字节的数据
+func ReadLimited(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("image exceeds %d bytes", limit)
+	}
+	return data, nil
+}
+
+// centerSquare 返回居中的最大正方形区域
+func centerSquare(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	return image.Rect(x0, y0, x0+side, y0+side)
+}
@@ -0,0 +1,132 @@
+package imageproc
+
+import (
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// jpegOrientation 从 JPEG 的 APP1/Exif 段读取方向标签（0x0112），缺失或解析失败返回 1
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		// SOS 之后是图像数据，不再有元数据段
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		segLen := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if segLen < 2 || pos+2+segLen > len(data) {
+			return 1
+		}
+		seg := data[pos+4 : pos+2+segLen]
+		if marker == 0xE1 && len(seg) > 6 && string(seg[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(seg[6:])
+		}
+		pos += 2 + segLen
+	}
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			v := int(order.Uint16(tiff[entry+8:]))
+			if v >= 1 && v <= 8 {
+				return v
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// applyOrientation 按 EXIF 方向值旋转/翻转图像，使其以正向显示。
+// 直接按行列偏移复制 Pix 中的 4 字节像素；NRGBA 保持原类型，其余格式先转为 RGBA
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dst := image.Rect(0, 0, w, h)
+	if orientation >= 5 {
+		dst = image.Rect(0, 0, h, w)
+	}
+	if src, ok := img.(*image.NRGBA); ok {
+		out := image.NewNRGBA(dst)
+		orientPix(out.Pix, out.Stride, src.Pix[src.PixOffset(b.Min.X, b.Min.Y):], src.Stride, w, h, orientation)
+		return out
+	}
+	src, ok := img.(*image.RGBA)
+	if !ok {
+		src = image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(src, src.Rect, img, b.Min, draw.Src)
+		b = src.Rect
+	}
+	out := image.NewRGBA(dst)
+	orientPix(out.Pix, out.Stride, src.Pix[src.PixOffset(b.Min.X, b.Min.Y):], src.Stride, w, h, orientation)
+	return out
+}
+
+// orientPix 源图 (x, y) 写到目标的 origin + x*stepX + y*stepY 字节处，
+// 各方向值对应的目标坐标：
+//
+//	2 水平翻转 (w-1-x, y)        3 旋转 180° (w-1-x, h-1-y)
+//	4 垂直翻转 (x, h-1-y)        5 沿主对角线翻转 (y, x)
+//	6 顺时针 90° (h-1-y, x)      7 沿副对角线翻转 (h-1-y, w-1-x)
+//	8 逆时针 90° (y, w-1-x)
+func orientPix(dst []byte, dstStride int, src []byte, srcStride, w, h, orientation int) {
+	var origin, stepX, stepY int
+	switch orientation {
+	case 2:
+		origin, stepX, stepY = (w-1)*4, -4, dstStride
+	case 3:
+		origin, stepX, stepY = (w-1)*4+(h-1)*dstStride, -4, -dstStride
+	case 4:
+		origin, stepX, stepY = (h-1)*dstStride, 4, -dstStride
+	case 5:
+		origin, stepX, stepY = 0, dstStride, 4
+	case 6:
+		origin, stepX, stepY = (h-1)*4, dstStride, -4
+	case 7:
+		origin, stepX, stepY = (h-1)*4+(w-1)*dstStride, -dstStride, -4
+	case 8:
+		origin, stepX, stepY = (w-1)*dstStride, -dstStride, 4
+	}
+	for y := 0; y < h; y++ {
+		row := src[y*srcStride : y*srcStride+w*4]
+		d := origin + y*stepY
+		for x := 0; x < w*4; x += 4 {
+			copy(dst[d:d+4], row[x:x+4])
+			d += stepX
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// buildTIFF 生成只含 IFD0 的 TIFF 头，entries 为 (tag, value) 对，值按 SHORT 存放
+func buildTIFF(order binary.ByteOrder, entries [][2]uint16) []byte {
+	var buf bytes.Buffer
+	if order == binary.LittleEndian {
+		buf.WriteString("II")
+	} else {
+		buf.WriteString("MM")
+	}
+	binary.Write(&buf, order, uint16(42))
+	binary.Write(&buf, order, uint32(8))
+	binary.Write(&buf, order, uint16(len(entries)))
+	for _, e := range entries {
+		binary.Write(&buf, order, e[0])
+		binary.Write(&buf, order, uint16(3)) // SHORT
+		binary.Write(&buf, order, uint32(1))
+		binary.Write(&buf, order, e[1])
+		binary.Write(&buf, order, uint16(0))
+	}
+	binary.Write(&buf, order, uint32(0))
+	return buf.Bytes()
+}
+
+// buildJPEG 在 SOI 后插入给定的 APP 段，再接一段最小的扫描数据
+func buildJPEG(segments ...[]byte) []byte {
+	out := []byte{0xFF, 0xD8}
+	for _, seg := range segments {
+		out = append(out, seg...)
+	}
+	return append(out, 0xFF, 0xDA, 0x00, 0x02, 0xFF, 0xD9)
+}
+
+func segment(marker byte, payload []byte) []byte {
+	seg := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+func exifSegment(tiff []byte) []byte {
+	return segment(0xE1, append([]byte("Exif\x00\x00"), tiff...))
+}
+
+func TestJpegOrientation(t *testing.T) {
+	jfif := segment(0xE0, []byte("JFIF\x00\x01\x02\x00\x00\x01\x00\x01\x00\x00"))
+	xmp := segment(0xE1, []byte("http://ns.adobe.com/xap/1.0/\x00<x/>"))
+	cases := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"little endian", buildJPEG(exifSegment(buildTIFF(binary.LittleEndian, [][2]uint16{{0x0112, 6}}))), 6},
+		{"big endian", buildJPEG(exifSegment(buildTIFF(binary.BigEndian, [][2]uint16{{0x0112, 8}}))), 8},
+		{"after other tags", buildJPEG(exifSegment(buildTIFF(binary.BigEndian, [][2]uint16{{0x010F, 1}, {0x0110, 2}, {0x0112, 3}}))), 3},
+		{"after JFIF and XMP", buildJPEG(jfif, xmp, exifSegment(buildTIFF(binary.LittleEndian, [][2]uint16{{0x0112, 5}}))), 5},
+		{"no orientation tag", buildJPEG(exifSegment(buildTIFF(binary.LittleEndian, [][2]uint16{{0x010F, 1}}))), 1},
+		{"value out of range", buildJPEG(exifSegment(buildTIFF(binary.LittleEndian, [][2]uint16{{0x0112, 9}}))), 1},
+		{"no exif", buildJPEG(jfif), 1},
+		{"exif after SOS ignored", append(buildJPEG(), exifSegment(buildTIFF(binary.LittleEndian, [][2]uint16{{0x0112, 6}}))...), 1},
+		{"not jpeg", []byte("\x89PNG\r\n\x1a\n"), 1},
+		{"empty", nil, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := jpegOrientation(tc.data); got != tc.want {
+				t.Errorf("jpegOrientation = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestJpegOrientationMalformed(t *testing.T) {
+	valid := buildJPEG(exifSegment(buildTIFF(binary.LittleEndian, [][2]uint16{{0x0112, 6}})))
+	// 任意位置截断都不应越界，且返回默认值或正确值
+	for i := 0; i < len(valid); i++ {
+		if got := jpegOrientation(valid[:i]); got != 1 && got != 6 {
+			t.Fatalf("truncated at %d: got %d", i, got)
+		}
+	}
+
+	badOffset := buildTIFF(binary.LittleEndian, [][2]uint16{{0x0112, 6}})
+	binary.LittleEndian.PutUint32(badOffset[4:], 0xFFFFFFF0)
+	hugeCount := buildTIFF(binary.BigEndian, [][2]uint16{{0x010F, 6}})
+	binary.BigEndian.PutUint16(hugeCount[8:], 0xFFFF)
+	badLength := buildJPEG(exifSegment(buildTIFF(binary.LittleEndian, [][2]uint16{{0x0112, 6}})))
+	binary.BigEndian.PutUint16(badLength[4:], 0xFFFF)
+	for name, data := range map[string][]byte{
+		"ifd offset past end":     buildJPEG(exifSegment(badOffset)),
+		"entry count past end":    buildJPEG(exifSegment(hugeCount)),
+		"segment length past end": badLength,
+		"bad byte order":          buildJPEG(exifSegment([]byte("XX\x00\x2a\x00\x00\x00\x08"))),
+		"short tiff":              buildJPEG(exifSegment([]byte("II"))),
+	} {
+		if got := jpegOrientation(data); got != 1 {
+			t.Errorf("%s: got %d, want 1", name, got)
+		}
+	}
+}
+
+func FuzzJpegOrientation(f *testing.F) {
+	f.Add(buildJPEG(exifSegment(buildTIFF(binary.LittleEndian, [][2]uint16{{0x0112, 6}}))))
+	f.Add(buildJPEG(exifSegment(buildTIFF(binary.BigEndian, [][2]uint16{{0x0112, 3}}))))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if got := jpegOrientation(data); got < 1 || got > 8 {
+			t.Fatalf("orientation %d out of range", got)
+		}
+	})
+}
+
+// referenceOrient 逐像素的参考实现，用于核对 orientPix 的偏移计算
+func referenceOrient(img image.Image, orientation int) *image.RGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dx, dy := x, y
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+func patterned(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: uint8(x * 40), G: uint8(y * 40), B: uint8(x + y), A: 255})
+		}
+	}
+	return img
+}
+
+func sameImage(t *testing.T, got image.Image, want *image.RGBA) {
+	t.Helper()
+	if got.Bounds().Size() != want.Bounds().Size() {
+		t.Fatalf("size = %v, want %v", got.Bounds().Size(), want.Bounds().Size())
+	}
+	gb, wb := got.Bounds(), want.Bounds()
+	for y := 0; y < wb.Dy(); y++ {
+		for x := 0; x < wb.Dx(); x++ {
+			g := color.RGBAModel.Convert(got.At(gb.Min.X+x, gb.Min.Y+y))
+			if w := want.At(wb.Min.X+x, wb.Min.Y+y); g != w {
+				t.Fatalf("pixel (%d,%d) = %v, want %v", x, y, g, w)
+			}
+		}
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	src := patterned(5, 3)
+	// 子图的 Min 不在原点，Pix 偏移需正确处理
+	sub := patterned(8, 6).SubImage(image.Rect(2, 1, 7, 4)).(*image.RGBA)
+	nrgba := image.NewNRGBA(src.Rect)
+	copy(nrgba.Pix, src.Pix)
+	gray := image.NewGray(src.Rect)
+	for i := range gray.Pix {
+		gray.Pix[i] = uint8(i * 13)
+	}
+	for orientation := 1; orientation <= 8; orientation++ {
+		for name, img := range map[string]image.Image{"rgba": src, "subimage": sub, "nrgba": nrgba, "gray": gray} {
+			got := applyOrientation(img, orientation)
+			sameImage(t, got, referenceOrient(img, orientation))
+			if _, ok := img.(*image.NRGBA); ok && orientation > 1 {
+				if _, keep := got.(*image.NRGBA); !keep {
+					t.Errorf("orientation %d %s: NRGBA converted to %T", orientation, name, got)
+				}
+			}
+		}
+	}
+}
+
+func TestSquareVariantsAppliesOrientation(t *testing.T) {
+	// 左半红、右半蓝的横图，方向 6 表示需顺时针旋转 90°：旋转后上红下蓝
+	src := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 64; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 32 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			src.SetRGBA(x, y, c)
+		}
+	}
+	var enc bytes.Buffer
+	if err := jpeg.Encode(&enc, src, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	plain := enc.Bytes()
+	exif := exifSegment(buildTIFF(binary.BigEndian, [][2]uint16{{0x0112, 6}}))
+	data := append(append(append([]byte{}, plain[:2]...), exif...), plain[2:]...)
+
+	out, err := SquareVariants(data, []int{16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(out[16]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	top := color.RGBAModel.Convert(img.At(8, 2)).(color.RGBA)
+	bottom := color.RGBAModel.Convert(img.At(8, 13)).(color.RGBA)
+	if top.R < 200 || top.B > 60 || bottom.B < 200 || bottom.R > 60 {
+		t.Fatalf("top = %v, bottom = %v; want red over blue", top, bottom)
+	}
+	if bytes.Contains(out[16], []byte("Exif\x00\x00")) {
+		t.Fatal("variant still carries EXIF")
+	}
+}
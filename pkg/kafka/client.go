@@ -58,6 +58,20 @@ func (c *Client) Close() {
 	})
 }
 
+// keyedTopics need per-key ordering: messages with the same key go to the same
+// partition. Other topics keep LeastBytes for even load across partitions.
+var keyedTopics = map[string]bool{
+	ProfileChangedTopic: true,
+	FollowChangedTopic:  true,
+}
+
+func balancerFor(topic string) kafka.Balancer {
+	if keyedTopics[topic] {
+		return &kafka.Hash{}
+	}
+	return &kafka.LeastBytes{}
+}
+
 // Writer returns a cached writer for a topic.
 func (c *Client) Writer(topic string) *kafka.Writer {
 	if v, ok := c.writers.Load(topic); ok {
@@ -66,7 +80,7 @@ func (c *Client) Writer(topic string) *kafka.Writer {
 	w := &kafka.Writer{
 		Addr:         kafka.TCP(c.brokers...),
 		Topic:        topic,
-		Balancer:     &kafka.LeastBytes{},
+		Balancer:     balancerFor(topic),
 		RequiredAcks: kafka.RequireAll,
 	}
 	actual, _ := c.writers.LoadOrStore(topic, w)
@@ -2,3 +2,11 @@ package kafka
 
 // FollowEventsTopic is the Kafka topic for follow/unfollow commands.
 const FollowEventsTopic = "user.follow.events"
+
+// ProfileChangedTopic carries user profile changes, keyed by user UUID so that
+// events of the same user stay in one partition and are consumed in order.
+const ProfileChangedTopic = "user.profile.changed"
+
+// FollowChangedTopic carries follow/unfollow facts after they are persisted,
+// for downstream consumers (feeds, notifications). Keyed by target user UUID.
+const FollowChangedTopic = "user.follow.changed"
@@ -5,10 +5,11 @@ import (
 	"github.com/google/uuid"
 
 	"user-service/pkg/grpcutil"
+	"user-service/pkg/reqmeta"
 	"user-service/pkg/restapi"
 )
 
-// RequestContextMiddleware 注入 request_id（及可用的 user_uuid）到上下文，并回写 X-Request-ID。
+// RequestContextMiddleware 注入 request_id、客户端 IP/UA（及可用的 user_uuid）到上下文，并回写 X-Request-ID。
 func RequestContextMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userUUID := c.GetHeader("X-User-UUID")
@@ -23,9 +24,25 @@ func RequestContextMiddleware() gin.HandlerFunc {
 		c.Set(restapi.HeaderKeyRequestId, reqID)
 
 		ctxWithReqID, _ := grpcutil.ContextWithRequestID(c.Request.Context(), reqID)
+		ctxWithReqID = reqmeta.WithClientInfo(ctxWithReqID, reqmeta.ClientInfo{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			DeviceID:  deviceIDFromRequest(c),
+		})
 		c.Request = c.Request.WithContext(ctxWithReqID)
 		c.Writer.Header().Set("X-Request-ID", reqID)
 
 		c.Next()
 	}
 }
+
+// deviceIDFromRequest 优先读取 X-Device-ID 头（App 端），其次读取 device_id Cookie（浏览器）
+func deviceIDFromRequest(c *gin.Context) string {
+	if v := c.GetHeader("X-Device-ID"); v != "" {
+		return v
+	}
+	if v, err := c.Cookie(reqmeta.DeviceCookieName); err == nil {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package moderation
+
+// automaton Aho-Corasick 多模式匹配自动机，按 rune 构建
+type automaton struct {
+	nodes []acNode
+	words []string
+}
+
+type acNode struct {
+	next map[rune]int
+	fail int
+	// out 以该节点结尾的词（含 fail 链上的词）在 words 中的下标
+	out []int
+}
+
+func newAutomaton(words []string) *automaton {
+	a := &automaton{nodes: []acNode{{next: map[rune]int{}}}}
+	for _, w := range words {
+		runes := []rune(w)
+		if len(runes) == 0 {
+			continue
+		}
+		cur := 0
+		for _, r := range runes {
+			nxt, ok := a.nodes[cur].next[r]
+			if !ok {
+				a.nodes = append(a.nodes, acNode{next: map[rune]int{}})
+				nxt = len(a.nodes) - 1
+				a.nodes[cur].next[r] = nxt
+			}
+			cur = nxt
+		}
+		a.nodes[cur].out = append(a.nodes[cur].out, len(a.words))
+		a.words = append(a.words, w)
+	}
+	a.buildFail()
+	return a
+}
+
+func (a *automaton) buildFail() {
+	queue := make([]int, 0, len(a.nodes))
+	for _, child := range a.nodes[0].next {
+		a.nodes[child].fail = 0
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range a.nodes[cur].next {
+			f := a.nodes[cur].fail
+			for f != 0 {
+				if _, ok := a.nodes[f].next[r]; ok {
+					break
+				}
+				f = a.nodes[f].fail
+			}
+			if nxt, ok := a.nodes[f].next[r]; ok && nxt != child {
+				a.nodes[child].fail = nxt
+			} else {
+				a.nodes[child].fail = 0
+			}
+			a.nodes[child].out = append(a.nodes[child].out, a.nodes[a.nodes[child].fail].out...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// match 返回命中的 (词下标, 结束位置) 列表，结束位置为 text 中最后一个 rune 的下标
+func (a *automaton) match(text []rune, fn func(word int, end int)) {
+	cur := 0
+	for i, r := range text {
+		for cur != 0 {
+			if _, ok := a.nodes[cur].next[r]; ok {
+				break
+			}
+			cur = a.nodes[cur].fail
+		}
+		if nxt, ok := a.nodes[cur].next[r]; ok {
+			cur = nxt
+		}
+		for _, w := range a.nodes[cur].out {
+			fn(w, i)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package moderation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func matches(a *automaton, text string) []string {
+	var got []string
+	a.match([]rune(text), func(w, end int) {
+		got = append(got, fmt.Sprintf("%s@%d", a.words[w], end))
+	})
+	sort.Strings(got)
+	return got
+}
+
+// naiveMatches 逐位置比较的参考实现
+func naiveMatches(words []string, text string) []string {
+	runes := []rune(text)
+	var got []string
+	for _, w := range words {
+		wr := []rune(w)
+		if len(wr) == 0 {
+			continue
+		}
+		for end := len(wr) - 1; end < len(runes); end++ {
+			if string(runes[end-len(wr)+1:end+1]) == w {
+				got = append(got, fmt.Sprintf("%s@%d", w, end))
+			}
+		}
+	}
+	sort.Strings(got)
+	return got
+}
+
+func TestAutomatonClassic(t *testing.T) {
+	a := newAutomaton([]string{"he", "she", "his", "hers"})
+	got := matches(a, "ushers")
+	want := []string{"he@3", "hers@5", "she@3"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("matches = %v, want %v", got, want)
+	}
+}
+
+func TestAutomatonAgainstNaive(t *testing.T) {
+	cases := []struct {
+		words []string
+		text  string
+	}{
+		{[]string{"a", "aa", "aaa"}, "aaaaa"},
+		{[]string{"abcd", "bc", "bcde", "c"}, "xabcdex"},
+		{[]string{"敏感", "敏感词", "感词"}, "这是敏感词汇，敏感"},
+		{[]string{"ab", "ba"}, "abababa"},
+		{[]string{"", "x"}, "xx"},
+		{[]string{"needle"}, "haystack"},
+	}
+	for _, tc := range cases {
+		a := newAutomaton(tc.words)
+		got, want := matches(a, tc.text), naiveMatches(tc.words, tc.text)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("words=%v text=%q: matches = %v, want %v", tc.words, tc.text, got, want)
+		}
+	}
+}
+
+func TestAutomatonEmpty(t *testing.T) {
+	a := newAutomaton(nil)
+	if got := matches(a, "anything"); len(got) != 0 {
+		t.Fatalf("matches = %v, want none", got)
+	}
+}
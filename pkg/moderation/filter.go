@@ -0,0 +1,131 @@
+package moderation
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Hit 一次命中，Start/End 为原文中的 rune 下标（闭区间）
+type Hit struct {
+	Word  string `json:"word"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// Filter 敏感词过滤器，构建后只读，可并发使用
+type Filter struct {
+	ac *automaton
+	// raw 归一化词条 -> 词典原词，用于展示
+	raw map[string]string
+}
+
+// NewFilter 用词条构建过滤器，词条按正文相同规则归一化
+func NewFilter(words []string) *Filter {
+	f := &Filter{raw: make(map[string]string, len(words))}
+	normalized := make([]string, 0, len(words))
+	for _, w := range words {
+		n := normalizeWord(w)
+		if n == "" {
+			continue
+		}
+		if _, dup := f.raw[n]; dup {
+			continue
+		}
+		f.raw[n] = w
+		normalized = append(normalized, n)
+	}
+	f.ac = newAutomaton(normalized)
+	return f
+}
+
+// ParseWords 读取词表：每行一个词，忽略空行和 # 开头的注释
+func ParseWords(r io.Reader) ([]string, error) {
+	var words []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words, sc.Err()
+}
+
+// LoadFile 从词表文件构建过滤器
+func LoadFile(path string) (*Filter, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	words, err := ParseWords(fh)
+	if err != nil {
+		return nil, err
+	}
+	return NewFilter(words), nil
+}
+
+// Size 词条数
+func (f *Filter) Size() int {
+	if f == nil {
+		return 0
+	}
+	return len(f.ac.words)
+}
+
+// Find 返回全部命中
+func (f *Filter) Find(text string) []Hit {
+	if f == nil || len(f.ac.words) == 0 || text == "" {
+		return nil
+	}
+	runes := []rune(text)
+	norm, index := normalize(runes)
+	var hits []Hit
+	f.ac.match(norm, func(w int, end int) {
+		word := f.ac.words[w]
+		start := end - utf8.RuneCountInString(word) + 1
+		hits = append(hits, Hit{Word: f.raw[word], Start: index[start], End: index[end]})
+	})
+	return hits
+}
+
+// Contains 是否命中任意敏感词
+func (f *Filter) Contains(text string) bool {
+	return len(f.Find(text)) > 0
+}
+
+// ContainsWord 是否有命中恰好落在单词边界上，用于账号、用户名等标识。
+// 标识没有空格分词，整串做子串匹配会误伤 mishit 这类正常拼写，
+// 因此只认首尾都在边界上的命中；汉字等无大小写的文字逐字成词，不受影响
+func (f *Filter) ContainsWord(text string) bool {
+	hits := f.Find(text)
+	if len(hits) == 0 {
+		return false
+	}
+	runes := []rune(text)
+	for _, h := range hits {
+		if wordBoundary(runes, h.Start) && wordBoundary(runes, h.End+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask 将命中片段（含其中夹杂的干扰字符）替换为 *
+func (f *Filter) Mask(text string) (string, bool) {
+	hits := f.Find(text)
+	if len(hits) == 0 {
+		return text, false
+	}
+	runes := []rune(text)
+	for _, h := range hits {
+		for i := h.Start; i <= h.End; i++ {
+			runes[i] = '*'
+		}
+	}
+	return string(runes), true
+}
@@ -0,0 +1,148 @@
+package moderation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"Hello", "hello"},
+		{"ＳＨＩＴ", "shit"},                   // 全角
+		{"$h1t", "shit"},                   // 替换写法
+		{"s h.i_t", "shit"},                // 分隔符
+		{"s\u200bh\u200di\ufefft", "shit"}, // 零宽字符
+		{"敏 感-词", "敏感词"},
+		{"２０２４", "2o2a"},
+	}
+	for _, tc := range cases {
+		if got := normalizeWord(tc.in); got != tc.want {
+			t.Errorf("normalizeWord(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeIndex(t *testing.T) {
+	norm, index := normalize([]rune("a b\u200bc"))
+	if string(norm) != "abc" {
+		t.Fatalf("norm = %q", string(norm))
+	}
+	want := []int{0, 2, 4}
+	for i, v := range want {
+		if index[i] != v {
+			t.Fatalf("index = %v, want %v", index, want)
+		}
+	}
+}
+
+func TestFilterFindAndMask(t *testing.T) {
+	f := NewFilter([]string{"shit", "敏感词", "Shit", "  "})
+	if f.Size() != 2 {
+		t.Fatalf("Size = %d, want 2 (duplicates and blanks dropped)", f.Size())
+	}
+	hits := f.Find("oh s-h-i-t, 这是敏感词")
+	if len(hits) != 2 {
+		t.Fatalf("hits = %+v", hits)
+	}
+	if hits[0].Word != "shit" || hits[0].Start != 3 || hits[0].End != 9 {
+		t.Errorf("first hit = %+v", hits[0])
+	}
+	masked, hit := f.Mask("oh s-h-i-t, 这是敏感词")
+	if !hit || masked != "oh *******, 这是***" {
+		t.Errorf("Mask = %q, %v", masked, hit)
+	}
+	if out, hit := f.Mask("clean text"); hit || out != "clean text" {
+		t.Errorf("Mask clean = %q, %v", out, hit)
+	}
+}
+
+func TestNilFilter(t *testing.T) {
+	var f *Filter
+	if f.Contains("shit") || f.ContainsWord("shit") || f.Size() != 0 {
+		t.Fatal("nil filter must match nothing")
+	}
+}
+
+func TestContainsWord(t *testing.T) {
+	f := NewFilter([]string{"shit", "ass", "敏感"})
+	cases := []struct {
+		text string
+		want bool
+	}{
+		{"shit", true},
+		{"shit_happens", true},
+		{"big.shit", true},
+		{"BigShit", true}, // 驼峰
+		{"$h1t", true},    // 整词的替换写法
+		{"s_h_i_t", true}, // 分隔符夹杂，首尾仍在边界
+		{"mishit", false}, // 词内子串
+		{"mi$hit", false}, // 替换字符不构成边界
+		{"class", false},
+		{"assassin", false},
+		{"glass_house", false},
+		{"mass1", false},
+		{"我敏感了", true}, // 汉字逐字成词
+	}
+	for _, tc := range cases {
+		if got := f.ContainsWord(tc.text); got != tc.want {
+			t.Errorf("ContainsWord(%q) = %v, want %v", tc.text, got, tc.want)
+		}
+	}
+	// 展示类字段仍按子串匹配
+	if !f.Contains("mishit") {
+		t.Error("Contains must still match substrings")
+	}
+}
+
+func TestParseWords(t *testing.T) {
+	words, err := ParseWords(strings.NewReader("# comment\n\n foo \nbar\n#baz\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(words, ",") != "foo,bar" {
+		t.Fatalf("words = %v", words)
+	}
+}
+
+func TestReloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte("foo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := Default()
+	t.Cleanup(func() { Init(old) })
+
+	r := NewReloader(path, 10*time.Millisecond)
+	if err := r.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if !Default().Contains("foo") {
+		t.Fatal("word list not loaded")
+	}
+	r.Start()
+	if err := os.WriteFile(path, []byte("foo\nbar\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	// 手动加载与定时检查并发，配合 -race 验证 modTime 的访问
+	for i := 0; i < 5; i++ {
+		_ = r.Load()
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for !Default().Contains("bar") {
+		if time.Now().After(deadline) {
+			t.Fatal("word list not reloaded")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	r.Stop()
+	r.Stop()
+}
@@ -0,0 +1,99 @@
+package moderation
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"user-service/pkg/logger"
+)
+
+var current atomic.Pointer[Filter]
+
+// Default 返回当前生效的过滤器，未初始化时返回空过滤器（不命中任何内容）
+func Default() *Filter {
+	return current.Load()
+}
+
+// Init 替换当前过滤器
+func Init(f *Filter) {
+	current.Store(f)
+}
+
+// Reloader 定期检查词表文件修改时间，变化时重新加载；加载失败保留旧词表。
+// Load 可能同时来自定时检查和手动触发，mu 保证加载串行并保护 modTime
+type Reloader struct {
+	path     string
+	interval time.Duration
+	mu       sync.Mutex
+	modTime  time.Time
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func NewReloader(path string, interval time.Duration) *Reloader {
+	return &Reloader{path: path, interval: interval, stop: make(chan struct{})}
+}
+
+// Load 立即加载一次词表
+func (r *Reloader) Load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return err
+	}
+	f, err := LoadFile(r.path)
+	if err != nil {
+		return err
+	}
+	Init(f)
+	r.modTime = info.ModTime()
+	logger.Infof("moderation word list loaded path=%s words=%d", r.path, f.Size())
+	return nil
+}
+
+// Start 启动后台热加载
+func (r *Reloader) Start() {
+	if r.interval <= 0 {
+		return
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				if !r.changed() {
+					continue
+				}
+				if err := r.Load(); err != nil {
+					logger.Warnf("reload moderation word list failed path=%s err=%v", r.path, err)
+				}
+			}
+		}
+	}()
+}
+
+// changed 词表文件是否晚于上次加载
+func (r *Reloader) changed() bool {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return info.ModTime().After(r.modTime)
+}
+
+// Stop 停止后台热加载，可重复调用
+func (r *Reloader) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+	r.wg.Wait()
+}
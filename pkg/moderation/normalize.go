@@ -0,0 +1,71 @@
+package moderation
+
+import (
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// leetMap 常见的字母数字替换写法（f4ck、$hit）
+var leetMap = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l',
+}
+
+// normalizeRune 全角转半角、转小写、还原替换字符；返回 false 表示该字符是分隔/干扰字符，匹配时跳过
+func normalizeRune(r rune) (rune, bool) {
+	if folded := []rune(width.Fold.String(string(r))); len(folded) == 1 {
+		r = folded[0]
+	}
+	if m, ok := leetMap[r]; ok {
+		return m, true
+	}
+	switch {
+	case unicode.IsLetter(r):
+		return unicode.ToLower(r), true
+	case unicode.IsDigit(r):
+		return r, true
+	}
+	// 空白、标点、符号、零宽字符等均视为插入的干扰字符
+	return 0, false
+}
+
+// wordRune 可连成单词的字符：有大小写的字母、数字及替换写法中的符号
+func wordRune(r rune) bool {
+	if _, ok := leetMap[r]; ok {
+		return true
+	}
+	return unicode.IsDigit(r) || unicode.IsUpper(r) || unicode.IsLower(r)
+}
+
+// wordBoundary text[i-1] 与 text[i] 之间是否为单词边界：首尾、分隔符两侧、
+// 无大小写的文字（如汉字）两侧，以及驼峰写法的小写到大写处
+func wordBoundary(text []rune, i int) bool {
+	if i <= 0 || i >= len(text) {
+		return true
+	}
+	prev, cur := text[i-1], text[i]
+	if !wordRune(prev) || !wordRune(cur) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// normalize 返回归一化后的 rune 序列，以及每个归一化 rune 对应的原文 rune 下标
+func normalize(text []rune) ([]rune, []int) {
+	norm := make([]rune, 0, len(text))
+	index := make([]int, 0, len(text))
+	for i, r := range text {
+		if n, ok := normalizeRune(r); ok {
+			norm = append(norm, n)
+			index = append(index, i)
+		}
+	}
+	return norm, index
+}
+
+// normalizeWord 词典词条使用与正文相同的归一化
+func normalizeWord(word string) string {
+	norm, _ := normalize([]rune(word))
+	return string(norm)
+}
@@ -68,6 +68,7 @@ func initSelfDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	}), &gorm.Config{
 		CreateBatchSize:        1000,
 		SkipDefaultTransaction: false,
+		TranslateError:         true, // 唯一键冲突等转换为 gorm.ErrDuplicatedKey
 		Logger:                 gormLogger,
 	})
 	if err != nil {
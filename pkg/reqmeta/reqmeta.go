@@ -0,0 +1,34 @@
+package reqmeta
+
+import "context"
+
+type ctxKey string
+
+const clientInfoContextKey ctxKey = "client_info"
+
+// DeviceCookieName 浏览器端设备标识 Cookie 名
+const DeviceCookieName = "device_id"
+
+// ClientInfo HTTP 请求来源信息，由 RequestContextMiddleware 注入
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+	// DeviceID 客户端设备标识（Cookie device_id 或 X-Device-ID 头），首次登录时由服务端下发
+	DeviceID string
+}
+
+// WithClientInfo 将客户端信息写入 context
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoContextKey, info)
+}
+
+// ClientInfoFromContext 读取客户端信息，非 HTTP 入口（gRPC、Kafka）返回零值
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	if ctx == nil {
+		return ClientInfo{}
+	}
+	if v, ok := ctx.Value(clientInfoContextKey).(ClientInfo); ok {
+		return v
+	}
+	return ClientInfo{}
+}
@@ -44,6 +44,10 @@ func FailedWithStatus(c *gin.Context, err error, httpStatus int) {
 
 func sendResponse(c *gin.Context, httpStatus int, data interface{}, err error) {
 	bizErr := errno.AssertBizError(err)
+	// 字段级校验错误在 data 中返回明细，便于客户端逐项提示
+	if ve, ok := err.(errno.ValidationErrors); ok && data == nil {
+		data = gin.H{"field_errors": ve}
+	}
 	c.Set("x-bizError", bizErr)
 	c.Set("x-httpStatus", httpStatus)
 	c.Writer.Header().Add("x-biz-code", strconv.Itoa(bizErr.Code()))
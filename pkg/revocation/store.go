@@ -11,8 +11,8 @@ type Store interface {
 	IncrementVersion(ctx context.Context, userUUID string) (int64, error)
 	StoreRefreshToken(ctx context.Context, userUUID string, tokenHash string, ttl time.Duration) error
 	DeleteRefreshToken(ctx context.Context, userUUID string, tokenHash string) error
-	// DeleteAllRefreshTokens 删除该用户的所有刷新令牌记录（用于简单单端登录）
-	DeleteAllRefreshTokens(ctx context.Context, userUUID string) error
+	// DeleteAllRefreshTokens 删除该用户的所有刷新令牌记录（用于简单单端登录），返回实际删除的条数
+	DeleteAllRefreshTokens(ctx context.Context, userUUID string) (int64, error)
 	ExistsRefreshToken(ctx context.Context, userUUID string, tokenHash string) (bool, error)
 }
 
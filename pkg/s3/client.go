@@ -0,0 +1,195 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = errors.New("s3 object not found")
+
+// Config S3 兼容存储连接参数（MinIO、AWS S3 等），使用 path-style 访问
+type Config struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	Bucket    string
+	Region    string
+	Timeout   time.Duration
+}
+
+// ObjectInfo HEAD 返回的对象元信息
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ETag        string
+}
+
+// Client 轻量 S3 客户端，只覆盖本服务用到的操作：预签名上传、HEAD、GET、PUT、DELETE
+type Client struct {
+	base   *url.URL
+	bucket string
+	signer *signer
+	http   *http.Client
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("s3: endpoint and bucket are required")
+	}
+	scheme := "http"
+	if cfg.UseSSL {
+		scheme = "https"
+	}
+	endpoint := cfg.Endpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = scheme + "://" + endpoint
+	}
+	base, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("s3: invalid endpoint: %w", err)
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &Client{
+		base:   base,
+		bucket: cfg.Bucket,
+		signer: &signer{accessKey: cfg.AccessKey, secretKey: cfg.SecretKey, region: region},
+		http:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Bucket 返回客户端绑定的存储桶
+func (c *Client) Bucket() string {
+	return c.bucket
+}
+
+func (c *Client) objectURL(key string) *url.URL {
+	u := *c.base
+	u.Path = strings.TrimRight(u.Path, "/") + "/" + c.bucket + "/" + strings.TrimLeft(key, "/")
+	u.RawPath = encodePath(u.Path)
+	u.RawQuery = ""
+	return &u
+}
+
+// PresignPut 生成直传 URL，客户端必须以相同 Content-Type 发起 PUT
+func (c *Client) PresignPut(key, contentType string, expires time.Duration) string {
+	headers := map[string]string{}
+	if contentType != "" {
+		headers["content-type"] = contentType
+	}
+	return c.signer.presign(http.MethodPut, c.objectURL(key), headers, expires, time.Now())
+}
+
+// PresignGet 生成限时下载 URL
+func (c *Client) PresignGet(key string, expires time.Duration) string {
+	return c.signer.presign(http.MethodGet, c.objectURL(key), nil, expires, time.Now())
+}
+
+// StatObject 查询对象元信息，不存在时返回 ErrNotFound
+func (c *Client) StatObject(ctx context.Context, key string) (*ObjectInfo, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	size, _ := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	return &ObjectInfo{
+		Key:         key,
+		Size:        size,
+		ContentType: resp.Header.Get("Content-Type"),
+		ETag:        strings.Trim(resp.Header.Get("ETag"), `"`),
+	}, nil
+}
+
+// GetObject 读取对象内容，调用方负责关闭
+func (c *Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// PutObject 上传对象
+func (c *Client) PutObject(ctx context.Context, key string, data []byte, contentType string) error {
+	resp, err := c.do(ctx, http.MethodPut, key, data, contentType)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// DeleteObject 删除对象，对象不存在不视为错误
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, key string, body []byte, contentType string) (*http.Response, error) {
+	u := c.objectURL(key)
+	var reader io.Reader
+	payloadHash := emptyPayloadSum
+	if body != nil {
+		reader = bytes.NewReader(body)
+		payloadHash = sha256Hex(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	c.signer.signRequest(req, payloadHash, time.Now())
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3: %s %s: status %d: %s", method, key, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+func formatSeconds(d time.Duration) string {
+	sec := int64(d / time.Second)
+	if sec < 1 {
+		sec = 1
+	}
+	// SigV4 预签名最长 7 天
+	if sec > 7*24*3600 {
+		sec = 7 * 24 * 3600
+	}
+	return strconv.FormatInt(sec, 10)
+}
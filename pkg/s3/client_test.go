@@ -0,0 +1,204 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"user-service/pkg/s3/s3test"
+)
+
+const (
+	testAccessKey = "test-access"
+	testSecretKey = "test-secret"
+	testBucket    = "media"
+)
+
+// verifier 按服务端视角重算签名，确认线上发出的请求与签名一致
+func verifier(s *signer) func(r *http.Request) error {
+	return func(r *http.Request) error {
+		query := r.URL.Query()
+		var signedHeaders, signature, amzDate, payloadHash string
+		if sig := query.Get("X-Amz-Signature"); sig != "" {
+			signature, signedHeaders, amzDate = sig, query.Get("X-Amz-SignedHeaders"), query.Get("X-Amz-Date")
+			payloadHash = unsignedPayload
+			query.Del("X-Amz-Signature")
+			issued, err := time.Parse(amzDateFormat, amzDate)
+			if err != nil {
+				return err
+			}
+			var expires int
+			fmt.Sscan(query.Get("X-Amz-Expires"), &expires)
+			if time.Now().After(issued.Add(time.Duration(expires) * time.Second)) {
+				return errors.New("request expired")
+			}
+		} else {
+			auth := r.Header.Get("Authorization")
+			for _, part := range strings.Split(strings.TrimPrefix(auth, signAlgorithm+" "), ", ") {
+				k, v, _ := strings.Cut(part, "=")
+				switch k {
+				case "SignedHeaders":
+					signedHeaders = v
+				case "Signature":
+					signature = v
+				}
+			}
+			amzDate = r.Header.Get("X-Amz-Date")
+			payloadHash = r.Header.Get("X-Amz-Content-Sha256")
+			body, _ := io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			if payloadHash != sha256Hex(body) {
+				return errors.New("payload hash mismatch")
+			}
+		}
+		now, err := time.Parse(amzDateFormat, amzDate)
+		if err != nil {
+			return err
+		}
+		headers := map[string]string{}
+		for _, name := range strings.Split(signedHeaders, ";") {
+			if name == "host" {
+				headers[name] = r.Host
+				continue
+			}
+			headers[name] = r.Header.Get(name)
+		}
+		want, _ := s.signature(r.Method, r.URL, query, headers, payloadHash, now)
+		if want != signature {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+}
+
+func newTestClient(t *testing.T) (*Client, *s3test.Server) {
+	t.Helper()
+	srv := s3test.NewServer(testBucket)
+	t.Cleanup(srv.Close)
+	client, err := NewClient(Config{Endpoint: srv.URL, AccessKey: testAccessKey, SecretKey: testSecretKey, Bucket: testBucket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Verify = verifier(client.signer)
+	return client, srv
+}
+
+func TestPresignPutRoundTrip(t *testing.T) {
+	client, srv := newTestClient(t)
+	key := "image/avatar/user-1/a b.png"
+	u := client.PresignPut(key, "image/png", time.Minute)
+
+	req, _ := http.NewRequest(http.MethodPut, u, strings.NewReader("png-bytes"))
+	req.Header.Set("Content-Type", "image/png")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("presigned PUT status = %d", resp.StatusCode)
+	}
+	obj, ok := srv.Object(key)
+	if !ok || string(obj.Data) != "png-bytes" || obj.ContentType != "image/png" {
+		t.Fatalf("stored object = %+v, %v", obj, ok)
+	}
+
+	info, err := client.StatObject(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != int64(len("png-bytes")) || info.ContentType != "image/png" || info.ETag == "" {
+		t.Fatalf("StatObject = %+v", info)
+	}
+}
+
+func TestPresignPutRejectsChangedContentType(t *testing.T) {
+	client, _ := newTestClient(t)
+	u := client.PresignPut("image/avatar/user-1/x.png", "image/png", time.Minute)
+
+	req, _ := http.NewRequest(http.MethodPut, u, strings.NewReader("<html>"))
+	req.Header.Set("Content-Type", "text/html")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestPresignGet(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.Put("export/user-1.zip", []byte("zip"), "application/zip")
+
+	resp, err := http.Get(client.PresignGet("export/user-1.zip", time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "zip" {
+		t.Fatalf("presigned GET status = %d body = %q", resp.StatusCode, body)
+	}
+}
+
+func TestPutGetDelete(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+	key := "image/avatar/user-1/a_128.jpg"
+
+	if err := client.PutObject(ctx, key, []byte("jpeg"), "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	if obj, ok := srv.Object(key); !ok || obj.ContentType != "image/jpeg" {
+		t.Fatalf("stored object = %+v, %v", obj, ok)
+	}
+	rc, err := client.GetObject(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "jpeg" {
+		t.Fatalf("GetObject = %q", data)
+	}
+	if err := client.DeleteObject(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := srv.Object(key); ok {
+		t.Fatal("object still present after delete")
+	}
+}
+
+func TestMissingObject(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := client.StatObject(ctx, "image/avatar/user-1/none.png"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("StatObject err = %v, want ErrNotFound", err)
+	}
+	if _, err := client.GetObject(ctx, "image/avatar/user-1/none.png"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetObject err = %v, want ErrNotFound", err)
+	}
+	// 删除不存在的对象不视为错误
+	if err := client.DeleteObject(ctx, "image/avatar/user-1/none.png"); err != nil {
+		t.Fatalf("DeleteObject err = %v", err)
+	}
+}
+
+func TestWrongSecretRejected(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.Verify = verifier(&signer{accessKey: testAccessKey, secretKey: "other", region: "us-east-1"})
+
+	err := client.PutObject(context.Background(), "k", []byte("x"), "image/png")
+	if err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Fatalf("PutObject err = %v, want 403", err)
+	}
+}
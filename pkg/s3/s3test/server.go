@@ -0,0 +1,104 @@
+// Package s3test 提供内存版 S3 兼容服务，供测试替代 MinIO
+package s3test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Object 存储的对象
+type Object struct {
+	Data        []byte
+	ContentType string
+}
+
+// Server path-style 的单桶 S3 替身，支持 PUT、HEAD、GET、DELETE。
+// 预签名 PUT 若签名了 content-type，请求必须携带 Content-Type 头
+type Server struct {
+	*httptest.Server
+	// Verify 非空时在处理前校验请求（如重算签名），返回错误时响应 403
+	Verify func(r *http.Request) error
+
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]Object
+}
+
+// NewServer 启动替身服务，调用方负责 Close
+func NewServer(bucket string) *Server {
+	s := &Server{bucket: bucket, objects: make(map[string]Object)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Put 直接写入对象，用于准备测试数据
+func (s *Server) Put(key string, data []byte, contentType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = Object{Data: data, ContentType: contentType}
+}
+
+// Object 返回对象及是否存在
+func (s *Server) Object(key string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[key]
+	return obj, ok
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+s.bucket+"/")
+	if !ok || key == "" {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+	if r.Header.Get("Authorization") == "" && r.URL.Query().Get("X-Amz-Signature") == "" {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+	if s.Verify != nil {
+		if err := s.Verify(r); err != nil {
+			http.Error(w, "SignatureDoesNotMatch: "+err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+	switch r.Method {
+	case http.MethodPut:
+		signed := r.URL.Query().Get("X-Amz-SignedHeaders")
+		if strings.Contains(";"+signed+";", ";content-type;") && r.Header.Get("Content-Type") == "" {
+			http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.Put(key, data, r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodHead, http.MethodGet:
+		obj, ok := s.Object(key)
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", obj.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.Data)))
+		w.Header().Set("ETag", `"etag-`+strconv.Itoa(len(obj.Data))+`"`)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(obj.Data)
+		}
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.objects, key)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,84 @@
+package usersearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Document 可被搜索的用户
+type Document struct {
+	UserUUID string
+	Nickname string
+	Handle   string
+	// Followers 粉丝数，匹配程度相同时按粉丝数排序
+	Followers int64
+}
+
+// Query 搜索条件
+type Query struct {
+	Text   string
+	Offset int
+	Limit  int
+}
+
+// Hit 一条搜索结果
+type Hit struct {
+	UserUUID string
+	Score    int
+}
+
+// Backend 搜索后端。默认的 memory 后端在进程内维护索引，多实例部署时各实例独立维护
+type Backend interface {
+	Name() string
+	// Upsert 新增或更新文档
+	Upsert(ctx context.Context, docs ...Document) error
+	// Remove 删除文档
+	Remove(ctx context.Context, userUUIDs ...string) error
+	// Replace 以 docs 整体替换索引（全量重建）
+	Replace(ctx context.Context, docs []Document) error
+	// Search 返回当前页结果和命中总数
+	Search(ctx context.Context, q Query) ([]Hit, int, error)
+}
+
+// Factory 创建搜索后端
+type Factory func() (Backend, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register 注册搜索后端实现，通常在实现包的 init 中调用
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := factories[name]; exists {
+		panic(fmt.Errorf("usersearch: backend %s registered twice", name))
+	}
+	factories[name] = factory
+}
+
+// Open 按名称创建搜索后端
+func Open(name string) (Backend, error) {
+	mu.RLock()
+	factory, ok := factories[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("usersearch: unknown backend %q (registered: %v)", name, Backends())
+	}
+	return factory()
+}
+
+// Backends 已注册的后端名称
+func Backends() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,352 @@
+package usersearch
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// 匹配程度，分值越高排序越靠前；同分按粉丝数排序
+const (
+	scoreExact    = 100 // 用户名、账号或昵称完全一致
+	scorePrefix   = 60  // 用户名或昵称前缀
+	scorePinyin   = 40  // 昵称拼音全拼或首字母前缀
+	scoreContains = 30  // 用户名或昵称包含
+	scoreFuzzy    = 10  // 用户名或昵称前缀存在少量拼写错误
+)
+
+func init() {
+	Register("memory", func() (Backend, error) { return NewMemoryBackend(), nil })
+}
+
+type memoryDoc struct {
+	Document
+	nickname string
+	handle   string
+	pinyin   string
+	initials string
+}
+
+// MemoryBackend 进程内索引：全量文档常驻内存，适合中小规模用户量。
+// 查询先用字/二元组倒排索引筛出候选，再对候选逐个打分，不再对全量文档做编辑距离计算
+type MemoryBackend struct {
+	mu  sync.RWMutex
+	idx *memoryIndex
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{idx: newMemoryIndex(0)}
+}
+
+func (b *MemoryBackend) Name() string { return "memory" }
+
+func (b *MemoryBackend) Upsert(ctx context.Context, docs ...Document) error {
+	prepared := make([]*memoryDoc, 0, len(docs))
+	for _, d := range docs {
+		prepared = append(prepared, newMemoryDoc(d))
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, d := range prepared {
+		b.idx.add(d)
+	}
+	return nil
+}
+
+func (b *MemoryBackend) Remove(ctx context.Context, userUUIDs ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, u := range userUUIDs {
+		b.idx.remove(u)
+	}
+	return nil
+}
+
+func (b *MemoryBackend) Replace(ctx context.Context, docs []Document) error {
+	next := newMemoryIndex(len(docs))
+	for _, d := range docs {
+		next.add(newMemoryDoc(d))
+	}
+	b.mu.Lock()
+	b.idx = next
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *MemoryBackend) Search(ctx context.Context, q Query) ([]Hit, int, error) {
+	text := normalize(q.Text)
+	if text == "" {
+		return nil, 0, nil
+	}
+	type scored struct {
+		doc   *memoryDoc
+		score int
+	}
+	b.mu.RLock()
+	matched := make([]scored, 0)
+	for _, d := range b.idx.candidates(text) {
+		if s := d.score(text); s > 0 {
+			matched = append(matched, scored{doc: d, score: s})
+		}
+	}
+	b.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].score != matched[j].score {
+			return matched[i].score > matched[j].score
+		}
+		if matched[i].doc.Followers != matched[j].doc.Followers {
+			return matched[i].doc.Followers > matched[j].doc.Followers
+		}
+		return matched[i].doc.UserUUID < matched[j].doc.UserUUID
+	})
+	total := len(matched)
+	if q.Offset >= total {
+		return []Hit{}, total, nil
+	}
+	end := total
+	if q.Limit > 0 && q.Offset+q.Limit < end {
+		end = q.Offset + q.Limit
+	}
+	hits := make([]Hit, 0, end-q.Offset)
+	for _, m := range matched[q.Offset:end] {
+		hits = append(hits, Hit{UserUUID: m.doc.UserUUID, Score: m.score})
+	}
+	return hits, total, nil
+}
+
+func newMemoryDoc(d Document) *memoryDoc {
+	m := &memoryDoc{
+		Document: d,
+		nickname: normalize(d.Nickname),
+		handle:   normalize(d.Handle),
+	}
+	if hasHan(d.Nickname) {
+		m.pinyin, m.initials = pinyin(d.Nickname)
+	}
+	return m
+}
+
+func (d *memoryDoc) score(q string) int {
+	switch {
+	case q == d.handle || q == d.nickname:
+		return scoreExact
+	case hasPrefix(d.handle, q) || hasPrefix(d.nickname, q):
+		return scorePrefix
+	case hasPrefix(d.pinyin, q) || hasPrefix(d.initials, q):
+		return scorePinyin
+	case strings.Contains(d.handle, q) || strings.Contains(d.nickname, q):
+		return scoreContains
+	case fuzzyPrefix(d.handle, q) || fuzzyPrefix(d.nickname, q) || fuzzyPrefix(d.pinyin, q):
+		return scoreFuzzy
+	}
+	return 0
+}
+
+// memoryIndex 文档及倒排索引。键为用户名、昵称、拼音、拼音首字母中出现的单字和相邻二元组
+type memoryIndex struct {
+	docs  map[string]*memoryDoc
+	grams map[string]map[string]struct{}
+}
+
+func newMemoryIndex(size int) *memoryIndex {
+	return &memoryIndex{
+		docs:  make(map[string]*memoryDoc, size),
+		grams: make(map[string]map[string]struct{}),
+	}
+}
+
+func (x *memoryIndex) add(d *memoryDoc) {
+	x.remove(d.UserUUID)
+	x.docs[d.UserUUID] = d
+	for _, g := range d.grams() {
+		addPosting(x.grams, g, d.UserUUID)
+	}
+}
+
+func (x *memoryIndex) remove(userUUID string) {
+	d, ok := x.docs[userUUID]
+	if !ok {
+		return
+	}
+	delete(x.docs, userUUID)
+	for _, g := range d.grams() {
+		removePosting(x.grams, g, userUUID)
+	}
+}
+
+// candidates 可能命中 q 的文档，保证不漏掉 score 大于 0 的文档：
+//   - 完全一致、前缀、包含、拼音前缀都要求 q 是某个字段的子串，字段必然含 q 的全部二元组；
+//   - 模糊前缀容忍 k 处编辑，每处编辑最多破坏 3 个二元组位置（相邻互换），
+//     因此至少保留 去重二元组数-3k 个（不少于 1 个）；q 只有 4 个字时中间互换可能破坏全部二元组，
+//     额外用互换后的二元组查找
+func (x *memoryIndex) candidates(q string) []*memoryDoc {
+	seen := make(map[string]struct{})
+	var res []*memoryDoc
+	collect := func(userUUID string) {
+		if _, dup := seen[userUUID]; !dup {
+			seen[userUUID] = struct{}{}
+			res = append(res, x.docs[userUUID])
+		}
+	}
+	qr := []rune(q)
+	if len(qr) == 1 {
+		for u := range x.grams[q] {
+			collect(u)
+		}
+		return res
+	}
+
+	probes := bigrams(qr)
+	need := len(probes)
+	if k := fuzzyTolerance(len(qr)); k > 0 {
+		need -= 3 * k
+		if need < 1 {
+			need = 1
+		}
+		if len(qr)-1 <= 3*k {
+			swapped := make([]rune, len(qr))
+			for i := 0; i+1 < len(qr); i++ {
+				copy(swapped, qr)
+				swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+				probes = appendDistinct(probes, bigrams(swapped)...)
+			}
+		}
+	}
+	counts := make(map[string]int)
+	for _, g := range probes {
+		for u := range x.grams[g] {
+			counts[u]++
+		}
+	}
+	for u, n := range counts {
+		if n >= need {
+			collect(u)
+		}
+	}
+	return res
+}
+
+// grams 文档的索引键：各字段的单字和相邻二元组（去重）
+func (d *memoryDoc) grams() []string {
+	var keys []string
+	for _, field := range []string{d.handle, d.nickname, d.pinyin, d.initials} {
+		runes := []rune(field)
+		for _, r := range runes {
+			keys = appendDistinct(keys, string(r))
+		}
+		keys = appendDistinct(keys, bigrams(runes)...)
+	}
+	return keys
+}
+
+// bigrams 相邻二元组（去重）
+func bigrams(runes []rune) []string {
+	var res []string
+	for i := 0; i+1 < len(runes); i++ {
+		res = appendDistinct(res, string(runes[i:i+2]))
+	}
+	return res
+}
+
+func appendDistinct(list []string, items ...string) []string {
+	for _, item := range items {
+		dup := false
+		for _, v := range list {
+			if v == item {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func addPosting(index map[string]map[string]struct{}, key, userUUID string) {
+	set, ok := index[key]
+	if !ok {
+		set = make(map[string]struct{})
+		index[key] = set
+	}
+	set[userUUID] = struct{}{}
+}
+
+func removePosting(index map[string]map[string]struct{}, key, userUUID string) {
+	if set, ok := index[key]; ok {
+		delete(set, userUUID)
+		if len(set) == 0 {
+			delete(index, key)
+		}
+	}
+}
+
+func hasPrefix(s, prefix string) bool {
+	return s != "" && strings.HasPrefix(s, prefix)
+}
+
+// fuzzyTolerance 长度为 n 的查询词容忍的编辑次数：4 个字符以上容忍 1 处，8 个以上容忍 2 处
+func fuzzyTolerance(n int) int {
+	switch {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	}
+	return 0
+}
+
+// fuzzyPrefix 查询词与 s 的同长前缀编辑距离在 fuzzyTolerance 范围内
+func fuzzyPrefix(s, q string) bool {
+	n := utf8.RuneCountInString(q)
+	maxDist := fuzzyTolerance(n)
+	if maxDist == 0 || s == "" {
+		return false
+	}
+	sr, qr := []rune(s), []rune(q)
+	// 允许被比较的前缀长度在 n±maxDist 之间浮动，以覆盖漏字和多字
+	best := maxDist + 1
+	for l := n - maxDist; l <= n+maxDist; l++ {
+		if l <= 0 || l > len(sr) {
+			continue
+		}
+		if dist := editDistance(sr[:l], qr, maxDist); dist < best {
+			best = dist
+		}
+	}
+	return best <= maxDist
+}
+
+// editDistance 编辑距离（相邻字符互换计 1 次，即 OSA 距离），超过 limit 时提前返回 limit+1
+func editDistance(a, b []rune, limit int) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
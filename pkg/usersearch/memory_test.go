@@ -0,0 +1,213 @@
+package usersearch
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"", "", 2, 0},
+		{"abc", "abc", 2, 0},
+		{"abc", "abd", 2, 1},   // 替换
+		{"abc", "abcd", 2, 1},  // 插入
+		{"abcd", "abc", 2, 1},  // 删除
+		{"abcd", "acbd", 2, 1}, // 相邻互换
+		{"ca", "abc", 3, 3},    // OSA 不允许互换后再编辑
+		{"kitten", "sitting", 3, 3},
+		{"kitten", "sitting", 1, 2}, // 超出 limit 提前返回 limit+1
+		{"张三丰", "张三峰", 1, 1},
+	}
+	for _, tc := range cases {
+		if got := editDistance([]rune(tc.a), []rune(tc.b), tc.limit); got != tc.want {
+			t.Errorf("editDistance(%q, %q, %d) = %d, want %d", tc.a, tc.b, tc.limit, got, tc.want)
+		}
+	}
+}
+
+func TestFuzzyPrefix(t *testing.T) {
+	cases := []struct {
+		s, q string
+		want bool
+	}{
+		{"alice_wonder", "alcie", true},        // 互换
+		{"alice_wonder", "alixe", true},        // 替换
+		{"alice_wonder", "alce", true},         // 漏字
+		{"alice_wonder", "allice", true},       // 多字
+		{"alice_wonder", "abc", false},         // 3 个字符以下不做模糊匹配
+		{"alice_wonder", "axxce", false},       // 5 个字符只容忍 1 处
+		{"alice_wonder", "alxce_wxnder", true}, // 8 个以上容忍 2 处
+		{"alice_wonder", "axxce_wxnder", false},
+		{"", "alice", false},
+		{"bob", "alice", false},
+	}
+	for _, tc := range cases {
+		if got := fuzzyPrefix(tc.s, tc.q); got != tc.want {
+			t.Errorf("fuzzyPrefix(%q, %q) = %v, want %v", tc.s, tc.q, got, tc.want)
+		}
+	}
+}
+
+func TestPinyin(t *testing.T) {
+	cases := []struct {
+		in, full, initials string
+	}{
+		{"张三", "zhangsan", "zs"},
+		{"李小龙", "lixiaolong", "lxl"},
+		{"张San", "zhangsan", "zsan"},
+		{"王 五 1", "wangwu1", "ww1"},
+		{"ＡＢ王", "abwang", "abw"}, // 全角字母
+	}
+	for _, tc := range cases {
+		full, initials := pinyin(tc.in)
+		if full != tc.full || initials != tc.initials {
+			t.Errorf("pinyin(%q) = %q, %q; want %q, %q", tc.in, full, initials, tc.full, tc.initials)
+		}
+	}
+	if hasHan("alice") || !hasHan("alice张") {
+		t.Error("hasHan mismatch")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := normalize(" Ａlice-Wonder_01！"); got != "alicewonder_01" {
+		t.Fatalf("normalize = %q", got)
+	}
+}
+
+func search(t *testing.T, b *MemoryBackend, text string) []string {
+	t.Helper()
+	hits, total, err := b.Search(context.Background(), Query{Text: text})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != len(hits) {
+		t.Fatalf("total = %d, hits = %d", total, len(hits))
+	}
+	res := make([]string, 0, len(hits))
+	for _, h := range hits {
+		res = append(res, fmt.Sprintf("%s:%d", h.UserUUID, h.Score))
+	}
+	return res
+}
+
+func TestMemoryBackendScoring(t *testing.T) {
+	b := NewMemoryBackend()
+	ctx := context.Background()
+	b.Replace(ctx, []Document{
+		{UserUUID: "u1", Nickname: "张三", Handle: "zhangsan", Followers: 5},
+		{UserUUID: "u2", Nickname: "Alice", Handle: "alice_wonder", Followers: 10},
+		{UserUUID: "u3", Nickname: "Malice", Handle: "m_alice", Followers: 1},
+	})
+	cases := map[string][]string{
+		"alice":   {"u2:100", "u3:30"}, // 昵称一致 > 包含
+		"alice_w": {"u2:60"},           // 前缀
+		"zs":      {"u1:40"},           // 拼音首字母
+		"zhangs":  {"u1:60"},           // 用户名前缀优先于拼音
+		"alcie":   {"u2:10"},           // 模糊前缀
+		"张":       {"u1:60"},           // 单字
+		"nobody":  {},
+	}
+	for q, want := range cases {
+		got := search(t, b, q)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("Search(%q) = %v, want %v", q, got, want)
+		}
+	}
+
+	b.Upsert(ctx, Document{UserUUID: "u2", Nickname: "Bob", Handle: "bob"})
+	if got := search(t, b, "alice_w"); len(got) != 0 {
+		t.Errorf("stale index entries after Upsert: %v", got)
+	}
+	b.Remove(ctx, "u1")
+	if got := search(t, b, "zhangsan"); len(got) != 0 {
+		t.Errorf("stale entry after Remove: %v", got)
+	}
+	if len(b.idx.grams) == 0 {
+		t.Fatal("index unexpectedly empty")
+	}
+	b.Remove(ctx, "u2", "u3")
+	if len(b.idx.grams) != 0 {
+		t.Errorf("postings left after removing all docs: %d grams", len(b.idx.grams))
+	}
+}
+
+// 候选筛选不能漏掉任何线性扫描会命中的文档
+func TestMemoryIndexMatchesLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	alphabet := []rune("abcdeab_")
+	word := func(n int) string {
+		r := make([]rune, n)
+		for i := range r {
+			r[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+		return string(r)
+	}
+	han := []string{"张", "三", "李", "四", "王"}
+	docs := make([]Document, 0, 300)
+	for i := 0; i < 300; i++ {
+		nick := word(3 + rng.Intn(8))
+		if i%5 == 0 {
+			nick = han[rng.Intn(len(han))] + han[rng.Intn(len(han))] + word(rng.Intn(3))
+		}
+		docs = append(docs, Document{
+			UserUUID: fmt.Sprintf("u%03d", i),
+			Nickname: nick,
+			Handle:   word(4 + rng.Intn(8)),
+		})
+	}
+	b := NewMemoryBackend()
+	b.Replace(context.Background(), docs)
+
+	queries := []string{"abcd", "acbd", "badc", "zhang", "zs", "张"}
+	for i := 0; i < 400; i++ {
+		q := word(1 + rng.Intn(10))
+		// 从文档中截取片段再随机编辑，保证有一定比例的模糊命中
+		if i%2 == 0 {
+			src := []rune(docs[rng.Intn(len(docs))].Handle)
+			n := 4 + rng.Intn(len(src)-3)
+			if n > len(src) {
+				n = len(src)
+			}
+			r := append([]rune(nil), src[:n]...)
+			j := rng.Intn(len(r) - 1)
+			switch rng.Intn(3) {
+			case 0:
+				r[j], r[j+1] = r[j+1], r[j]
+			case 1:
+				r[j] = alphabet[rng.Intn(len(alphabet))]
+			case 2:
+				r = append(r[:j], r[j+1:]...)
+			}
+			q = string(r)
+		}
+		queries = append(queries, q)
+	}
+	for _, q := range queries {
+		text := normalize(q)
+		var want []string
+		for _, d := range b.idx.docs {
+			if d.score(text) > 0 {
+				want = append(want, d.UserUUID)
+			}
+		}
+		var got []string
+		for _, d := range b.idx.candidates(text) {
+			if d.score(text) > 0 {
+				got = append(got, d.UserUUID)
+			}
+		}
+		sort.Strings(want)
+		sort.Strings(got)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("query %q: indexed %v, linear %v", q, got, want)
+		}
+	}
+}
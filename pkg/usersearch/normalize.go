@@ -0,0 +1,54 @@
+package usersearch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gosimple/unidecode"
+	"golang.org/x/text/width"
+)
+
+// normalize 全角转半角、转小写并去掉空白与标点，用于索引项与查询词
+func normalize(s string) string {
+	s = width.Fold.String(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// hasHan 是否包含汉字
+func hasHan(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// pinyin 返回汉字昵称的全拼（zhangsan）和首字母（zs），非汉字字符原样保留
+func pinyin(s string) (full, initials string) {
+	var fb, ib strings.Builder
+	for _, r := range width.Fold.String(s) {
+		if unicode.Is(unicode.Han, r) {
+			syllable := strings.ToLower(strings.TrimSpace(unidecode.Unidecode(string(r))))
+			if syllable == "" {
+				continue
+			}
+			fb.WriteString(syllable)
+			ib.WriteByte(syllable[0])
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			lower := unicode.ToLower(r)
+			fb.WriteRune(lower)
+			ib.WriteRune(lower)
+		}
+	}
+	return fb.String(), ib.String()
+}
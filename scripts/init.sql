@@ -9,15 +9,196 @@ CREATE TABLE IF NOT EXISTS `user` (
     `account` VARCHAR(50) NOT NULL COMMENT '用户账号',
     `password` VARCHAR(255) NOT NULL COMMENT '用户密码（加密后）',
     `avatar_url` VARCHAR(512) DEFAULT '' COMMENT '用户头像URL',
+    `avatar_variants` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '已生成的头像尺寸，逗号分隔',
+    `handle` VARCHAR(32) NULL DEFAULT NULL COMMENT '公开用户名（@handle），大小写不敏感唯一',
+    `handle_changed_at` TIMESTAMP NULL DEFAULT NULL COMMENT '用户名最近修改时间',
+    `version` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '乐观锁版本号',
     `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
     `is_deleted` TINYINT UNSIGNED DEFAULT 0 COMMENT '是否删除：0-未删除，1-已删除',
     PRIMARY KEY (`id`),
     UNIQUE KEY `uk_user_uuid` (`user_uuid`),
     UNIQUE KEY `uk_account` (`account`),
+    UNIQUE KEY `uk_handle` (`handle`),
     KEY `idx_created_at` (`created_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户表';
 
+-- 个人数据导出任务表
+CREATE TABLE IF NOT EXISTS `user_export` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `export_uuid` VARCHAR(36) NOT NULL COMMENT '导出任务UUID',
+    `user_uuid` VARCHAR(36) NOT NULL COMMENT '用户UUID',
+    `status` VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending/running/done/failed/expired',
+    `object_key` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '导出包存储key',
+    `file_size` BIGINT NOT NULL DEFAULT 0 COMMENT '导出包大小（字节）',
+    `attempts` INT NOT NULL DEFAULT 0 COMMENT '已尝试次数',
+    `error_msg` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '最近一次失败原因',
+    `finished_at` TIMESTAMP NULL DEFAULT NULL COMMENT '完成时间',
+    `expires_at` TIMESTAMP NULL DEFAULT NULL COMMENT '导出包过期时间',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `is_deleted` TINYINT UNSIGNED DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_export_uuid` (`export_uuid`),
+    KEY `idx_user_status` (`user_uuid`, `status`),
+    KEY `idx_status_expires` (`status`, `expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='个人数据导出任务表';
+
+-- 安全审计事件表（登录历史等）
+CREATE TABLE IF NOT EXISTS `user_security_event` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `user_uuid` VARCHAR(36) NOT NULL DEFAULT '' COMMENT '用户UUID，未知账号登录失败时为空',
+    `event_type` VARCHAR(32) NOT NULL COMMENT '事件类型：login_success/login_failure/token_refresh/logout/password_change/token_revoked/2fa_*',
+    `result` VARCHAR(16) NOT NULL COMMENT 'success/failure',
+    `ip` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '客户端IP',
+    `user_agent` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '客户端UA',
+    `request_id` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '请求ID',
+    `metadata` JSON NULL COMMENT '附加信息',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_user_created` (`user_uuid`, `id`),
+    KEY `idx_ip` (`ip`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='安全审计事件表';
+
+-- 用户登录设备表（新设备 / 异地登录检测）
+CREATE TABLE IF NOT EXISTS `user_device` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `user_uuid` VARCHAR(36) NOT NULL COMMENT '用户UUID',
+    `device_id` VARCHAR(64) NOT NULL COMMENT '设备标识',
+    `user_agent` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '最近一次登录UA',
+    `last_ip` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '最近一次登录IP',
+    `last_region` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '最近一次登录地区',
+    `last_seen_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '最近一次登录时间',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '首次登录时间',
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `is_deleted` TINYINT UNSIGNED DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_device` (`user_uuid`, `device_id`),
+    KEY `idx_user_last_seen` (`user_uuid`, `last_seen_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户登录设备表';
+
+-- 用户名历史表（旧用户名在保留期内跳转到当前用户，且不可被他人占用）
+CREATE TABLE IF NOT EXISTS `user_handle_history` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `user_uuid` VARCHAR(36) NOT NULL COMMENT '用户UUID',
+    `handle` VARCHAR(32) NOT NULL COMMENT '曾用用户名',
+    `redirect_until` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '跳转保留截止时间',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `is_deleted` TINYINT UNSIGNED DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_handle` (`handle`),
+    KEY `idx_user_uuid` (`user_uuid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户名历史表';
+
+-- 头像处理任务表
+CREATE TABLE IF NOT EXISTS `user_media_job` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `user_uuid` VARCHAR(36) NOT NULL COMMENT '用户UUID',
+    `object_key` VARCHAR(512) NOT NULL COMMENT '原图对象key',
+    `status` VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending/running/done/failed',
+    `attempts` INT NOT NULL DEFAULT 0 COMMENT '已尝试次数',
+    `error_msg` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '最近一次失败原因',
+    `next_run_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '下次执行时间（重试退避）',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `is_deleted` TINYINT UNSIGNED DEFAULT 0,
+    PRIMARY KEY (`id`),
+    KEY `idx_status_next_run` (`status`, `next_run_at`),
+    KEY `idx_user_uuid` (`user_uuid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='头像处理任务表';
+
+-- 用户隐私设置表（无记录时按全部公开处理）
+CREATE TABLE IF NOT EXISTS `user_privacy_setting` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `user_uuid` VARCHAR(36) NOT NULL COMMENT '用户UUID',
+    `private_account` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '私密账号：关注需经本人同意，关系列表仅粉丝可见',
+    `hide_follower_list` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '粉丝列表仅本人可见',
+    `hide_following_list` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '关注列表仅本人可见',
+    `hide_counts` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '粉丝数、关注数仅本人可见',
+    `profile_visibility` VARCHAR(16) NOT NULL DEFAULT 'public' COMMENT '主页可见范围：public/followers/private',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `is_deleted` TINYINT UNSIGNED DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_uuid` (`user_uuid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户隐私设置表';
+
+-- 用户偏好设置表（仅保存显式设置过的项，默认值见配置 preferences.schema）
+CREATE TABLE IF NOT EXISTS `user_preference` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `user_uuid` VARCHAR(36) NOT NULL COMMENT '用户UUID',
+    `pref_key` VARCHAR(64) NOT NULL COMMENT '偏好项',
+    `pref_value` VARCHAR(1024) NOT NULL COMMENT '偏好值（JSON）',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `is_deleted` TINYINT UNSIGNED DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_key` (`user_uuid`, `pref_key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户偏好设置表';
+
+-- 事件发件箱表（与业务数据同事务写入，由中继组件投递到 Kafka）
+CREATE TABLE IF NOT EXISTS `event_outbox` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `event_id` VARCHAR(36) NOT NULL COMMENT '事件ID，消费方据此去重',
+    `topic` VARCHAR(128) NOT NULL COMMENT 'Kafka topic',
+    `msg_key` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '消息key（用户UUID），决定分区与顺序',
+    `payload` TEXT NOT NULL COMMENT '事件JSON',
+    `status` VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending/sent/failed',
+    `attempts` INT NOT NULL DEFAULT 0 COMMENT '投递失败次数',
+    `error_msg` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '最近一次失败原因',
+    `sent_at` TIMESTAMP NULL DEFAULT NULL COMMENT '发布时间',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `is_deleted` TINYINT UNSIGNED DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_event_id` (`event_id`),
+    KEY `idx_status_id` (`status`, `id`),
+    KEY `idx_status_sent_at` (`status`, `sent_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='事件发件箱表';
+
+-- 拉黑/屏蔽关系表
+CREATE TABLE IF NOT EXISTS `user_block` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `user_uuid` VARCHAR(36) NOT NULL COMMENT '操作人UUID',
+    `target_uuid` VARCHAR(36) NOT NULL COMMENT '被拉黑/屏蔽的用户UUID',
+    `kind` VARCHAR(16) NOT NULL COMMENT '关系类型：block/mute',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `is_deleted` TINYINT UNSIGNED DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_target_kind` (`user_uuid`, `target_uuid`, `kind`),
+    KEY `idx_user_kind_created` (`user_uuid`, `kind`, `created_at`),
+    KEY `idx_target_kind` (`target_uuid`, `kind`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户拉黑/屏蔽关系表';
+
+-- 推荐关注忽略记录表（用户点击“不感兴趣”后不再推荐该账号）
+CREATE TABLE IF NOT EXISTS `user_recommend_dismissal` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `user_uuid` VARCHAR(36) NOT NULL COMMENT '操作人UUID',
+    `target_uuid` VARCHAR(36) NOT NULL COMMENT '被忽略的推荐用户UUID',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `is_deleted` TINYINT UNSIGNED DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_target` (`user_uuid`, `target_uuid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='推荐关注忽略记录表';
+
+-- 关系操作审计表（移除粉丝等），与安全事件分开，不出现在用户的登录历史中
+CREATE TABLE IF NOT EXISTS `user_relation_audit` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `user_uuid` VARCHAR(36) NOT NULL COMMENT '操作人UUID',
+    `target_uuid` VARCHAR(36) NOT NULL COMMENT '被操作的用户UUID',
+    `action` VARCHAR(32) NOT NULL COMMENT '操作类型：follower_removed',
+    `ip` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '客户端IP',
+    `user_agent` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '客户端UA',
+    `request_id` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '请求ID',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_user_created` (`user_uuid`, `id`),
+    KEY `idx_target_created` (`target_uuid`, `id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='关系操作审计表';
+
 -- 插入测试数据
 INSERT INTO `user` (`user_uuid`, `account`, `password`) VALUES 
 ('550e8400-e29b-41d4-a716-446655440000', 'testuser', '$2a$10$N9qo8uLOickgx2ZMRZoMye7I6ZQ7hD13wK1Y9/1p92ledvHSKlSaa'), -- 密码: secret